@@ -19,20 +19,9 @@ import (
 func Start() {
 	cfgs := configs.ReadConfigs()
 
-	server := initServer(cfgs)
-
-	handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
-		return server
-	}, &mcp.StreamableHTTPOptions{JSONResponse: true})
-
-	mux := http.NewServeMux()
-	mux.Handle(cfgs.ServerConfig.Path, handler)
-
-	wrappedHandler := middleware.LoggingHandler(mux)
-
 	httpServer := &http.Server{
 		Addr:    cfgs.ServerConfig.BindAddress,
-		Handler: wrappedHandler,
+		Handler: NewHandler(cfgs),
 	}
 
 	log.Printf("Google Ads MCP server (streamable HTTP) listening on path %s (bind %s)", cfgs.ServerConfig.Path, cfgs.ServerConfig.BindAddress)
@@ -60,3 +49,18 @@ func Start() {
 		}
 	}
 }
+
+// NewHandler returns the streamable HTTP handler serving every tool at the configured
+// path. Tests mount it on an httptest server in front of a fake Google Ads API.
+func NewHandler(cfgs configs.Configs) http.Handler {
+	server := initServer(cfgs)
+
+	handler := mcp.NewStreamableHTTPHandler(func(r *http.Request) *mcp.Server {
+		return server
+	}, &mcp.StreamableHTTPOptions{JSONResponse: true})
+
+	mux := http.NewServeMux()
+	mux.Handle(cfgs.ServerConfig.Path, handler)
+
+	return middleware.LoggingHandler(mux)
+}
@@ -0,0 +1,68 @@
+package app_test
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"google-ads-mcp/internal/app/configs"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+	"google-ads-mcp/internal/testing/fakeads"
+	"google-ads-mcp/internal/tools/searchcampaigns"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newCachedSession is newSession with the search result cache enabled.
+func newCachedSession(t *testing.T) (*fakeads.Server, *mcp.ClientSession) {
+	t.Helper()
+
+	fake := fakeads.New()
+	t.Cleanup(fake.Close)
+
+	cfgs := fake.Configs()
+	cfgs.CacheConfig = configs.CacheConfig{MaxEntries: 16, TodayTTL: time.Hour, PastTTL: time.Hour}
+	return fake, connect(t, cfgs)
+}
+
+// searchCache returns the cache status of a search_campaigns call for customerID.
+func searchCache(t *testing.T, session *mcp.ClientSession, customerID string) string {
+	t.Helper()
+
+	output := decode[searchcampaigns.ToolOutput](t, callTool(t, session, "search_campaigns", map[string]any{"customer_id": customerID}))
+	return output.Cache
+}
+
+func TestCacheEvictedByMutate(t *testing.T) {
+	mutates := map[string]struct {
+		tool      string
+		arguments map[string]any
+	}{
+		"set_campaign_status":    {"set_campaign_status", map[string]any{"campaign_ids": []string{"1"}, "status": "PAUSED"}},
+		"update_campaign_budget": {"update_campaign_budget", map[string]any{"campaign_id": "1", "amount_micros": 12_000_000}},
+		"add_negative_keywords":  {"add_negative_keywords", map[string]any{"campaign_id": "1", "keywords": []map[string]any{{"text": "free"}}}},
+	}
+
+	for name, mutate := range mutates {
+		t.Run(name, func(t *testing.T) {
+			fake, session := newCachedSession(t)
+			fake.AddResourceRows("campaign", budgetRow(false, 1))
+
+			// Every spelling of the customer ID shares one entry
+			if status := searchCache(t, session, "111-111-1111"); status != string(searchcache.StatusMiss) {
+				t.Fatalf("first search cache = %q, want miss", status)
+			}
+			if status := searchCache(t, session, "customers/1111111111"); status != string(searchcache.StatusHit) {
+				t.Fatalf("repeated search cache = %q, want hit", status)
+			}
+
+			arguments := map[string]any{"customer_id": clientID}
+			maps.Copy(arguments, mutate.arguments)
+			decode[map[string]any](t, callTool(t, session, mutate.tool, arguments))
+
+			if status := searchCache(t, session, "111-111-1111"); status != string(searchcache.StatusMiss) {
+				t.Errorf("search cache after %s = %q, want miss", mutate.tool, status)
+			}
+		})
+	}
+}
@@ -3,13 +3,36 @@ package configs
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"google-ads-mcp/internal/infrastructure/api/transport"
+)
+
+const (
+	defaultMaxFetchAllRows        = 10000
+	defaultMaxBudgetChangePercent = 50.0
+	defaultFanOutMaxConcurrency   = 8
+	defaultFanOutAccountTimeout   = 60 * time.Second
+	defaultTransport              = "rest"
+	defaultCacheMaxEntries        = 256
+	defaultCacheTodayTTL          = 5 * time.Minute
+	defaultCachePastTTL           = time.Hour
 )
 
 type Configs struct {
 	ServerConfig    ServerConfig
 	GoogleAdsConfig GoogleAdsConfig
+	SearchConfig    SearchConfig
+	BudgetConfig    BudgetConfig
+	FanOutConfig    FanOutConfig
+	FXConfig        FXConfig
+	ResponseConfig  ResponseConfig
+	TransportConfig TransportConfig
+	CacheConfig     CacheConfig
 }
 
 type ServerConfig struct {
@@ -18,6 +41,69 @@ type ServerConfig struct {
 	Path        string
 }
 
+// SearchConfig holds the limits applied to Google Ads search requests
+type SearchConfig struct {
+	// MaxFetchAllRows caps the number of rows collected when a tool walks every page
+	MaxFetchAllRows int
+}
+
+// BudgetConfig holds the guardrails applied to campaign budget updates
+type BudgetConfig struct {
+	// MaxChangePercent rejects updates that move a budget by more than this percentage
+	MaxChangePercent float64
+	// MaxChangeMicros rejects updates that move a budget by more than this amount; 0 disables the
+	// check, which also rejects every change to a budget of 0 as no percentage applies to it
+	MaxChangeMicros int64
+}
+
+// FanOutConfig holds the limits applied when a tool queries several accounts
+type FanOutConfig struct {
+	// MaxConcurrency is the number of accounts queried at the same time
+	MaxConcurrency int
+	// AccountTimeout is the deadline for each account; slower accounts are reported as failed
+	AccountTimeout time.Duration
+}
+
+// FXConfig holds the exchange rates used to report amounts in a single currency
+type FXConfig struct {
+	// RatesFile is a local JSON or CSV file of exchange rates; empty disables conversion
+	RatesFile string
+	// ReportingCurrency is the default currency amounts are converted to; tools can override it
+	ReportingCurrency string
+}
+
+// ResponseConfig holds the size budget applied to tool responses
+type ResponseConfig struct {
+	// MaxBytes truncates the rows of responses larger than this many bytes; 0 disables the limit
+	MaxBytes int
+	// MaxTokens truncates the rows of responses larger than this many estimated tokens; 0 disables the limit
+	MaxTokens int
+}
+
+// TransportConfig selects how requests are sent to the Google Ads API
+type TransportConfig struct {
+	// Kind is "rest" for protobuf JSON over HTTP or "grpc" for the gRPC interface
+	Kind string
+	// BaseURL and APIVersion locate the REST interface; empty values use the defaults.
+	// The gRPC interface only speaks transport.GRPCAPIVersion
+	BaseURL    string
+	APIVersion string
+	// CassetteMode is "record" to save REST traffic to CassetteFile or "replay" to answer from it; empty disables cassettes
+	CassetteMode string
+	CassetteFile string
+}
+
+// CacheConfig holds the search result cache settings
+type CacheConfig struct {
+	// MaxEntries is the number of search results kept; 0 disables the cache
+	MaxEntries int
+	// TodayTTL and PastTTL are the lifetimes of results whose date range includes today or ended before it
+	TodayTTL time.Duration
+	PastTTL  time.Duration
+	// Dir keeps cached results on disk across restarts; empty keeps them in memory only
+	Dir string
+}
+
 type GoogleAdsConfig struct {
 	CustomerID         string
 	DeveloperToken     string
@@ -52,6 +138,41 @@ func ReadConfigs() Configs {
 
 	bindAddress := fmt.Sprintf("%s:%s", host, port)
 
+	searchConfig, err := readSearchConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read search configuration: %v", err))
+	}
+
+	budgetConfig, err := readBudgetConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read budget configuration: %v", err))
+	}
+
+	fanOutConfig, err := readFanOutConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read fan-out configuration: %v", err))
+	}
+
+	fxConfig, err := readFXConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read FX configuration: %v", err))
+	}
+
+	responseConfig, err := readResponseConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read response configuration: %v", err))
+	}
+
+	transportConfig, err := readTransportConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read transport configuration: %v", err))
+	}
+
+	cacheConfig, err := readCacheConfig()
+	if err != nil {
+		panic(fmt.Sprintf("failed to read cache configuration: %v", err))
+	}
+
 	// Read unified Google Ads configuration - try local file first, then Google Secret Manager
 	googleAdsConfig, err := readGoogleAdsConfig()
 	if err != nil {
@@ -65,7 +186,209 @@ func ReadConfigs() Configs {
 			Path:        path,
 		},
 		GoogleAdsConfig: googleAdsConfig,
+		SearchConfig:    searchConfig,
+		BudgetConfig:    budgetConfig,
+		FanOutConfig:    fanOutConfig,
+		FXConfig:        fxConfig,
+		ResponseConfig:  responseConfig,
+		TransportConfig: transportConfig,
+		CacheConfig:     cacheConfig,
+	}
+}
+
+// readSearchConfig reads the search limits from environment variables
+func readSearchConfig() (SearchConfig, error) {
+	maxFetchAllRows := defaultMaxFetchAllRows
+	if raw := os.Getenv("MAX_FETCH_ALL_ROWS"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			return SearchConfig{}, fmt.Errorf("MAX_FETCH_ALL_ROWS must be a positive integer, got %q", raw)
+		}
+		maxFetchAllRows = value
+	}
+
+	return SearchConfig{
+		MaxFetchAllRows: maxFetchAllRows,
+	}, nil
+}
+
+// readBudgetConfig reads the budget update guardrails from environment variables
+func readBudgetConfig() (BudgetConfig, error) {
+	maxChangePercent := defaultMaxBudgetChangePercent
+	if raw := os.Getenv("MAX_BUDGET_CHANGE_PERCENT"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value <= 0 {
+			return BudgetConfig{}, fmt.Errorf("MAX_BUDGET_CHANGE_PERCENT must be a positive number, got %q", raw)
+		}
+		maxChangePercent = value
+	}
+
+	var maxChangeMicros int64
+	if raw := os.Getenv("MAX_BUDGET_CHANGE_MICROS"); raw != "" {
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || value < 0 {
+			return BudgetConfig{}, fmt.Errorf("MAX_BUDGET_CHANGE_MICROS must be a non-negative integer, got %q", raw)
+		}
+		maxChangeMicros = value
+	}
+
+	return BudgetConfig{
+		MaxChangePercent: maxChangePercent,
+		MaxChangeMicros:  maxChangeMicros,
+	}, nil
+}
+
+// readFanOutConfig reads the multi-account fan-out limits from environment variables
+func readFanOutConfig() (FanOutConfig, error) {
+	maxConcurrency := defaultFanOutMaxConcurrency
+	if raw := os.Getenv("FANOUT_MAX_CONCURRENCY"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			return FanOutConfig{}, fmt.Errorf("FANOUT_MAX_CONCURRENCY must be a positive integer, got %q", raw)
+		}
+		maxConcurrency = value
+	}
+
+	accountTimeout := defaultFanOutAccountTimeout
+	if raw := os.Getenv("FANOUT_ACCOUNT_TIMEOUT_SECONDS"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			return FanOutConfig{}, fmt.Errorf("FANOUT_ACCOUNT_TIMEOUT_SECONDS must be a positive integer, got %q", raw)
+		}
+		accountTimeout = time.Duration(value) * time.Second
+	}
+
+	return FanOutConfig{
+		MaxConcurrency: maxConcurrency,
+		AccountTimeout: accountTimeout,
+	}, nil
+}
+
+// readFXConfig reads the exchange rates source and default reporting currency from environment variables
+func readFXConfig() (FXConfig, error) {
+	ratesFile := strings.TrimSpace(os.Getenv("FX_RATES_FILE"))
+	reportingCurrency := strings.ToUpper(strings.TrimSpace(os.Getenv("REPORTING_CURRENCY")))
+
+	if reportingCurrency != "" && ratesFile == "" {
+		return FXConfig{}, fmt.Errorf("REPORTING_CURRENCY requires FX_RATES_FILE")
+	}
+
+	return FXConfig{
+		RatesFile:         ratesFile,
+		ReportingCurrency: reportingCurrency,
+	}, nil
+}
+
+// readResponseConfig reads the tool response size budget from environment variables
+func readResponseConfig() (ResponseConfig, error) {
+	var maxBytes int
+	if raw := os.Getenv("MAX_RESPONSE_BYTES"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return ResponseConfig{}, fmt.Errorf("MAX_RESPONSE_BYTES must be a non-negative integer, got %q", raw)
+		}
+		maxBytes = value
+	}
+
+	var maxTokens int
+	if raw := os.Getenv("MAX_RESPONSE_TOKENS"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return ResponseConfig{}, fmt.Errorf("MAX_RESPONSE_TOKENS must be a non-negative integer, got %q", raw)
+		}
+		maxTokens = value
+	}
+
+	return ResponseConfig{
+		MaxBytes:  maxBytes,
+		MaxTokens: maxTokens,
+	}, nil
+}
+
+// readTransportConfig reads the Google Ads API transport from environment variables
+func readTransportConfig() (TransportConfig, error) {
+	kind := strings.ToLower(strings.TrimSpace(os.Getenv("GOOGLE_ADS_TRANSPORT")))
+	switch kind {
+	case "":
+		kind = defaultTransport
+	case "rest", "grpc":
+	default:
+		return TransportConfig{}, fmt.Errorf("GOOGLE_ADS_TRANSPORT must be rest or grpc, got %q", kind)
+	}
+
+	baseURL := strings.TrimSpace(os.Getenv("GOOGLE_ADS_BASE_URL"))
+	if baseURL != "" {
+		parsed, err := url.Parse(baseURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return TransportConfig{}, fmt.Errorf("GOOGLE_ADS_BASE_URL must be an absolute URL, got %q", baseURL)
+		}
+	}
+
+	// The gRPC stubs are generated for one version; another one cannot be honored
+	apiVersion := strings.TrimSpace(os.Getenv("GOOGLE_ADS_API_VERSION"))
+	if kind == "grpc" && apiVersion != "" && apiVersion != transport.GRPCAPIVersion {
+		return TransportConfig{}, fmt.Errorf("GOOGLE_ADS_API_VERSION %s is not supported by the grpc transport, which speaks %s", apiVersion, transport.GRPCAPIVersion)
+	}
+
+	cassetteMode := strings.ToLower(strings.TrimSpace(os.Getenv("GOOGLE_ADS_CASSETTE_MODE")))
+	cassetteFile := strings.TrimSpace(os.Getenv("GOOGLE_ADS_CASSETTE_FILE"))
+	switch cassetteMode {
+	case "":
+	case "record", "replay":
+		if kind != "rest" {
+			return TransportConfig{}, fmt.Errorf("GOOGLE_ADS_CASSETTE_MODE requires the rest transport, got %q", kind)
+		}
+		if cassetteFile == "" {
+			return TransportConfig{}, fmt.Errorf("GOOGLE_ADS_CASSETTE_FILE is required when GOOGLE_ADS_CASSETTE_MODE is %s", cassetteMode)
+		}
+	default:
+		return TransportConfig{}, fmt.Errorf("GOOGLE_ADS_CASSETTE_MODE must be record or replay, got %q", cassetteMode)
 	}
+
+	return TransportConfig{
+		Kind:         kind,
+		BaseURL:      baseURL,
+		APIVersion:   apiVersion,
+		CassetteMode: cassetteMode,
+		CassetteFile: cassetteFile,
+	}, nil
+}
+
+// readCacheConfig reads the search result cache settings from environment variables
+func readCacheConfig() (CacheConfig, error) {
+	maxEntries := defaultCacheMaxEntries
+	if raw := os.Getenv("SEARCH_CACHE_MAX_ENTRIES"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return CacheConfig{}, fmt.Errorf("SEARCH_CACHE_MAX_ENTRIES must be a non-negative integer, got %q", raw)
+		}
+		maxEntries = value
+	}
+
+	todayTTL := defaultCacheTodayTTL
+	if raw := os.Getenv("SEARCH_CACHE_TODAY_TTL_SECONDS"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return CacheConfig{}, fmt.Errorf("SEARCH_CACHE_TODAY_TTL_SECONDS must be a non-negative integer, got %q", raw)
+		}
+		todayTTL = time.Duration(value) * time.Second
+	}
+
+	pastTTL := defaultCachePastTTL
+	if raw := os.Getenv("SEARCH_CACHE_PAST_TTL_SECONDS"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return CacheConfig{}, fmt.Errorf("SEARCH_CACHE_PAST_TTL_SECONDS must be a non-negative integer, got %q", raw)
+		}
+		pastTTL = time.Duration(value) * time.Second
+	}
+
+	return CacheConfig{
+		MaxEntries: maxEntries,
+		TodayTTL:   todayTTL,
+		PastTTL:    pastTTL,
+		Dir:        strings.TrimSpace(os.Getenv("SEARCH_CACHE_DIR")),
+	}, nil
 }
 
 // readGoogleAdsConfig reads unified Google Ads configuration from local file or environment variable
@@ -0,0 +1,116 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google-ads-mcp/internal/app"
+	"google-ads-mcp/internal/app/configs"
+	"google-ads-mcp/internal/testing/fakeads"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// clientID is a client account below the fake's root manager.
+const clientID = "1111111111"
+
+// newSession starts a fake Google Ads API, serves every tool in front of it through
+// the streamable HTTP handler and returns an MCP client session connected to it.
+// The tools authenticate with the fake's service account.
+func newSession(t *testing.T) (*fakeads.Server, *mcp.ClientSession) {
+	t.Helper()
+
+	fake := fakeads.New()
+	t.Cleanup(fake.Close)
+
+	return fake, connect(t, fake.Configs())
+}
+
+// connect serves every tool configured by cfgs through the streamable HTTP handler
+// and returns an MCP client session connected to it.
+func connect(t *testing.T, cfgs configs.Configs) *mcp.ClientSession {
+	t.Helper()
+
+	server := httptest.NewServer(app.NewHandler(cfgs))
+	t.Cleanup(server.Close)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{Endpoint: server.URL + cfgs.ServerConfig.Path}, nil)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+
+	return session
+}
+
+func callTool(t *testing.T, session *mcp.ClientSession, name string, arguments map[string]any) *mcp.CallToolResult {
+	t.Helper()
+
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: arguments})
+	if err != nil {
+		t.Fatalf("CallTool %s: %v", name, err)
+	}
+	return result
+}
+
+// decode returns the structured output of a successful tool call.
+func decode[T any](t *testing.T, result *mcp.CallToolResult) T {
+	t.Helper()
+
+	var output T
+	if result.IsError {
+		t.Fatalf("tool failed: %s", resultText(result))
+	}
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatalf("encoding structured content: %v", err)
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		t.Fatalf("decoding structured content: %v", err)
+	}
+	return output
+}
+
+// requireToolError fails unless the tool call failed with a message containing want.
+func requireToolError(t *testing.T, result *mcp.CallToolResult, want string) {
+	t.Helper()
+
+	if !result.IsError {
+		t.Fatalf("tool succeeded, want an error containing %q", want)
+	}
+	if text := resultText(result); !strings.Contains(text, want) {
+		t.Errorf("tool error = %q, want it to contain %q", text, want)
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	var texts []string
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			texts = append(texts, text.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+// requestsOf returns the calls the fake received with method.
+func requestsOf(fake *fakeads.Server, method string) []fakeads.Request {
+	var requests []fakeads.Request
+	for _, request := range fake.Requests() {
+		if request.Method == method {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// streamFailure fails a stream after its first batch.
+func streamFailure() *fakeads.Failure {
+	failure := fakeads.QueryError("query timed out")
+	failure.AfterBatches = 1
+	return failure
+}
@@ -0,0 +1,252 @@
+package app_test
+
+import (
+	"fmt"
+	"testing"
+
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+	"google-ads-mcp/internal/testing/fakeads"
+	"google-ads-mcp/internal/tools/addnegativekeywords"
+	"google-ads-mcp/internal/tools/setcampaignstatus"
+	"google-ads-mcp/internal/tools/updatecampaignbudget"
+
+	"github.com/shenzhencenter/google-ads-pb/common"
+	"github.com/shenzhencenter/google-ads-pb/enums"
+	"github.com/shenzhencenter/google-ads-pb/resources"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSetCampaignStatus(t *testing.T) {
+	arguments := map[string]any{
+		"customer_id":  clientID,
+		"campaign_ids": []string{"1", "2"},
+		"status":       "PAUSED",
+	}
+
+	t.Run("success", func(t *testing.T) {
+		fake, session := newSession(t)
+
+		output := decode[setcampaignstatus.ToolOutput](t, callTool(t, session, "set_campaign_status", arguments))
+		if output.SucceededCount != 2 || output.FailedCount != 0 {
+			t.Errorf("succeeded = %d, failed = %d, want 2 and 0", output.SucceededCount, output.FailedCount)
+		}
+		want := fmt.Sprintf("customers/%s/campaigns/2", clientID)
+		if len(output.Results) != 2 || output.Results[1].ResourceName != want {
+			t.Errorf("results = %+v, want campaign 2 updated as %s", output.Results, want)
+		}
+
+		mutates := requestsOf(fake, fakeads.MethodMutateCampaigns)
+		if len(mutates) != 1 || mutates[0].LoginCustomerID != fakeads.DefaultCustomerID {
+			t.Errorf("mutates = %+v, want one as the root manager", mutates)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.FailCustomer(clientID, fakeads.AuthorizationError())
+
+		requireToolError(t, callTool(t, session, "set_campaign_status", arguments), "not authorized")
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.FailOperation(1, fakeads.FieldError("status is required"))
+
+		output := decode[setcampaignstatus.ToolOutput](t, callTool(t, session, "set_campaign_status", arguments))
+		if output.SucceededCount != 1 || output.FailedCount != 1 {
+			t.Errorf("succeeded = %d, failed = %d, want 1 and 1", output.SucceededCount, output.FailedCount)
+		}
+		if len(output.Results) != 2 || !output.Results[0].Success || output.Results[1].Success || len(output.Results[1].Errors) == 0 {
+			t.Errorf("results = %+v, want campaign 1 updated and campaign 2 failed with its error", output.Results)
+		}
+	})
+}
+
+func budgetRow(explicitlyShared bool, referenceCount int64) *services.GoogleAdsRow {
+	return &services.GoogleAdsRow{
+		Campaign: &resources.Campaign{Id: proto.Int64(1), Name: proto.String("Campaign")},
+		CampaignBudget: &resources.CampaignBudget{
+			ResourceName:     fmt.Sprintf("customers/%s/campaignBudgets/9", clientID),
+			Id:               proto.Int64(9),
+			Name:             proto.String("Budget"),
+			AmountMicros:     proto.Int64(10_000_000),
+			ExplicitlyShared: proto.Bool(explicitlyShared),
+			ReferenceCount:   proto.Int64(referenceCount),
+		},
+	}
+}
+
+func TestUpdateCampaignBudget(t *testing.T) {
+	arguments := map[string]any{
+		"customer_id":   clientID,
+		"campaign_id":   "1",
+		"amount_micros": 12_000_000,
+	}
+
+	t.Run("success", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("campaign", budgetRow(false, 1))
+
+		output := decode[updatecampaignbudget.ToolOutput](t, callTool(t, session, "update_campaign_budget", arguments))
+		if output.OldAmountMicros == nil || *output.OldAmountMicros != 10_000_000 || output.NewAmountMicros == nil || *output.NewAmountMicros != 12_000_000 {
+			t.Errorf("old = %v, new = %v, want 10000000 and 12000000", output.OldAmountMicros, output.NewAmountMicros)
+		}
+		if output.ChangePercent != 20 || output.Shared {
+			t.Errorf("change = %.2f%%, shared = %t, want 20%% on an unshared budget", output.ChangePercent, output.Shared)
+		}
+
+		mutates := requestsOf(fake, fakeads.MethodMutate)
+		if len(mutates) != 1 {
+			t.Fatalf("fake received %d mutates, want 1", len(mutates))
+		}
+		update := mutates[0].Mutate.GetMutateOperations()[0].GetCampaignBudgetOperation().GetUpdate()
+		if update.GetAmountMicros() != 12_000_000 || update.GetResourceName() != budgetRow(false, 1).GetCampaignBudget().GetResourceName() {
+			t.Errorf("update = %v, want budget 9 set to 12000000", update)
+		}
+	})
+
+	t.Run("dashed customer ID", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("campaign", budgetRow(false, 1))
+
+		decode[updatecampaignbudget.ToolOutput](t, callTool(t, session, "update_campaign_budget", map[string]any{
+			"customer_id":   "111-111-1111",
+			"campaign_id":   "1",
+			"amount_micros": 12_000_000,
+		}))
+		mutates := requestsOf(fake, fakeads.MethodMutate)
+		if len(mutates) != 1 || mutates[0].CustomerID != clientID {
+			t.Errorf("mutates = %+v, want one for %s", mutates, clientID)
+		}
+	})
+
+	t.Run("shared budget", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("campaign", budgetRow(true, 1))
+
+		output := decode[updatecampaignbudget.ToolOutput](t, callTool(t, session, "update_campaign_budget", arguments))
+		if !output.Shared || len(output.Warnings) != 1 || output.Warnings[0] != "budget 9 is shared by 1 campaign: the new amount applies to all of them" {
+			t.Errorf("shared = %t, warnings = %q, want the shared budget warning", output.Shared, output.Warnings)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.FailCustomer(clientID, fakeads.AuthorizationError())
+
+		requireToolError(t, callTool(t, session, "update_campaign_budget", arguments), "not authorized")
+	})
+
+	t.Run("mutate failure", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("campaign", budgetRow(false, 1))
+		fake.FailOperation(0, fakeads.FieldError("amount_micros is required"))
+
+		requireToolError(t, callTool(t, session, "update_campaign_budget", arguments), "amount_micros is required")
+	})
+}
+
+func TestAddNegativeKeywords(t *testing.T) {
+	arguments := map[string]any{
+		"customer_id":   clientID,
+		"campaign_id":   "1",
+		"shared_set_id": "7",
+		"keywords": []map[string]any{
+			{"text": "free"},
+			{"text": "cheap"},
+		},
+	}
+	sharedSetRow := &services.GoogleAdsRow{SharedSet: &resources.SharedSet{Type: enums.SharedSetTypeEnum_NEGATIVE_KEYWORDS}}
+	existingRow := &services.GoogleAdsRow{CampaignCriterion: &resources.CampaignCriterion{
+		Criterion: &resources.CampaignCriterion_Keyword{Keyword: &common.KeywordInfo{
+			Text:      proto.String("free"),
+			MatchType: enums.KeywordMatchTypeEnum_EXACT,
+		}},
+	}}
+
+	t.Run("success", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("shared_set", sharedSetRow)
+		fake.AddResourceRows("campaign_criterion", existingRow)
+
+		output := decode[addnegativekeywords.ToolOutput](t, callTool(t, session, "add_negative_keywords", arguments))
+		if len(output.Targets) != 2 {
+			t.Errorf("targets = %+v, want the campaign and the shared set", output.Targets)
+		}
+		// "free" already exists on the campaign, so only the shared set gets it
+		if output.AddedCount != 3 || output.SkippedCount != 1 {
+			t.Errorf("added = %d, skipped = %d, want 3 and 1", output.AddedCount, output.SkippedCount)
+		}
+		if len(output.Skipped) != 1 || output.Skipped[0].Text != "free" || output.Skipped[0].Level != "campaign" {
+			t.Errorf("skipped = %+v, want free on the campaign", output.Skipped)
+		}
+
+		mutates := requestsOf(fake, fakeads.MethodMutate)
+		if len(mutates) != 1 || len(mutates[0].Mutate.GetMutateOperations()) != 3 {
+			t.Errorf("mutates = %+v, want one with 3 operations", mutates)
+		}
+	})
+
+	t.Run("dashed customer ID", func(t *testing.T) {
+		fake, session := newCachedSession(t)
+		fake.AddResourceRows("campaign", campaignRow(1, "USD"))
+		if status := searchCache(t, session, "111-111-1111"); status != string(searchcache.StatusMiss) {
+			t.Fatalf("first search cache = %q, want miss", status)
+		}
+
+		output := decode[addnegativekeywords.ToolOutput](t, callTool(t, session, "add_negative_keywords", map[string]any{
+			"customer_id": "111-111-1111",
+			"campaign_id": "1",
+			"keywords":    []map[string]any{{"text": "free"}},
+		}))
+		want := fmt.Sprintf("customers/%s/campaigns/1", clientID)
+		if len(output.Targets) != 1 || output.Targets[0].ResourceName != want {
+			t.Errorf("targets = %+v, want %s", output.Targets, want)
+		}
+
+		// The fake rejects a dashed customer ID in the URL path, as the API does
+		mutates := requestsOf(fake, fakeads.MethodMutate)
+		if len(mutates) != 1 {
+			t.Fatalf("fake received %d mutates, want 1", len(mutates))
+		}
+		if campaign := mutates[0].Mutate.GetMutateOperations()[0].GetCampaignCriterionOperation().GetCreate().GetCampaign(); campaign != want {
+			t.Errorf("criterion campaign = %q, want %s", campaign, want)
+		}
+
+		if status := searchCache(t, session, "111-111-1111"); status != string(searchcache.StatusMiss) {
+			t.Errorf("search cache after the mutate = %q, want miss", status)
+		}
+	})
+
+	t.Run("not a negative keyword list", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("shared_set", &services.GoogleAdsRow{SharedSet: &resources.SharedSet{Type: enums.SharedSetTypeEnum_NEGATIVE_PLACEMENTS}})
+
+		requireToolError(t, callTool(t, session, "add_negative_keywords", arguments), "NEGATIVE_PLACEMENTS")
+		if mutates := requestsOf(fake, fakeads.MethodMutate); len(mutates) != 0 {
+			t.Errorf("fake received %d mutates, want none", len(mutates))
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.FailCustomer(clientID, fakeads.AuthorizationError())
+
+		requireToolError(t, callTool(t, session, "add_negative_keywords", arguments), "not authorized")
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("shared_set", sharedSetRow)
+		fake.FailOperation(1, fakeads.FieldError("keyword text is too long"))
+
+		output := decode[addnegativekeywords.ToolOutput](t, callTool(t, session, "add_negative_keywords", arguments))
+		if output.AddedCount != 3 || output.FailedCount != 1 {
+			t.Errorf("added = %d, failed = %d, want 3 and 1", output.AddedCount, output.FailedCount)
+		}
+		if len(output.Results) != 4 || output.Results[1].Success || len(output.Results[1].Errors) == 0 {
+			t.Errorf("results = %+v, want the second operation failed with its error", output.Results)
+		}
+	})
+}
@@ -0,0 +1,356 @@
+package app_test
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"testing"
+
+	"google-ads-mcp/internal/testing/fakeads"
+	"google-ads-mcp/internal/tools/accountsummary"
+	"google-ads-mcp/internal/tools/listadaccounts"
+	"google-ads-mcp/internal/tools/rungaqlquery"
+	"google-ads-mcp/internal/tools/searchadgroups"
+	"google-ads-mcp/internal/tools/searchads"
+	"google-ads-mcp/internal/tools/searchcampaigns"
+	"google-ads-mcp/internal/tools/searchkeywords"
+	"google-ads-mcp/internal/tools/searchsearchterms"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/shenzhencenter/google-ads-pb/common"
+	"github.com/shenzhencenter/google-ads-pb/enums"
+	"github.com/shenzhencenter/google-ads-pb/resources"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+)
+
+func customerClientRow(customerID string, level int64, manager bool) *services.GoogleAdsRow {
+	return &services.GoogleAdsRow{CustomerClient: &resources.CustomerClient{
+		ClientCustomer:  proto.String("customers/" + customerID),
+		DescriptiveName: proto.String("Account " + customerID),
+		CurrencyCode:    proto.String("USD"),
+		Level:           proto.Int64(level),
+		Manager:         proto.Bool(manager),
+		Status:          enums.CustomerStatusEnum_ENABLED,
+	}}
+}
+
+func campaignRow(id int64, currencyCode string) *services.GoogleAdsRow {
+	return &services.GoogleAdsRow{
+		Campaign: &resources.Campaign{
+			ResourceName: fmt.Sprintf("customers/%s/campaigns/%d", clientID, id),
+			Id:           proto.Int64(id),
+			Name:         proto.String("Campaign"),
+			Status:       enums.CampaignStatusEnum_ENABLED,
+		},
+		Customer: &resources.Customer{CurrencyCode: proto.String(currencyCode)},
+		Metrics:  &common.Metrics{Clicks: proto.Int64(10), CostMicros: proto.Int64(5_000_000)},
+	}
+}
+
+func TestListAdAccounts(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("customer_client", customerClientRow("1111111111", 1, false), customerClientRow("2222222222", 1, false))
+
+		output := decode[listadaccounts.ToolOutput](t, callTool(t, session, "list_ad_accounts", map[string]any{}))
+		if len(output.Accounts) != 2 || output.Accounts[0].CustomerID != "customers/1111111111" {
+			t.Errorf("accounts = %+v, want 1111111111 and 2222222222", output.Accounts)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.FailCustomer(fakeads.DefaultCustomerID, fakeads.AuthorizationError())
+
+		requireToolError(t, callTool(t, session, "list_ad_accounts", map[string]any{}), "not authorized")
+	})
+
+	t.Run("stream fails part way", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.StreamBatchSize = 1
+		fake.AddResourceRows("customer_client", customerClientRow("1111111111", 1, false), customerClientRow("2222222222", 1, false))
+		fake.FailResource("customer_client", streamFailure())
+
+		requireToolError(t, callTool(t, session, "list_ad_accounts", map[string]any{"fetch_all": true}), "query timed out")
+	})
+}
+
+func TestSearchCampaigns(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("campaign", campaignRow(1, "USD"), campaignRow(2, "USD"))
+
+		output := decode[searchcampaigns.ToolOutput](t, callTool(t, session, "search_campaigns", map[string]any{"customer_id": clientID}))
+		if len(output.Campaigns) != 2 || output.Campaigns[1].ID != "2" {
+			t.Errorf("campaigns = %+v, want campaigns 1 and 2", output.Campaigns)
+		}
+
+		// The client is not in the (empty) hierarchy, so the root manager logs in
+		searches := requestsOf(fake, fakeads.MethodSearch)
+		last := searches[len(searches)-1]
+		if last.CustomerID != clientID || last.LoginCustomerID != fakeads.DefaultCustomerID {
+			t.Errorf("search went to customer %s as %s, want %s as %s", last.CustomerID, last.LoginCustomerID, clientID, fakeads.DefaultCustomerID)
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.FailCustomer(clientID, fakeads.AuthorizationError())
+
+		requireToolError(t, callTool(t, session, "search_campaigns", map[string]any{"customer_id": clientID}), "not authorized")
+	})
+
+	t.Run("segments split across pages", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.PageSize = 1
+		for _, date := range []string{"2026-10-01", "2026-10-02"} {
+			row := campaignRow(1, "USD")
+			row.Segments = &common.Segments{Date: proto.String(date)}
+			fake.AddResourceRows("campaign", row)
+		}
+
+		output := decode[searchcampaigns.ToolOutput](t, callTool(t, session, "search_campaigns", map[string]any{
+			"customer_id":      clientID,
+			"segment_by":       "date",
+			"date_range_start": "2026-10-01",
+			"date_range_end":   "2026-10-02",
+		}))
+		if !output.HasMore || output.NextPageToken == "" {
+			t.Errorf("has_more = %t, next_page_token = %q, want more rows to resume from", output.HasMore, output.NextPageToken)
+		}
+		if len(output.Campaigns) != 1 || !output.Campaigns[0].Incomplete || len(output.Campaigns[0].Segments) != 1 {
+			t.Errorf("campaigns = %+v, want campaign 1 with one segment marked incomplete", output.Campaigns)
+		}
+	})
+
+	t.Run("comparison past the row cap", func(t *testing.T) {
+		fake := fakeads.New()
+		t.Cleanup(fake.Close)
+		cfgs := fake.Configs()
+		cfgs.SearchConfig.MaxFetchAllRows = 1
+		session := connect(t, cfgs)
+		fake.AddResourceRows("campaign", campaignRow(1, "USD"), campaignRow(2, "USD"))
+
+		requireToolError(t, callTool(t, session, "search_campaigns", map[string]any{
+			"customer_id":      clientID,
+			"date_range_start": "2026-10-08",
+			"date_range_end":   "2026-10-14",
+			"compare_to":       "previous_period",
+		}), "more than 1 campaigns")
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("campaign", campaignRow(1, "USD"))
+		fake.FailCustomer("2222222222", fakeads.AuthorizationError())
+
+		output := decode[searchcampaigns.ToolOutput](t, callTool(t, session, "search_campaigns", map[string]any{
+			"customer_ids": []string{clientID, "2222222222"},
+		}))
+		if len(output.Accounts) != 1 || output.Accounts[0].CustomerID != clientID {
+			t.Errorf("accounts = %+v, want only %s", output.Accounts, clientID)
+		}
+		if len(output.FailedAccounts) != 1 || output.FailedAccounts[0].CustomerID != "2222222222" {
+			t.Errorf("failed accounts = %+v, want 2222222222", output.FailedAccounts)
+		}
+		if output.Rollup == nil || output.Rollup.SucceededCount != 1 || output.Rollup.FailedCount != 1 || output.Rollup.CurrencyCode != "USD" {
+			t.Errorf("rollup = %+v, want one USD account succeeded and one failed", output.Rollup)
+		}
+	})
+}
+
+func TestAccountSummary(t *testing.T) {
+	customerRow := &services.GoogleAdsRow{
+		Customer: &resources.Customer{
+			Id:              proto.Int64(1111111111),
+			DescriptiveName: proto.String("Client"),
+			CurrencyCode:    proto.String("USD"),
+			TimeZone:        proto.String("UTC"),
+		},
+		Metrics: &common.Metrics{Clicks: proto.Int64(10), Impressions: proto.Int64(100), CostMicros: proto.Int64(5_000_000)},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("customer", customerRow)
+
+		output := decode[accountsummary.ToolOutput](t, callTool(t, session, "account_summary", map[string]any{
+			"customer_ids": []string{clientID, "2222222222"},
+		}))
+		if output.Rollup.SucceededCount != 2 || output.Rollup.Metrics.Clicks != 20 {
+			t.Errorf("rollup = %+v, want 2 accounts and 20 clicks", output.Rollup)
+		}
+		if output.DateRangeEnd == "" || output.Accounts[0].DateRangeEnd != output.DateRangeEnd {
+			t.Errorf("date range end = %q, account date = %q, want yesterday for both", output.DateRangeEnd, output.Accounts[0].DateRangeEnd)
+		}
+		for _, request := range requestsOf(fake, fakeads.MethodSearch) {
+			if strings.Contains(request.Query, "FROM customer ") && !strings.Contains(request.Query, "DURING YESTERDAY") {
+				t.Errorf("query %q does not ask for yesterday in the account time zone", request.Query)
+			}
+		}
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		fake, session := newSession(t)
+		// Expanding "all" walks the hierarchy below the root manager
+		fake.FailCustomer(fakeads.DefaultCustomerID, fakeads.AuthorizationError())
+
+		requireToolError(t, callTool(t, session, "account_summary", map[string]any{"customer_ids": []string{"all"}}), "not authorized")
+	})
+
+	t.Run("partial failure", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows("customer", customerRow)
+		fake.FailCustomer("2222222222", fakeads.AuthorizationError())
+
+		output := decode[accountsummary.ToolOutput](t, callTool(t, session, "account_summary", map[string]any{
+			"customer_ids": []string{clientID, "2222222222"},
+		}))
+		if len(output.Accounts) != 1 || output.Rollup.FailedCount != 1 || output.Rollup.Metrics.Clicks != 10 {
+			t.Errorf("accounts = %+v, rollup = %+v, want one account with 10 clicks and one failure", output.Accounts, output.Rollup)
+		}
+		if len(output.FailedAccounts) != 1 || !strings.Contains(output.FailedAccounts[0].Error, "not authorized") {
+			t.Errorf("failed accounts = %+v, want 2222222222 not authorized", output.FailedAccounts)
+		}
+	})
+}
+
+// searchToolTests covers a single-account search tool called with arguments: rows
+// are returned, API errors fail the call, and a fetch_all stream that fails part way
+// fails the call instead of returning the rows streamed so far.
+func searchToolTests(t *testing.T, tool, resource string, arguments map[string]any, rows []*services.GoogleAdsRow, check func(*testing.T, *mcp.CallToolResult)) {
+	withArguments := func(extra map[string]any) map[string]any {
+		merged := map[string]any{"customer_id": clientID}
+		maps.Copy(merged, arguments)
+		maps.Copy(merged, extra)
+		return merged
+	}
+
+	t.Run("success", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.AddResourceRows(resource, rows...)
+
+		check(t, callTool(t, session, tool, withArguments(nil)))
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.FailCustomer(clientID, fakeads.AuthorizationError())
+
+		requireToolError(t, callTool(t, session, tool, withArguments(nil)), "not authorized")
+	})
+
+	t.Run("stream fails part way", func(t *testing.T) {
+		fake, session := newSession(t)
+		fake.StreamBatchSize = 1
+		fake.AddResourceRows(resource, rows...)
+		fake.FailResource(resource, streamFailure())
+
+		requireToolError(t, callTool(t, session, tool, withArguments(map[string]any{"fetch_all": true})), "query timed out")
+		if streams := requestsOf(fake, fakeads.MethodSearchStream); len(streams) != 1 {
+			t.Errorf("fake received %d streams, want 1", len(streams))
+		}
+	})
+}
+
+func TestSearchAdGroups(t *testing.T) {
+	row := func(id int64) *services.GoogleAdsRow {
+		return &services.GoogleAdsRow{
+			AdGroup: &resources.AdGroup{
+				ResourceName: fmt.Sprintf("customers/%s/adGroups/%d", clientID, id),
+				Id:           proto.Int64(id),
+				Name:         proto.String("Ad group"),
+				Status:       enums.AdGroupStatusEnum_ENABLED,
+			},
+			Campaign: &resources.Campaign{Id: proto.Int64(1), Name: proto.String("Campaign")},
+			Metrics:  &common.Metrics{Clicks: proto.Int64(3)},
+		}
+	}
+
+	searchToolTests(t, "search_ad_groups", "ad_group", nil, []*services.GoogleAdsRow{row(11), row(12)}, func(t *testing.T, result *mcp.CallToolResult) {
+		output := decode[searchadgroups.ToolOutput](t, result)
+		if len(output.AdGroups) != 2 || output.AdGroups[0].ID != "11" || output.AdGroups[0].CampaignID != "1" {
+			t.Errorf("ad groups = %+v, want 11 and 12 in campaign 1", output.AdGroups)
+		}
+	})
+}
+
+func TestSearchAds(t *testing.T) {
+	row := func(id int64) *services.GoogleAdsRow {
+		return &services.GoogleAdsRow{
+			AdGroupAd: &resources.AdGroupAd{
+				ResourceName: fmt.Sprintf("customers/%s/adGroupAds/11~%d", clientID, id),
+				Status:       enums.AdGroupAdStatusEnum_ENABLED,
+				Ad:           &resources.Ad{Id: proto.Int64(id), Type: enums.AdTypeEnum_RESPONSIVE_SEARCH_AD},
+			},
+			AdGroup:  &resources.AdGroup{Id: proto.Int64(11)},
+			Campaign: &resources.Campaign{Id: proto.Int64(1)},
+		}
+	}
+
+	searchToolTests(t, "search_ads", "ad_group_ad", nil, []*services.GoogleAdsRow{row(21), row(22)}, func(t *testing.T, result *mcp.CallToolResult) {
+		output := decode[searchads.ToolOutput](t, result)
+		if len(output.Ads) != 2 || output.Ads[1].ID != "22" || output.Ads[1].AdGroupID != "11" {
+			t.Errorf("ads = %+v, want 21 and 22 in ad group 11", output.Ads)
+		}
+	})
+}
+
+func TestSearchKeywords(t *testing.T) {
+	row := func(id int64, text string) *services.GoogleAdsRow {
+		return &services.GoogleAdsRow{
+			AdGroupCriterion: &resources.AdGroupCriterion{
+				ResourceName: fmt.Sprintf("customers/%s/adGroupCriteria/11~%d", clientID, id),
+				CriterionId:  proto.Int64(id),
+				Status:       enums.AdGroupCriterionStatusEnum_ENABLED,
+				Criterion: &resources.AdGroupCriterion_Keyword{Keyword: &common.KeywordInfo{
+					Text:      proto.String(text),
+					MatchType: enums.KeywordMatchTypeEnum_PHRASE,
+				}},
+			},
+			AdGroup:  &resources.AdGroup{Id: proto.Int64(11)},
+			Campaign: &resources.Campaign{Id: proto.Int64(1)},
+		}
+	}
+
+	searchToolTests(t, "search_keywords", "keyword_view", nil, []*services.GoogleAdsRow{row(31, "running shoes"), row(32, "trail shoes")}, func(t *testing.T, result *mcp.CallToolResult) {
+		output := decode[searchkeywords.ToolOutput](t, result)
+		if len(output.Keywords) != 2 || output.Keywords[0].Text != "running shoes" || output.Keywords[0].CriterionID != "31" {
+			t.Errorf("keywords = %+v, want running shoes and trail shoes", output.Keywords)
+		}
+	})
+}
+
+func TestSearchSearchTerms(t *testing.T) {
+	row := func(term string) *services.GoogleAdsRow {
+		return &services.GoogleAdsRow{
+			SearchTermView: &resources.SearchTermView{
+				ResourceName: fmt.Sprintf("customers/%s/searchTermViews/1~11~%s", clientID, term),
+				SearchTerm:   proto.String(term),
+				Status:       enums.SearchTermTargetingStatusEnum_NONE,
+			},
+			AdGroup:  &resources.AdGroup{Id: proto.Int64(11)},
+			Campaign: &resources.Campaign{Id: proto.Int64(1)},
+			Metrics:  &common.Metrics{Clicks: proto.Int64(2)},
+		}
+	}
+
+	searchToolTests(t, "search_search_terms", "search_term_view", nil, []*services.GoogleAdsRow{row("cheap shoes"), row("free shoes")}, func(t *testing.T, result *mcp.CallToolResult) {
+		output := decode[searchsearchterms.ToolOutput](t, result)
+		if len(output.SearchTerms) != 2 || output.SearchTerms[1].SearchTerm != "free shoes" {
+			t.Errorf("search terms = %+v, want cheap shoes and free shoes", output.SearchTerms)
+		}
+	})
+}
+
+func TestRunGAQLQuery(t *testing.T) {
+	arguments := map[string]any{"query": "SELECT campaign.id, campaign.name FROM campaign"}
+
+	searchToolTests(t, "run_gaql_query", "campaign", arguments, []*services.GoogleAdsRow{campaignRow(1, "USD"), campaignRow(2, "USD")}, func(t *testing.T, result *mcp.CallToolResult) {
+		output := decode[rungaqlquery.ToolOutput](t, result)
+		if output.Resource != "campaign" || len(output.Rows) != 2 {
+			t.Errorf("output = %+v, want 2 campaign rows", output)
+		}
+	})
+}
@@ -2,18 +2,42 @@ package app
 
 import (
 	"google-ads-mcp/internal/app/configs"
+	accountsummaryrepo "google-ads-mcp/internal/infrastructure/api/accountsummary"
+	addnegativekeywordsrepo "google-ads-mcp/internal/infrastructure/api/addnegativekeywords"
+	"google-ads-mcp/internal/infrastructure/api/currency"
+	"google-ads-mcp/internal/infrastructure/api/fanout"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
 	repo "google-ads-mcp/internal/infrastructure/api/listadaccounts"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+	rungaqlqueryrepo "google-ads-mcp/internal/infrastructure/api/rungaqlquery"
 	searchadgroupsrepo "google-ads-mcp/internal/infrastructure/api/searchadgroups"
 	searchadsrepo "google-ads-mcp/internal/infrastructure/api/searchads"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
 	searchcampaignsrepo "google-ads-mcp/internal/infrastructure/api/searchcampaigns"
+	searchkeywordsrepo "google-ads-mcp/internal/infrastructure/api/searchkeywords"
+	searchsearchtermsrepo "google-ads-mcp/internal/infrastructure/api/searchsearchterms"
+	setcampaignstatusrepo "google-ads-mcp/internal/infrastructure/api/setcampaignstatus"
+	"google-ads-mcp/internal/infrastructure/api/transport"
+	updatecampaignbudgetrepo "google-ads-mcp/internal/infrastructure/api/updatecampaignbudget"
 	"google-ads-mcp/internal/infrastructure/auth"
+	"google-ads-mcp/internal/infrastructure/fx"
 	"google-ads-mcp/internal/infrastructure/http"
 	"google-ads-mcp/internal/infrastructure/log/local"
+	"google-ads-mcp/internal/tools/accountsummary"
+	"google-ads-mcp/internal/tools/addnegativekeywords"
 	"google-ads-mcp/internal/tools/listadaccounts"
+	"google-ads-mcp/internal/tools/rungaqlquery"
 	"google-ads-mcp/internal/tools/searchadgroups"
 	"google-ads-mcp/internal/tools/searchads"
 	"google-ads-mcp/internal/tools/searchcampaigns"
+	"google-ads-mcp/internal/tools/searchkeywords"
+	"google-ads-mcp/internal/tools/searchsearchterms"
+	"google-ads-mcp/internal/tools/setcampaignstatus"
+	"google-ads-mcp/internal/tools/updatecampaignbudget"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
@@ -25,90 +49,272 @@ func initServer(configs configs.Configs) *mcp.Server {
 
 	server := mcp.NewServer(implementation, options)
 
+	// Every tool shares one Google Ads client over the configured REST or gRPC transport
+	adsClient := initGoogleAdsClient(configs)
+	// Report searches go through one cache, so repeated tool calls reuse their results
+	searchClient := initSearchClient(configs, adsClient)
+	// One resolver is shared so the account hierarchy is walked and cached once;
+	// it also lists the client accounts that customer_ids "all" expands to
+	loginResolver := initLoginCustomerResolver(configs, adsClient)
+	fxConverter := initFXConverter(configs)
+	// Account currencies are cached once for the tools that format money as decimals
+	currencyLookup := initCurrencyLookup(configs, adsClient, loginResolver)
+
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_ad_accounts",
-		Description: "List Google Ads accounts",
-	}, initListAdAccountsTool(configs).ListAdAccounts)
+		Description: "List Google Ads accounts. Set include_managers to get the manager account hierarchy with parent, level and path for every account",
+	}, initListAdAccountsTool(configs, adsClient).ListAdAccounts)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "search_campaigns",
+		Description:  "Search Google Ads campaigns. Set fields to return only the listed attributes and metrics. Set customer_ids (or [\"all\"] for every client account under the manager) instead of customer_id to query several accounts in parallel; results are grouped per account with a rollup and failed accounts are reported. With customer_ids, reporting_currency converts cost and value with the configured FX rates. Results are cached; set bypass_cache to fetch fresh data",
+		OutputSchema: projectedOutputSchema[searchcampaigns.ToolOutput](),
+	}, initSearchCampaignsTool(configs, searchClient, loginResolver, loginResolver, fxConverter).SearchCampaigns)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "account_summary",
+		Description: "Summarize spend and performance of several Google Ads accounts in parallel. Set customer_ids to a list of accounts or [\"all\"] for every client account under the manager; defaults to yesterday in each account's time zone. Returns per-account totals, a rollup and the accounts that failed",
+	}, initAccountSummaryTool(configs, adsClient, loginResolver, loginResolver, fxConverter).AccountSummary)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "search_ad_groups",
+		Description:  "Search Google Ads ad groups. Set fields to return only the listed attributes and metrics. Results are cached; set bypass_cache to fetch fresh data",
+		OutputSchema: projectedOutputSchema[searchadgroups.ToolOutput](),
+	}, initSearchAdGroupsTool(configs, searchClient, loginResolver, currencyLookup).SearchAdGroups)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:         "search_ads",
+		Description:  "Search Google Ads. Set fields to return only the listed attributes and metrics. Results are cached; set bypass_cache to fetch fresh data",
+		OutputSchema: projectedOutputSchema[searchads.ToolOutput](),
+	}, initSearchAdsTool(configs, searchClient, loginResolver, currencyLookup).SearchAds)
 
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "search_campaigns",
-		Description: "Search Google Ads campaigns",
-	}, initSearchCampaignsTool(configs).SearchCampaigns)
+		Name:         "search_keywords",
+		Description:  "Search Google Ads keywords with match type, bids, quality score components and performance metrics. Set fields to return only the listed attributes and metrics. Results are cached; set bypass_cache to fetch fresh data",
+		OutputSchema: projectedOutputSchema[searchkeywords.ToolOutput](),
+	}, initSearchKeywordsTool(configs, searchClient, loginResolver, currencyLookup).SearchKeywords)
 
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "search_ad_groups",
-		Description: "Search Google Ads ad groups",
-	}, initSearchAdGroupsTool(configs).SearchAdGroups)
+		Name:         "search_search_terms",
+		Description:  "Search the Google Ads search terms report: the queries that triggered ads, their targeting status, triggering keyword and metrics. Set fields to return only the listed attributes and metrics. Results are cached; set bypass_cache to fetch fresh data",
+		OutputSchema: projectedOutputSchema[searchsearchterms.ToolOutput](),
+	}, initSearchSearchTermsTool(configs, searchClient, loginResolver, currencyLookup).SearchSearchTerms)
 
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "search_ads",
-		Description: "Search Google Ads",
-	}, initSearchAdsTool(configs).SearchAds)
+		Name:        "run_gaql_query",
+		Description: "Run a read-only Google Ads Query Language (GAQL) SELECT query and return the rows as flattened field paths. Results are cached; set bypass_cache to fetch fresh data",
+	}, initRunGAQLQueryTool(configs, searchClient, loginResolver).RunGAQLQuery)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "set_campaign_status",
+		Description: "Pause or enable Google Ads campaigns. Set validate_only to dry-run the change without applying it; failures are reported per campaign",
+	}, initSetCampaignStatusTool(configs, adsClient, loginResolver).SetCampaignStatus)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_campaign_budget",
+		Description: "Change the daily budget of a Google Ads campaign. Changes above the configured ceiling are rejected and shared budgets are flagged; set validate_only to dry-run",
+	}, initUpdateCampaignBudgetTool(configs, adsClient, loginResolver, currencyLookup).UpdateCampaignBudget)
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_negative_keywords",
+		Description: "Add negative keywords to a campaign or an ad group, a shared negative keyword list (shared set of type NEGATIVE_KEYWORDS), or a shared list together with a campaign or ad group. Keywords that already exist are skipped; set validate_only to dry-run",
+	}, initAddNegativeKeywordsTool(configs, adsClient, loginResolver).AddNegativeKeywords)
 
 	return server
 }
 
-func initListAdAccountsTool(configs configs.Configs) *listadaccounts.Tool {
-	httpClient := http.NewClient(nil)
+func initListAdAccountsTool(configs configs.Configs, adsClient *googleads.Client) *listadaccounts.Tool {
+	service := repo.NewService(adsClient, configs.GoogleAdsConfig.CustomerID, configs.SearchConfig.MaxFetchAllRows)
+
+	return listadaccounts.NewListAdAccountsTool(service, responseBudget(configs))
+}
+
+func initSearchCampaignsTool(configs configs.Configs, searchClient *searchcache.Client, loginResolver logincustomer.Resolver, accountLister logincustomer.AccountLister, fxConverter *fx.Converter) *searchcampaigns.Tool {
 	logger := local.NewLogger()
 
-	// Use the service account JSON from Google Secret Manager
-	tokenManager, err := auth.NewTokenManagerFromServiceAccount([]byte(configs.GoogleAdsConfig.ServiceAccountJSON), auth.GoogleAdsScope)
-	if err != nil {
-		panic("failed to initialize token manager: " + err.Error())
-	}
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := searchcampaignsrepo.NewService(searchClient, logger, loginResolver, accountLister, configs.SearchConfig.MaxFetchAllRows, fanOutOptions(configs), fxConverter)
 
-	service := repo.NewService(httpClient, logger, tokenManager, configs.GoogleAdsConfig.CustomerID, configs.GoogleAdsConfig.DeveloperToken)
+	return searchcampaigns.NewSearchCampaignsTool(service, responseBudget(configs))
+}
+
+func initAccountSummaryTool(configs configs.Configs, adsClient *googleads.Client, loginResolver logincustomer.Resolver, accountLister logincustomer.AccountLister, fxConverter *fx.Converter) *accountsummary.Tool {
+	logger := local.NewLogger()
+
+	service := accountsummaryrepo.NewService(adsClient, logger, loginResolver, accountLister, fanOutOptions(configs), fxConverter)
+
+	return accountsummary.NewAccountSummaryTool(service)
+}
 
-	return listadaccounts.NewListAdAccountsTool(service)
+func initSearchAdGroupsTool(configs configs.Configs, searchClient *searchcache.Client, loginResolver logincustomer.Resolver, currencyLookup currency.Lookup) *searchadgroups.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := searchadgroupsrepo.NewService(searchClient, loginResolver, configs.SearchConfig.MaxFetchAllRows)
+
+	return searchadgroups.NewSearchAdGroupsTool(service, currencyLookup, responseBudget(configs))
+}
+
+func initSearchAdsTool(configs configs.Configs, searchClient *searchcache.Client, loginResolver logincustomer.Resolver, currencyLookup currency.Lookup) *searchads.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := searchadsrepo.NewService(searchClient, loginResolver, configs.SearchConfig.MaxFetchAllRows)
+
+	return searchads.NewSearchAdsTool(service, currencyLookup, responseBudget(configs))
 }
 
-func initSearchCampaignsTool(configs configs.Configs) *searchcampaigns.Tool {
-	httpClient := http.NewClient(nil)
+func initSearchKeywordsTool(configs configs.Configs, searchClient *searchcache.Client, loginResolver logincustomer.Resolver, currencyLookup currency.Lookup) *searchkeywords.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := searchkeywordsrepo.NewService(searchClient, loginResolver, configs.SearchConfig.MaxFetchAllRows)
+
+	return searchkeywords.NewSearchKeywordsTool(service, currencyLookup, responseBudget(configs))
+}
+
+func initSearchSearchTermsTool(configs configs.Configs, searchClient *searchcache.Client, loginResolver logincustomer.Resolver, currencyLookup currency.Lookup) *searchsearchterms.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := searchsearchtermsrepo.NewService(searchClient, loginResolver, configs.SearchConfig.MaxFetchAllRows)
+
+	return searchsearchterms.NewSearchSearchTermsTool(service, currencyLookup, responseBudget(configs))
+}
+
+func initRunGAQLQueryTool(configs configs.Configs, searchClient *searchcache.Client, loginResolver logincustomer.Resolver) *rungaqlquery.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := rungaqlqueryrepo.NewService(searchClient, loginResolver, configs.SearchConfig.MaxFetchAllRows)
+
+	return rungaqlquery.NewRunGAQLQueryTool(service, responseBudget(configs))
+}
+
+func initSetCampaignStatusTool(configs configs.Configs, adsClient *googleads.Client, loginResolver logincustomer.Resolver) *setcampaignstatus.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := setcampaignstatusrepo.NewService(adsClient, loginResolver)
+
+	return setcampaignstatus.NewSetCampaignStatusTool(service)
+}
+
+func initUpdateCampaignBudgetTool(configs configs.Configs, adsClient *googleads.Client, loginResolver logincustomer.Resolver, currencyLookup currency.Lookup) *updatecampaignbudget.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := updatecampaignbudgetrepo.NewService(adsClient, loginResolver, configs.BudgetConfig.MaxChangePercent, configs.BudgetConfig.MaxChangeMicros)
+
+	return updatecampaignbudget.NewUpdateCampaignBudgetTool(service, currencyLookup)
+}
+
+func initAddNegativeKeywordsTool(configs configs.Configs, adsClient *googleads.Client, loginResolver logincustomer.Resolver) *addnegativekeywords.Tool {
+	// loginResolver picks the login-customer-id header per request, defaulting to the configured manager account
+	service := addnegativekeywordsrepo.NewService(adsClient, loginResolver)
+
+	return addnegativekeywords.NewAddNegativeKeywordsTool(service)
+}
+
+func initLoginCustomerResolver(configs configs.Configs, adsClient *googleads.Client) *logincustomer.CachedResolver {
 	logger := local.NewLogger()
 
-	// Use the service account JSON from Google Secret Manager
-	tokenManager, err := auth.NewTokenManagerFromServiceAccount([]byte(configs.GoogleAdsConfig.ServiceAccountJSON), auth.GoogleAdsScope)
-	if err != nil {
-		panic("failed to initialize token manager: " + err.Error())
-	}
+	// The configured customer ID is the root manager of the hierarchy
+	return logincustomer.NewCachedResolver(adsClient, logger, configs.GoogleAdsConfig.CustomerID)
+}
 
-	// loginCustomerID is the manager account ID from config (used in login-customer-id header)
-	service := searchcampaignsrepo.NewService(httpClient, logger, tokenManager, configs.GoogleAdsConfig.CustomerID, configs.GoogleAdsConfig.DeveloperToken)
+func initCurrencyLookup(configs configs.Configs, adsClient *googleads.Client, loginResolver logincustomer.Resolver) *currency.CachedLookup {
+	logger := local.NewLogger()
 
-	return searchcampaigns.NewSearchCampaignsTool(service)
+	return currency.NewCachedLookup(adsClient, logger, loginResolver)
 }
 
-func initSearchAdGroupsTool(configs configs.Configs) *searchadgroups.Tool {
-	httpClient := http.NewClient(nil)
+func initGoogleAdsClient(configs configs.Configs) *googleads.Client {
 	logger := local.NewLogger()
 
+	return googleads.NewClient(initTransport(configs), logger)
+}
+
+func initSearchClient(configs configs.Configs, adsClient *googleads.Client) *searchcache.Client {
+	if configs.CacheConfig.MaxEntries == 0 {
+		return searchcache.NewClient(adsClient, nil)
+	}
+
+	cache, err := searchcache.New(searchcache.Options{
+		MaxEntries: configs.CacheConfig.MaxEntries,
+		TodayTTL:   configs.CacheConfig.TodayTTL,
+		PastTTL:    configs.CacheConfig.PastTTL,
+		Dir:        configs.CacheConfig.Dir,
+	}, local.NewLogger())
+	if err != nil {
+		panic("failed to initialize search cache: " + err.Error())
+	}
+
+	return searchcache.NewClient(adsClient, cache)
+}
+
+func initTransport(configs configs.Configs) transport.Transport {
+	if configs.TransportConfig.CassetteMode != "" {
+		return initCassetteTransport(configs)
+	}
+
 	// Use the service account JSON from Google Secret Manager
 	tokenManager, err := auth.NewTokenManagerFromServiceAccount([]byte(configs.GoogleAdsConfig.ServiceAccountJSON), auth.GoogleAdsScope)
 	if err != nil {
 		panic("failed to initialize token manager: " + err.Error())
 	}
 
-	// loginCustomerID is the manager account ID from config (used in login-customer-id header)
-	service := searchadgroupsrepo.NewService(httpClient, logger, tokenManager, configs.GoogleAdsConfig.CustomerID, configs.GoogleAdsConfig.DeveloperToken)
+	if configs.TransportConfig.Kind == transport.KindGRPC {
+		conn, err := transport.DialGRPC(transport.DefaultGRPCAddress, tokenManager)
+		if err != nil {
+			panic("failed to initialize gRPC transport: " + err.Error())
+		}
+		return transport.NewGRPC(conn, configs.GoogleAdsConfig.DeveloperToken)
+	}
 
-	return searchadgroups.NewSearchAdGroupsTool(service)
+	return transport.NewREST(http.NewClient(nil), tokenManager, configs.GoogleAdsConfig.DeveloperToken, configs.TransportConfig.BaseURL, configs.TransportConfig.APIVersion)
 }
 
-func initSearchAdsTool(configs configs.Configs) *searchads.Tool {
-	httpClient := http.NewClient(nil)
-	logger := local.NewLogger()
+// initCassetteTransport records REST traffic to the cassette file or replays it.
+// Replays run offline, so they send a placeholder token instead of fetching one.
+func initCassetteTransport(configs configs.Configs) transport.Transport {
+	cassette, err := http.NewCassette(configs.TransportConfig.CassetteMode, configs.TransportConfig.CassetteFile)
+	if err != nil {
+		panic("failed to open cassette: " + err.Error())
+	}
 
-	// Use the service account JSON from Google Secret Manager
-	tokenManager, err := auth.NewTokenManagerFromServiceAccount([]byte(configs.GoogleAdsConfig.ServiceAccountJSON), auth.GoogleAdsScope)
+	var tokenProvider auth.TokenProvider = auth.StaticToken("replay")
+	if configs.TransportConfig.CassetteMode == http.CassetteRecord {
+		tokenProvider, err = auth.NewTokenManagerFromServiceAccount([]byte(configs.GoogleAdsConfig.ServiceAccountJSON), auth.GoogleAdsScope)
+		if err != nil {
+			panic("failed to initialize token manager: " + err.Error())
+		}
+	}
+
+	httpConfig := http.DefaultConfig()
+	httpConfig.Cassette = cassette
+
+	return transport.NewREST(http.NewClient(httpConfig), tokenProvider, configs.GoogleAdsConfig.DeveloperToken, configs.TransportConfig.BaseURL, configs.TransportConfig.APIVersion)
+}
+
+func initFXConverter(configs configs.Configs) *fx.Converter {
+	if configs.FXConfig.RatesFile == "" {
+		return fx.NewConverter(nil, "")
+	}
+
+	table, err := fx.LoadFile(configs.FXConfig.RatesFile)
 	if err != nil {
-		panic("failed to initialize token manager: " + err.Error())
+		panic("failed to load FX rates: " + err.Error())
 	}
 
-	// loginCustomerID is the manager account ID from config (used in login-customer-id header)
-	service := searchadsrepo.NewService(httpClient, logger, tokenManager, configs.GoogleAdsConfig.CustomerID, configs.GoogleAdsConfig.DeveloperToken)
+	return fx.NewConverter(table, configs.FXConfig.ReportingCurrency)
+}
+
+func fanOutOptions(configs configs.Configs) fanout.Options {
+	return fanout.Options{
+		MaxConcurrency: configs.FanOutConfig.MaxConcurrency,
+		AccountTimeout: configs.FanOutConfig.AccountTimeout,
+	}
+}
+
+// projectedOutputSchema infers the output schema of a tool that accepts fields; its
+// items only carry the selected fields, so their properties are not required.
+func projectedOutputSchema[T any]() *jsonschema.Schema {
+	schema, err := projection.OutputSchema[T]()
+	if err != nil {
+		panic("failed to infer output schema: " + err.Error())
+	}
+	return schema
+}
 
-	return searchads.NewSearchAdsTool(service)
+func responseBudget(configs configs.Configs) responsebudget.Budget {
+	return responsebudget.New(configs.ResponseConfig.MaxBytes, configs.ResponseConfig.MaxTokens)
 }
 
 func initImplementation() *mcp.Implementation {
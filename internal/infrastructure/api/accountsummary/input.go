@@ -0,0 +1,11 @@
+package accountsummary
+
+// Filters captures the parameters used to summarize several accounts.
+type Filters struct {
+	CustomerIDs     []string // The single value "all" expands to every client account below the manager
+	LoginCustomerID string
+	DateRangeStart  string
+	DateRangeEnd    string
+	// ReportingCurrency converts the money metrics to this currency; empty uses the configured default
+	ReportingCurrency string
+}
@@ -0,0 +1,78 @@
+package accountsummary
+
+import (
+	"google-ads-mcp/internal/infrastructure/api/fanout"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/fx"
+)
+
+// Account holds the account-level totals of a single customer.
+type Account struct {
+	CustomerID   string
+	Name         string
+	CurrencyCode string
+	TimeZone     string
+	// DateRangeStart and DateRangeEnd are the dates summarized; without a requested
+	// range both are yesterday in the account time zone
+	DateRangeStart string
+	DateRangeEnd   string
+	Metrics        Metrics
+	Converted      *Converted // Set when a reporting currency is requested and a rate is found
+	// ConversionErr is set when the account could not be converted to the reporting currency
+	ConversionErr error
+}
+
+// Converted holds the money metrics of an account in the reporting currency.
+type Converted struct {
+	Rate              fx.Rate
+	CostMicros        int64
+	ConversionsValue  float64
+	AverageCPC        int64   // Average cost per click in micros
+	CostPerConversion float64 // Cost per conversion in micros
+}
+
+// ConvertedRollup sums the converted money metrics of the accounts that could be converted.
+type ConvertedRollup struct {
+	CurrencyCode        string
+	CostMicros          int64
+	ConversionsValue    float64
+	AverageCPC          int64   // Average cost per click in micros
+	CostPerConversion   float64 // Cost per conversion in micros
+	RateDates           []string
+	UnconvertedAccounts []string // Accounts left out of the converted totals
+}
+
+// Metrics represents account-level performance metrics.
+type Metrics struct {
+	Clicks            int64    // Total clicks
+	Impressions       int64    // Total impressions
+	CTR               float64  // Click-through rate
+	AverageCPC        int64    // Average cost per click in micros
+	CostMicros        int64    // Total cost in micros
+	Conversions       float64  // Conversions
+	ConversionsValue  float64  // Total conversion value
+	CostPerConversion float64  // Cost per conversion in micros
+	Interactions      int64    // Total interactions (clicks + engagements)
+	KPIs              kpi.KPIs // Derived ratios (ROAS, CPA, CPM, ...)
+}
+
+// Rollup sums the metrics of every account that succeeded. Metrics amounts are
+// added as reported, in each account's own currency; Converted holds the totals
+// in the reporting currency.
+type Rollup struct {
+	AccountCount   int
+	SucceededCount int
+	FailedCount    int
+	Metrics        Metrics          // Ratios are recomputed from the totals
+	Converted      *ConvertedRollup // Set when a reporting currency is requested
+}
+
+// Result aggregates the per-account summaries and their rollup.
+type Result struct {
+	Accounts       []fanout.Result[Account] // One entry per account, in request order; Err is set for failed accounts
+	Rollup         Rollup
+	DateRangeStart string // Empty when defaulted accounts disagree on yesterday; see Account
+	DateRangeEnd   string
+	// ReportingCurrency is the currency amounts were converted to; empty when no conversion was requested
+	ReportingCurrency string
+}
@@ -0,0 +1,267 @@
+package accountsummary
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"google-ads-mcp/internal/infrastructure/api/fanout"
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/fx"
+	"google-ads-mcp/internal/infrastructure/log"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+)
+
+type Service struct {
+	client        *googleads.Client
+	logger        log.Logger
+	loginResolver logincustomer.Resolver
+	accountLister logincustomer.AccountLister
+	fanOut        fanout.Options
+	converter     *fx.Converter
+}
+
+func NewService(client *googleads.Client, logger log.Logger, loginResolver logincustomer.Resolver, accountLister logincustomer.AccountLister, fanOut fanout.Options, converter *fx.Converter) *Service {
+	return &Service{
+		client:        client,
+		logger:        logger,
+		loginResolver: loginResolver,
+		accountLister: accountLister,
+		fanOut:        fanOut,
+		converter:     converter,
+	}
+}
+
+// Summarize fetches the account-level totals of every account with a bounded worker
+// pool. Failed accounts are reported in the result instead of failing the call.
+// Without a date range, yesterday in each account's time zone is summarized, and the
+// result carries the date only when every account agrees on it.
+func (s *Service) Summarize(ctx context.Context, filters Filters) (Result, error) {
+	query, err := s.buildQuery(filters)
+	if err != nil {
+		return Result{}, err
+	}
+
+	reportingCurrency, err := s.converter.ReportingCurrency(filters.ReportingCurrency)
+	if err != nil {
+		return Result{}, fmt.Errorf("accountsummary: %w", err)
+	}
+
+	customerIDs, err := fanout.ResolveCustomerIDs(ctx, s.accountLister, filters.CustomerIDs, filters.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("accountsummary: resolving customer IDs: %w", err)
+	}
+
+	results := fanout.Run(ctx, customerIDs, s.fanOut, func(ctx context.Context, customerID string) (Account, error) {
+		account, err := s.summarizeAccount(ctx, customerID, filters.LoginCustomerID, query)
+		if err != nil {
+			return Account{}, err
+		}
+		account.DateRangeStart, account.DateRangeEnd = filters.DateRangeStart, filters.DateRangeEnd
+		if account.DateRangeStart == "" && account.DateRangeEnd == "" {
+			account.DateRangeEnd = yesterdayIn(account.TimeZone)
+			account.DateRangeStart = account.DateRangeEnd
+		}
+		return account, nil
+	})
+
+	rollup := Rollup{AccountCount: len(results)}
+	for _, result := range results {
+		if result.Err != nil {
+			rollup.FailedCount++
+			s.logger.Warn(ctx, "google ads account summary failed for account", map[string]string{
+				"customer_id": result.CustomerID,
+				"error":       result.Err.Error(),
+			})
+			continue
+		}
+		rollup.SucceededCount++
+		addMetrics(&rollup.Metrics, result.Value.Metrics)
+	}
+	computeRatios(&rollup.Metrics)
+
+	if reportingCurrency != "" {
+		rollup.Converted = s.convert(results, reportingCurrency)
+	}
+
+	dateRangeStart, dateRangeEnd := filters.DateRangeStart, filters.DateRangeEnd
+	if dateRangeStart == "" && dateRangeEnd == "" {
+		dateRangeStart = commonYesterday(results)
+		dateRangeEnd = dateRangeStart
+	}
+
+	return Result{
+		Accounts:          results,
+		Rollup:            rollup,
+		DateRangeStart:    dateRangeStart,
+		DateRangeEnd:      dateRangeEnd,
+		ReportingCurrency: reportingCurrency,
+	}, nil
+}
+
+// commonYesterday returns the date summarized for every succeeded account, or an
+// empty string when their time zones put yesterday on different dates.
+func commonYesterday(results []fanout.Result[Account]) string {
+	date := ""
+	for _, result := range results {
+		if result.Err != nil || result.Value.DateRangeEnd == "" {
+			continue
+		}
+		if date != "" && date != result.Value.DateRangeEnd {
+			return ""
+		}
+		date = result.Value.DateRangeEnd
+	}
+	return date
+}
+
+// yesterdayIn returns yesterday's date in an account time zone, falling back to UTC
+// when the zone is unknown.
+func yesterdayIn(timeZone string) string {
+	location, err := time.LoadLocation(timeZone)
+	if err != nil || timeZone == "" {
+		location = time.UTC
+	}
+	return time.Now().In(location).AddDate(0, 0, -1).Format("2006-01-02")
+}
+
+// convert fills in the converted metrics of every succeeded account, using the
+// rate in effect on the last day of its report, and sums them.
+func (s *Service) convert(results []fanout.Result[Account], currency string) *ConvertedRollup {
+	rollup := &ConvertedRollup{CurrencyCode: currency}
+	var clicks int64
+	var conversions float64
+	for i := range results {
+		account := &results[i].Value
+		if results[i].Err != nil {
+			continue
+		}
+
+		rate, err := s.converter.Rate(account.CurrencyCode, currency, account.DateRangeEnd)
+		if err != nil {
+			account.ConversionErr = err
+			rollup.UnconvertedAccounts = append(rollup.UnconvertedAccounts, account.CustomerID)
+			continue
+		}
+
+		converted := &Converted{
+			Rate:             rate,
+			CostMicros:       rate.ConvertMicros(account.Metrics.CostMicros),
+			ConversionsValue: rate.Convert(account.Metrics.ConversionsValue),
+		}
+		converted.AverageCPC, converted.CostPerConversion = costRatios(converted.CostMicros, account.Metrics.Clicks, account.Metrics.Conversions)
+		account.Converted = converted
+
+		rollup.CostMicros += converted.CostMicros
+		rollup.ConversionsValue += converted.ConversionsValue
+		clicks += account.Metrics.Clicks
+		conversions += account.Metrics.Conversions
+		if rate.Date != "" && !slices.Contains(rollup.RateDates, rate.Date) {
+			rollup.RateDates = append(rollup.RateDates, rate.Date)
+		}
+	}
+	rollup.AverageCPC, rollup.CostPerConversion = costRatios(rollup.CostMicros, clicks, conversions)
+	slices.Sort(rollup.RateDates)
+
+	return rollup
+}
+
+// summarizeAccount fetches the totals of one account. The date range is only used
+// as a filter, so the API returns a single aggregated customer row.
+func (s *Service) summarizeAccount(ctx context.Context, customerID, loginCustomerIDOverride, query string) (Account, error) {
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, customerID, loginCustomerIDOverride)
+	if err != nil {
+		return Account{}, fmt.Errorf("accountsummary: resolving login customer ID: %w", err)
+	}
+
+	request := &services.SearchGoogleAdsRequest{CustomerId: customerID, Query: query}
+
+	account := Account{CustomerID: customerID}
+	_, err = s.client.SearchPages(ctx, request, loginCustomerID, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.GetResults() {
+			customer := row.GetCustomer()
+			if customer == nil {
+				continue
+			}
+			account.Name = customer.GetDescriptiveName()
+			account.CurrencyCode = customer.GetCurrencyCode()
+			account.TimeZone = customer.GetTimeZone()
+
+			metricsResource := row.GetMetrics()
+			addMetrics(&account.Metrics, Metrics{
+				Clicks:           metricsResource.GetClicks(),
+				Impressions:      metricsResource.GetImpressions(),
+				CostMicros:       metricsResource.GetCostMicros(),
+				Conversions:      metricsResource.GetConversions(),
+				ConversionsValue: metricsResource.GetConversionsValue(),
+				Interactions:     metricsResource.GetInteractions(),
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return Account{}, fmt.Errorf("accountsummary: %w", err)
+	}
+	computeRatios(&account.Metrics)
+
+	return account, nil
+}
+
+func (s *Service) buildQuery(filters Filters) (string, error) {
+	qb := gaql.NewQueryBuilder("customer").Select(
+		"customer.id",
+		"customer.descriptive_name",
+		"customer.currency_code",
+		"customer.time_zone",
+		"metrics.clicks",
+		"metrics.impressions",
+		"metrics.cost_micros",
+		"metrics.conversions",
+		"metrics.conversions_value",
+		"metrics.interactions",
+	)
+
+	if filters.DateRangeStart == "" && filters.DateRangeEnd == "" {
+		// The API resolves YESTERDAY in the time zone of each account
+		qb.Where("segments.date DURING YESTERDAY")
+	} else if err := qb.WhereDateRange(filters.DateRangeStart, filters.DateRangeEnd); err != nil {
+		return "", fmt.Errorf("accountsummary: building query: %w", err)
+	}
+
+	return qb.Build(), nil
+}
+
+func addMetrics(total *Metrics, m Metrics) {
+	total.Clicks += m.Clicks
+	total.Impressions += m.Impressions
+	total.CostMicros += m.CostMicros
+	total.Conversions += m.Conversions
+	total.ConversionsValue += m.ConversionsValue
+	total.Interactions += m.Interactions
+}
+
+// computeRatios derives the ratio metrics from the summed totals.
+func computeRatios(m *Metrics) {
+	if m.Impressions > 0 {
+		m.CTR = float64(m.Clicks) / float64(m.Impressions)
+	}
+	m.AverageCPC, m.CostPerConversion = costRatios(m.CostMicros, m.Clicks, m.Conversions)
+	m.KPIs = kpi.Compute(kpi.Inputs{
+		Clicks:           m.Clicks,
+		Impressions:      m.Impressions,
+		Interactions:     m.Interactions,
+		CostMicros:       m.CostMicros,
+		Conversions:      m.Conversions,
+		ConversionsValue: m.ConversionsValue,
+	})
+}
+
+// costRatios returns the average cost per click and the cost per conversion, in micros.
+func costRatios(costMicros, clicks int64, conversions float64) (int64, float64) {
+	return kpi.AverageCPC(costMicros, clicks), float64(kpi.CPA(costMicros, conversions))
+}
@@ -0,0 +1,20 @@
+package addnegativekeywords
+
+// Request captures the negative keywords to add and where to add them.
+// CampaignID, AdGroupID or SharedSetID must be set; SharedSetID can be combined
+// with either of the others, but CampaignID and AdGroupID cannot.
+type Request struct {
+	CustomerID      string
+	LoginCustomerID string
+	CampaignID      string
+	AdGroupID       string
+	SharedSetID     string
+	Keywords        []Keyword
+	ValidateOnly    bool
+}
+
+// Keyword is a negative keyword to add. MatchType defaults to EXACT.
+type Keyword struct {
+	Text      string
+	MatchType string
+}
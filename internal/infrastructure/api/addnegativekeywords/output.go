@@ -0,0 +1,42 @@
+package addnegativekeywords
+
+// Result represents the outcome of adding negative keywords.
+type Result struct {
+	Targets       []Target
+	ValidateOnly  bool
+	Operations    []OperationResult
+	RequestErrors []OperationError // Errors that apply to the whole request; no operation succeeded
+	Skipped       []SkippedKeyword
+	RequestID     string
+}
+
+// Target is a campaign, ad group or shared set the keywords were added to.
+type Target struct {
+	Level        string // campaign, ad_group or shared_set
+	ResourceName string
+}
+
+// OperationResult represents the outcome of creating a single negative keyword.
+type OperationResult struct {
+	Index        int
+	Level        string // The level of the target the keyword was added to
+	Text         string
+	MatchType    string
+	ResourceName string
+	Success      bool
+	Errors       []OperationError
+}
+
+// OperationError represents an error reported by the API for one operation.
+type OperationError struct {
+	Code    string // e.g. "criterion_error.KEYWORD_TEXT_TOO_LONG"
+	Message string
+}
+
+// SkippedKeyword is a keyword that was not sent to the API.
+type SkippedKeyword struct {
+	Level     string // The target the keyword already exists on; empty for duplicates in the request
+	Text      string
+	MatchType string
+	Reason    string // already_exists or duplicate_in_request
+}
@@ -0,0 +1,408 @@
+package addnegativekeywords
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google-ads-mcp/internal/infrastructure/api/apierrors"
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+
+	"github.com/shenzhencenter/google-ads-pb/common"
+	"github.com/shenzhencenter/google-ads-pb/enums"
+	"github.com/shenzhencenter/google-ads-pb/resources"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+)
+
+// Levels at which negative keywords can be added.
+const (
+	LevelCampaign  = "campaign"
+	LevelAdGroup   = "ad_group"
+	LevelSharedSet = "shared_set"
+)
+
+// Reasons for not sending a keyword to the API.
+const (
+	SkipAlreadyExists      = "already_exists"
+	SkipDuplicateInRequest = "duplicate_in_request"
+)
+
+var matchTypes = map[string]enums.KeywordMatchTypeEnum_KeywordMatchType{
+	"EXACT":  enums.KeywordMatchTypeEnum_EXACT,
+	"PHRASE": enums.KeywordMatchTypeEnum_PHRASE,
+	"BROAD":  enums.KeywordMatchTypeEnum_BROAD,
+}
+
+type Service struct {
+	client        *googleads.Client
+	loginResolver logincustomer.Resolver
+}
+
+func NewService(client *googleads.Client, loginResolver logincustomer.Resolver) *Service {
+	return &Service{
+		client:        client,
+		loginResolver: loginResolver,
+	}
+}
+
+// target is the resolved campaign, ad group or shared set receiving the negatives.
+type target struct {
+	level        string
+	id           string
+	resourceName string
+}
+
+// negativeKeyword is a validated keyword with its dedupe key.
+type negativeKeyword struct {
+	text      string
+	matchType enums.KeywordMatchTypeEnum_KeywordMatchType
+}
+
+func (k negativeKeyword) key() string {
+	return keywordKey(k.text, k.matchType)
+}
+
+// addition is one keyword to create on one target.
+type addition struct {
+	target  target
+	keyword negativeKeyword
+}
+
+// AddNegativeKeywords creates negative keyword criteria on a campaign or ad group,
+// shared criteria in a negative keyword list, or both. Keywords that already exist
+// on a target, or that repeat within the request, are skipped before the mutate call.
+func (s *Service) AddNegativeKeywords(ctx context.Context, request Request) (Result, error) {
+	customerID := logincustomer.NormalizeCustomerID(request.CustomerID)
+	if customerID == "" {
+		return Result{}, fmt.Errorf("addnegativekeywords: invalid customer ID: customer ID is required")
+	}
+
+	targets, err := resolveTargets(customerID, request)
+	if err != nil {
+		return Result{}, fmt.Errorf("addnegativekeywords: %w", err)
+	}
+
+	keywords, err := normalizeKeywords(request.Keywords)
+	if err != nil {
+		return Result{}, fmt.Errorf("addnegativekeywords: %w", err)
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, request.CustomerID, request.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("addnegativekeywords: resolving login customer ID: %w", err)
+	}
+
+	result := Result{
+		ValidateOnly: request.ValidateOnly,
+		Operations:   make([]OperationResult, 0, len(keywords)*len(targets)),
+	}
+
+	var unique []negativeKeyword
+	seen := make(map[string]bool, len(keywords))
+	for _, keyword := range keywords {
+		if seen[keyword.key()] {
+			result.Skipped = append(result.Skipped, skippedKeyword(keyword, "", SkipDuplicateInRequest))
+			continue
+		}
+		seen[keyword.key()] = true
+		unique = append(unique, keyword)
+	}
+
+	var additions []addition
+	for _, tgt := range targets {
+		result.Targets = append(result.Targets, Target{Level: tgt.level, ResourceName: tgt.resourceName})
+
+		if tgt.level == LevelSharedSet {
+			if err := s.checkSharedSet(ctx, customerID, tgt, loginCustomerID); err != nil {
+				return Result{}, err
+			}
+		}
+
+		existing, err := s.getExistingNegatives(ctx, customerID, tgt, loginCustomerID)
+		if err != nil {
+			return Result{}, err
+		}
+		for _, keyword := range unique {
+			if existing[keyword.key()] {
+				result.Skipped = append(result.Skipped, skippedKeyword(keyword, tgt.level, SkipAlreadyExists))
+				continue
+			}
+			additions = append(additions, addition{target: tgt, keyword: keyword})
+		}
+	}
+	if len(additions) == 0 {
+		return result, nil
+	}
+
+	resourceNames, partialFailures, requestID, err := s.mutate(ctx, customerID, additions, request.ValidateOnly, loginCustomerID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Errors without an operation index fail the whole request
+	result.RequestErrors = mapOperationErrors(partialFailures[apierrors.RequestLevel])
+	for i, add := range additions {
+		operation := OperationResult{
+			Index:     i,
+			Level:     add.target.level,
+			Text:      add.keyword.text,
+			MatchType: strings.ToLower(add.keyword.matchType.String()),
+			Errors:    mapOperationErrors(partialFailures[i]),
+			Success:   len(partialFailures[i]) == 0 && len(result.RequestErrors) == 0,
+		}
+		if i < len(resourceNames) {
+			operation.ResourceName = resourceNames[i]
+		}
+		result.Operations = append(result.Operations, operation)
+	}
+	result.RequestID = requestID
+
+	return result, nil
+}
+
+func skippedKeyword(keyword negativeKeyword, level, reason string) SkippedKeyword {
+	return SkippedKeyword{
+		Level:     level,
+		Text:      keyword.text,
+		MatchType: strings.ToLower(keyword.matchType.String()),
+		Reason:    reason,
+	}
+}
+
+// resolveTargets returns the campaign or ad group and the shared set of request. A
+// shared set can be combined with a campaign or an ad group, which receive the same
+// keywords.
+func resolveTargets(customerID string, request Request) ([]target, error) {
+	var targets []target
+	if id := strings.TrimSpace(request.CampaignID); id != "" {
+		targets = append(targets, target{level: LevelCampaign, id: id, resourceName: fmt.Sprintf("customers/%s/campaigns/%s", customerID, id)})
+	}
+	if id := strings.TrimSpace(request.AdGroupID); id != "" {
+		targets = append(targets, target{level: LevelAdGroup, id: id, resourceName: fmt.Sprintf("customers/%s/adGroups/%s", customerID, id)})
+	}
+	if len(targets) > 1 {
+		return nil, fmt.Errorf("campaign_id and ad_group_id cannot be combined")
+	}
+	if id := strings.TrimSpace(request.SharedSetID); id != "" {
+		targets = append(targets, target{level: LevelSharedSet, id: id, resourceName: fmt.Sprintf("customers/%s/sharedSets/%s", customerID, id)})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("one of campaign_id, ad_group_id or shared_set_id is required")
+	}
+	for _, tgt := range targets {
+		if _, err := strconv.ParseInt(tgt.id, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid %s ID %q: must be numeric", tgt.level, tgt.id)
+		}
+	}
+
+	return targets, nil
+}
+
+func normalizeKeywords(keywords []Keyword) ([]negativeKeyword, error) {
+	if len(keywords) == 0 {
+		return nil, fmt.Errorf("at least one keyword is required")
+	}
+
+	normalized := make([]negativeKeyword, 0, len(keywords))
+	for _, keyword := range keywords {
+		text := strings.Join(strings.Fields(keyword.Text), " ")
+		if text == "" {
+			return nil, fmt.Errorf("keyword text is required")
+		}
+
+		matchTypeName := strings.ToUpper(strings.TrimSpace(keyword.MatchType))
+		if matchTypeName == "" {
+			matchTypeName = "EXACT"
+		}
+		matchType, ok := matchTypes[matchTypeName]
+		if !ok {
+			return nil, fmt.Errorf("invalid match type %q for keyword %q: must be one of EXACT, PHRASE, BROAD", keyword.MatchType, text)
+		}
+
+		normalized = append(normalized, negativeKeyword{text: text, matchType: matchType})
+	}
+
+	return normalized, nil
+}
+
+// keywordKey identifies a keyword regardless of case and spacing.
+func keywordKey(text string, matchType enums.KeywordMatchTypeEnum_KeywordMatchType) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " ")) + "|" + matchType.String()
+}
+
+// checkSharedSet fails unless the shared set exists and is a negative keyword list;
+// keywords cannot be added to placement or other lists.
+func (s *Service) checkSharedSet(ctx context.Context, customerID string, tgt target, loginCustomerID string) error {
+	query := gaql.NewQueryBuilder("shared_set").
+		Select("shared_set.type").
+		Where("shared_set.id = " + tgt.id).
+		Build()
+
+	response, err := s.client.Search(ctx, &services.SearchGoogleAdsRequest{CustomerId: customerID, Query: query}, loginCustomerID)
+	if err != nil {
+		return fmt.Errorf("addnegativekeywords: %w", err)
+	}
+	if len(response.GetResults()) == 0 {
+		return fmt.Errorf("addnegativekeywords: shared set %s not found", tgt.id)
+	}
+	if setType := response.GetResults()[0].GetSharedSet().GetType(); setType != enums.SharedSetTypeEnum_NEGATIVE_KEYWORDS {
+		return fmt.Errorf("addnegativekeywords: shared set %s is a %s list, not a NEGATIVE_KEYWORDS list", tgt.id, setType)
+	}
+	return nil
+}
+
+// getExistingNegatives returns the dedupe keys of the negative keywords already on the target.
+func (s *Service) getExistingNegatives(ctx context.Context, customerID string, tgt target, loginCustomerID string) (map[string]bool, error) {
+	var qb *gaql.QueryBuilder
+	switch tgt.level {
+	case LevelCampaign:
+		qb = gaql.NewQueryBuilder("campaign_criterion").
+			Select("campaign_criterion.keyword.text", "campaign_criterion.keyword.match_type").
+			Where("campaign.id = " + tgt.id).
+			Where("campaign_criterion.negative = TRUE").
+			Where("campaign_criterion.type = KEYWORD").
+			Where("campaign_criterion.status != REMOVED")
+	case LevelAdGroup:
+		qb = gaql.NewQueryBuilder("ad_group_criterion").
+			Select("ad_group_criterion.keyword.text", "ad_group_criterion.keyword.match_type").
+			Where("ad_group.id = " + tgt.id).
+			Where("ad_group_criterion.negative = TRUE").
+			Where("ad_group_criterion.type = KEYWORD").
+			Where("ad_group_criterion.status != REMOVED")
+	case LevelSharedSet:
+		qb = gaql.NewQueryBuilder("shared_criterion").
+			Select("shared_criterion.keyword.text", "shared_criterion.keyword.match_type").
+			Where("shared_set.id = " + tgt.id).
+			Where("shared_criterion.type = KEYWORD")
+	}
+
+	existing := make(map[string]bool)
+	request := &services.SearchGoogleAdsRequest{CustomerId: customerID, Query: qb.Build()}
+	_, err := s.client.SearchPages(ctx, request, loginCustomerID, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.GetResults() {
+			var keyword *common.KeywordInfo
+			switch tgt.level {
+			case LevelCampaign:
+				keyword = row.GetCampaignCriterion().GetKeyword()
+			case LevelAdGroup:
+				keyword = row.GetAdGroupCriterion().GetKeyword()
+			case LevelSharedSet:
+				keyword = row.GetSharedCriterion().GetKeyword()
+			}
+			if keyword != nil {
+				existing[keywordKey(keyword.GetText(), keyword.GetMatchType())] = true
+			}
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("addnegativekeywords: %w", err)
+	}
+
+	return existing, nil
+}
+
+// mutate creates the criteria with partial failure enabled and returns the resource
+// name of every operation together with its errors, grouped by operation index.
+func (s *Service) mutate(ctx context.Context, customerID string, additions []addition, validateOnly bool, loginCustomerID string) ([]string, map[int][]apierrors.Detail, string, error) {
+	operations := make([]*services.MutateOperation, 0, len(additions))
+	for _, add := range additions {
+		tgt, keyword := add.target, add.keyword
+		var operation *services.MutateOperation
+		switch tgt.level {
+		case LevelCampaign:
+			operation = &services.MutateOperation{Operation: &services.MutateOperation_CampaignCriterionOperation{
+				CampaignCriterionOperation: &services.CampaignCriterionOperation{
+					Operation: &services.CampaignCriterionOperation_Create{
+						Create: &resources.CampaignCriterion{
+							Campaign:  proto.String(tgt.resourceName),
+							Negative:  proto.Bool(true),
+							Criterion: &resources.CampaignCriterion_Keyword{Keyword: keywordInfo(keyword)},
+						},
+					},
+				},
+			}}
+		case LevelAdGroup:
+			operation = &services.MutateOperation{Operation: &services.MutateOperation_AdGroupCriterionOperation{
+				AdGroupCriterionOperation: &services.AdGroupCriterionOperation{
+					Operation: &services.AdGroupCriterionOperation_Create{
+						Create: &resources.AdGroupCriterion{
+							AdGroup:   proto.String(tgt.resourceName),
+							Negative:  proto.Bool(true),
+							Criterion: &resources.AdGroupCriterion_Keyword{Keyword: keywordInfo(keyword)},
+						},
+					},
+				},
+			}}
+		case LevelSharedSet:
+			operation = &services.MutateOperation{Operation: &services.MutateOperation_SharedCriterionOperation{
+				SharedCriterionOperation: &services.SharedCriterionOperation{
+					Operation: &services.SharedCriterionOperation_Create{
+						Create: &resources.SharedCriterion{
+							SharedSet: proto.String(tgt.resourceName),
+							Criterion: &resources.SharedCriterion_Keyword{Keyword: keywordInfo(keyword)},
+						},
+					},
+				},
+			}}
+		}
+		operations = append(operations, operation)
+	}
+
+	mutateRequest := &services.MutateGoogleAdsRequest{
+		CustomerId:       customerID,
+		MutateOperations: operations,
+		PartialFailure:   true,
+		ValidateOnly:     validateOnly,
+	}
+
+	mutateResponse, requestID, err := s.client.Mutate(ctx, mutateRequest, loginCustomerID)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("addnegativekeywords: %w", err)
+	}
+
+	var resourceNames []string
+	for i, response := range mutateResponse.GetMutateOperationResponses() {
+		if i >= len(additions) {
+			break
+		}
+		switch additions[i].target.level {
+		case LevelCampaign:
+			resourceNames = append(resourceNames, response.GetCampaignCriterionResult().GetResourceName())
+		case LevelAdGroup:
+			resourceNames = append(resourceNames, response.GetAdGroupCriterionResult().GetResourceName())
+		case LevelSharedSet:
+			resourceNames = append(resourceNames, response.GetSharedCriterionResult().GetResourceName())
+		}
+	}
+
+	partialFailures, err := apierrors.PartialFailures(mutateResponse.GetPartialFailureError())
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("addnegativekeywords: decoding partial failure: %w", err)
+	}
+
+	return resourceNames, partialFailures, requestID, nil
+}
+
+func keywordInfo(keyword negativeKeyword) *common.KeywordInfo {
+	return &common.KeywordInfo{
+		Text:      proto.String(keyword.text),
+		MatchType: keyword.matchType,
+	}
+}
+
+func mapOperationErrors(details []apierrors.Detail) []OperationError {
+	var errs []OperationError
+	for _, detail := range details {
+		errs = append(errs, OperationError{
+			Code:    detail.Code,
+			Message: detail.Message,
+		})
+	}
+	return errs
+}
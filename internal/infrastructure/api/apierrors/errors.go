@@ -0,0 +1,383 @@
+package apierrors
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	infrahttp "google-ads-mcp/internal/infrastructure/http"
+
+	pberrors "github.com/shenzhencenter/google-ads-pb/errors"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxRenderedDetails caps how many API errors are spelled out in an error message.
+const maxRenderedDetails = 3
+
+// Detail is a single error reported by the Google Ads API.
+type Detail struct {
+	Code       string // "<error_type>.<ENUM_VALUE>", e.g. "query_error.UNRECOGNIZED_FIELD"
+	Message    string
+	FieldPath  string // e.g. "operations[0].update.amount_micros"
+	Trigger    string // The value that triggered the error, when reported
+	RetryDelay time.Duration
+}
+
+// APIError is a failed Google Ads API call. The typed errors below embed it;
+// use errors.As to inspect the kind of failure.
+type APIError struct {
+	StatusCode int
+	Status     string // RPC status name, e.g. INVALID_ARGUMENT
+	Message    string
+	RequestID  string
+	Details    []Detail
+	Failure    *pberrors.GoogleAdsFailure
+}
+
+func (e *APIError) Error() string {
+	return e.render("google ads api error", "")
+}
+
+// AuthenticationError means the OAuth credentials were rejected.
+type AuthenticationError struct{ *APIError }
+
+func (e *AuthenticationError) Error() string {
+	return e.render("authentication failed", "Check the service account credentials and the OAuth scope.")
+}
+
+func (e *AuthenticationError) Unwrap() error { return e.APIError }
+
+// AuthorizationError means the caller may not access the customer or the operation.
+type AuthorizationError struct{ *APIError }
+
+func (e *AuthorizationError) Error() string {
+	return e.render("not authorized", "Check that the customer is linked to the login-customer-id manager account and that the developer token has the required access level.")
+}
+
+func (e *AuthorizationError) Unwrap() error { return e.APIError }
+
+// QuotaError means a rate or daily quota was exhausted.
+type QuotaError struct {
+	*APIError
+	RetryDelay time.Duration
+}
+
+func (e *QuotaError) Error() string {
+	hint := "Reduce the request rate or retry later."
+	if e.RetryDelay > 0 {
+		hint = fmt.Sprintf("Retry after %s.", e.RetryDelay)
+	}
+	return e.render("quota exceeded", hint)
+}
+
+func (e *QuotaError) Unwrap() error { return e.APIError }
+
+// QuerySyntaxError means the GAQL query was rejected.
+type QuerySyntaxError struct{ *APIError }
+
+func (e *QuerySyntaxError) Error() string {
+	return e.render("invalid query", "Fix the GAQL query and try again.")
+}
+
+func (e *QuerySyntaxError) Unwrap() error { return e.APIError }
+
+// FieldError means a request field has an invalid value.
+type FieldError struct {
+	*APIError
+	FieldPath string
+}
+
+func (e *FieldError) Error() string {
+	return e.render("invalid field", "")
+}
+
+func (e *FieldError) Unwrap() error { return e.APIError }
+
+// FromResponse decodes a non-2xx Google Ads REST response into a typed error.
+// Bodies that cannot be decoded still produce an *APIError with the HTTP status.
+func FromResponse(response *infrahttp.Response) error {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		RequestID:  http.Header(response.Headers).Get("request-id"),
+	}
+
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	var rpcStatus status.Status
+	if err := json.Unmarshal(response.Body, &envelope); err == nil && len(envelope.Error) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true, Resolver: Resolver{}}).Unmarshal(envelope.Error, &rpcStatus); err == nil {
+			apiErr.Message = rpcStatus.GetMessage()
+		}
+
+		var statusName struct {
+			Status string `json:"status"`
+		}
+		if err := json.Unmarshal(envelope.Error, &statusName); err == nil {
+			apiErr.Status = statusName.Status
+		}
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(http.StatusText(response.StatusCode))
+	}
+
+	failure, err := googleAdsFailure(&rpcStatus)
+	if err == nil && failure != nil {
+		apiErr.Failure = failure
+		apiErr.Details = details(failure)
+		if failure.GetRequestId() != "" {
+			apiErr.RequestID = failure.GetRequestId()
+		}
+	}
+
+	return classify(apiErr)
+}
+
+// FromStatus decodes the status of a failed Google Ads gRPC call into a typed error.
+// The status code is mapped to the HTTP status the REST interface would return.
+func FromStatus(rpcStatus *status.Status, requestID string) error {
+	rpcCode := code.Code(rpcStatus.GetCode())
+	apiErr := &APIError{
+		StatusCode: httpStatusCodes[rpcCode],
+		Status:     rpcCode.String(),
+		Message:    rpcStatus.GetMessage(),
+		RequestID:  requestID,
+	}
+
+	failure, err := googleAdsFailure(rpcStatus)
+	if err == nil && failure != nil {
+		apiErr.Failure = failure
+		apiErr.Details = details(failure)
+		if failure.GetRequestId() != "" {
+			apiErr.RequestID = failure.GetRequestId()
+		}
+	}
+
+	return classify(apiErr)
+}
+
+// httpStatusCodes maps RPC status codes to HTTP statuses as in google/rpc/code.proto.
+var httpStatusCodes = map[code.Code]int{
+	code.Code_CANCELLED:           499,
+	code.Code_UNKNOWN:             http.StatusInternalServerError,
+	code.Code_INVALID_ARGUMENT:    http.StatusBadRequest,
+	code.Code_DEADLINE_EXCEEDED:   http.StatusGatewayTimeout,
+	code.Code_NOT_FOUND:           http.StatusNotFound,
+	code.Code_ALREADY_EXISTS:      http.StatusConflict,
+	code.Code_PERMISSION_DENIED:   http.StatusForbidden,
+	code.Code_UNAUTHENTICATED:     http.StatusUnauthorized,
+	code.Code_RESOURCE_EXHAUSTED:  http.StatusTooManyRequests,
+	code.Code_FAILED_PRECONDITION: http.StatusBadRequest,
+	code.Code_ABORTED:             http.StatusConflict,
+	code.Code_OUT_OF_RANGE:        http.StatusBadRequest,
+	code.Code_UNIMPLEMENTED:       http.StatusNotImplemented,
+	code.Code_INTERNAL:            http.StatusInternalServerError,
+	code.Code_UNAVAILABLE:         http.StatusServiceUnavailable,
+	code.Code_DATA_LOSS:           http.StatusInternalServerError,
+}
+
+// RequestLevel is the PartialFailures key of the errors that do not point at an
+// operation; they apply to the request as a whole.
+const RequestLevel = -1
+
+// PartialFailures groups the errors of a mutate partial failure by operation index.
+// Errors that do not point at an operation are grouped under RequestLevel.
+func PartialFailures(partialFailure *status.Status) (map[int][]Detail, error) {
+	grouped := make(map[int][]Detail)
+	failure, err := googleAdsFailure(partialFailure)
+	if err != nil || failure == nil {
+		return grouped, err
+	}
+
+	failureDetails := details(failure)
+	for i, adsErr := range failure.GetErrors() {
+		index := operationIndex(adsErr)
+		grouped[index] = append(grouped[index], failureDetails[i])
+	}
+
+	return grouped, nil
+}
+
+// UnmarshalResponse decodes a Google Ads JSON response, resolving error details
+// of newer API versions to the compiled message types.
+func UnmarshalResponse(body []byte, message proto.Message) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true, Resolver: Resolver{}}.Unmarshal(body, message)
+}
+
+func classify(apiErr *APIError) error {
+	group := ""
+	if len(apiErr.Details) > 0 {
+		group, _, _ = strings.Cut(apiErr.Details[0].Code, ".")
+	}
+
+	switch group {
+	case "authentication_error":
+		return &AuthenticationError{APIError: apiErr}
+	case "authorization_error":
+		return &AuthorizationError{APIError: apiErr}
+	case "quota_error":
+		return &QuotaError{APIError: apiErr, RetryDelay: apiErr.Details[0].RetryDelay}
+	case "query_error":
+		return &QuerySyntaxError{APIError: apiErr}
+	case "field_error", "field_mask_error":
+		return &FieldError{APIError: apiErr, FieldPath: apiErr.Details[0].FieldPath}
+	}
+
+	if len(apiErr.Details) > 0 && apiErr.Details[0].FieldPath != "" {
+		return &FieldError{APIError: apiErr, FieldPath: apiErr.Details[0].FieldPath}
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusUnauthorized:
+		return &AuthenticationError{APIError: apiErr}
+	case http.StatusForbidden:
+		return &AuthorizationError{APIError: apiErr}
+	case http.StatusTooManyRequests:
+		return &QuotaError{APIError: apiErr}
+	}
+
+	return apiErr
+}
+
+// render builds a short message: what failed, the API errors, a hint and the request ID.
+func (e *APIError) render(kind, hint string) string {
+	var b strings.Builder
+	b.WriteString(kind)
+	b.WriteString(": ")
+
+	if len(e.Details) == 0 {
+		b.WriteString(strings.TrimSuffix(e.Message, "."))
+		if e.StatusCode > 0 {
+			fmt.Fprintf(&b, " (HTTP %d)", e.StatusCode)
+		}
+	}
+
+	for i, detail := range e.Details {
+		if i == maxRenderedDetails {
+			fmt.Fprintf(&b, "; and %d more", len(e.Details)-maxRenderedDetails)
+			break
+		}
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		if detail.FieldPath != "" {
+			fmt.Fprintf(&b, "%s: ", detail.FieldPath)
+		}
+		b.WriteString(strings.TrimSuffix(detail.Message, "."))
+		if detail.Trigger != "" {
+			fmt.Fprintf(&b, " (got %q)", detail.Trigger)
+		}
+		if detail.Code != "" {
+			fmt.Fprintf(&b, " [%s]", detail.Code)
+		}
+	}
+
+	message := b.String()
+	if hint != "" {
+		message += ". " + hint
+	}
+	if e.RequestID != "" {
+		message += fmt.Sprintf(" (request-id: %s)", e.RequestID)
+	}
+
+	return message
+}
+
+// googleAdsFailure returns the GoogleAdsFailure carried in the status details, if any.
+func googleAdsFailure(rpcStatus *status.Status) (*pberrors.GoogleAdsFailure, error) {
+	for _, detail := range rpcStatus.GetDetails() {
+		if !strings.HasSuffix(detail.GetTypeUrl(), ".errors.GoogleAdsFailure") {
+			continue
+		}
+
+		// The detail was resolved to the compiled GoogleAdsFailure, which may carry
+		// an older API version in its type URL, so decode the payload directly.
+		var failure pberrors.GoogleAdsFailure
+		if err := proto.Unmarshal(detail.GetValue(), &failure); err != nil {
+			return nil, err
+		}
+		return &failure, nil
+	}
+
+	return nil, nil
+}
+
+func details(failure *pberrors.GoogleAdsFailure) []Detail {
+	result := make([]Detail, 0, len(failure.GetErrors()))
+	for _, adsErr := range failure.GetErrors() {
+		detail := Detail{
+			Code:      errorCode(adsErr.GetErrorCode()),
+			Message:   adsErr.GetMessage(),
+			FieldPath: fieldPath(adsErr.GetLocation()),
+			Trigger:   trigger(adsErr),
+		}
+		if delay := adsErr.GetDetails().GetQuotaErrorDetails().GetRetryDelay(); delay != nil {
+			detail.RetryDelay = delay.AsDuration()
+		}
+		result = append(result, detail)
+	}
+	return result
+}
+
+// operationIndex returns the index of the operation an error refers to, or -1.
+// googleAds:mutate locates errors under "mutate_operations" instead of "operations".
+func operationIndex(adsErr *pberrors.GoogleAdsError) int {
+	for _, element := range adsErr.GetLocation().GetFieldPathElements() {
+		name := element.GetFieldName()
+		if (name == "operations" || name == "mutate_operations") && element.Index != nil {
+			return int(element.GetIndex())
+		}
+	}
+	return -1
+}
+
+// fieldPath renders an error location as "operations[0].update.status".
+func fieldPath(location *pberrors.ErrorLocation) string {
+	parts := make([]string, 0, len(location.GetFieldPathElements()))
+	for _, element := range location.GetFieldPathElements() {
+		part := element.GetFieldName()
+		if element.Index != nil {
+			part = fmt.Sprintf("%s[%d]", part, element.GetIndex())
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// errorCode formats the populated member of an ErrorCode oneof as "<error_type>.<ENUM_VALUE>".
+func errorCode(code *pberrors.ErrorCode) string {
+	if code == nil {
+		return ""
+	}
+
+	var formatted string
+	code.ProtoReflect().Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := fmt.Sprintf("%d", value.Enum())
+		if enumValue := field.Enum().Values().ByNumber(value.Enum()); enumValue != nil {
+			name = string(enumValue.Name())
+		}
+		formatted = fmt.Sprintf("%s.%s", field.Name(), name)
+		return false
+	})
+	return formatted
+}
+
+func trigger(adsErr *pberrors.GoogleAdsError) string {
+	if adsErr.GetTrigger() == nil {
+		return ""
+	}
+
+	var formatted string
+	adsErr.GetTrigger().ProtoReflect().Range(func(_ protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		formatted = fmt.Sprint(value.Interface())
+		return false
+	})
+	return formatted
+}
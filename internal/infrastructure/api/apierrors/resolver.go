@@ -0,0 +1,39 @@
+package apierrors
+
+import (
+	"errors"
+	"regexp"
+
+	pberrors "github.com/shenzhencenter/google-ads-pb/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var apiVersionRegex = regexp.MustCompile(`googleads\.v\d+\.`)
+
+// Resolver resolves google.protobuf.Any payloads of a newer API version
+// (e.g. v22 errors) to the message types compiled into google-ads-pb.
+type Resolver struct{}
+
+func (Resolver) FindMessageByName(name protoreflect.FullName) (protoreflect.MessageType, error) {
+	return protoregistry.GlobalTypes.FindMessageByName(name)
+}
+
+func (Resolver) FindMessageByURL(url string) (protoreflect.MessageType, error) {
+	messageType, err := protoregistry.GlobalTypes.FindMessageByURL(url)
+	if !errors.Is(err, protoregistry.NotFound) {
+		return messageType, err
+	}
+
+	compiled := string((&pberrors.GoogleAdsFailure{}).ProtoReflect().Descriptor().FullName())
+	version := apiVersionRegex.FindString(compiled)
+	return protoregistry.GlobalTypes.FindMessageByURL(apiVersionRegex.ReplaceAllString(url, version))
+}
+
+func (Resolver) FindExtensionByName(field protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	return protoregistry.GlobalTypes.FindExtensionByName(field)
+}
+
+func (Resolver) FindExtensionByNumber(message protoreflect.FullName, field protoreflect.FieldNumber) (protoreflect.ExtensionType, error) {
+	return protoregistry.GlobalTypes.FindExtensionByNumber(message, field)
+}
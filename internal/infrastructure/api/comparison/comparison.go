@@ -0,0 +1,200 @@
+package comparison
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Supported compare_to modes.
+const (
+	PreviousPeriod = "previous_period"
+	PreviousYear   = "previous_year"
+	Custom         = "custom"
+)
+
+// Presence tells in which of the compared periods an entity returned data.
+type Presence string
+
+const (
+	PresenceBoth         Presence = "both"
+	PresenceCurrentOnly  Presence = "current_only"
+	PresencePreviousOnly Presence = "previous_only"
+)
+
+// Request captures the date ranges involved in a period-over-period comparison.
+type Request struct {
+	CompareTo             string
+	DateRangeStart        string
+	DateRangeEnd          string
+	CompareDateRangeStart string
+	CompareDateRangeEnd   string
+}
+
+// Period is a resolved pair of date ranges (YYYY-MM-DD, inclusive).
+type Period struct {
+	CurrentStart  string
+	CurrentEnd    string
+	PreviousStart string
+	PreviousEnd   string
+}
+
+// Enabled reports whether the request asks for a comparison at all.
+func (r Request) Enabled() bool {
+	return strings.TrimSpace(r.CompareTo) != "" || r.CompareDateRangeStart != "" || r.CompareDateRangeEnd != ""
+}
+
+// ResolvePeriod validates the request and computes the comparison date range.
+// Explicit compare dates imply the custom mode when compare_to is empty.
+func ResolvePeriod(r Request) (Period, error) {
+	if r.DateRangeStart == "" || r.DateRangeEnd == "" {
+		return Period{}, fmt.Errorf("date_range_start and date_range_end are required when comparing periods")
+	}
+
+	start, err := time.Parse(dateLayout, r.DateRangeStart)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid date format for start date %q: expected YYYY-MM-DD", r.DateRangeStart)
+	}
+	end, err := time.Parse(dateLayout, r.DateRangeEnd)
+	if err != nil {
+		return Period{}, fmt.Errorf("invalid date format for end date %q: expected YYYY-MM-DD", r.DateRangeEnd)
+	}
+	if end.Before(start) {
+		return Period{}, fmt.Errorf("date_range_end %q is before date_range_start %q", r.DateRangeEnd, r.DateRangeStart)
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(r.CompareTo))
+	if mode == "" {
+		mode = Custom
+	}
+
+	var previousStart, previousEnd time.Time
+	switch mode {
+	case PreviousPeriod:
+		days := int(end.Sub(start).Hours()/24) + 1
+		previousEnd = start.AddDate(0, 0, -1)
+		previousStart = previousEnd.AddDate(0, 0, -(days - 1))
+	case PreviousYear:
+		previousStart = start.AddDate(-1, 0, 0)
+		previousEnd = end.AddDate(-1, 0, 0)
+	case Custom:
+		if r.CompareDateRangeStart == "" || r.CompareDateRangeEnd == "" {
+			return Period{}, fmt.Errorf("compare_date_range_start and compare_date_range_end are required for custom comparisons")
+		}
+		previousStart, err = time.Parse(dateLayout, r.CompareDateRangeStart)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid date format for compare start date %q: expected YYYY-MM-DD", r.CompareDateRangeStart)
+		}
+		previousEnd, err = time.Parse(dateLayout, r.CompareDateRangeEnd)
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid date format for compare end date %q: expected YYYY-MM-DD", r.CompareDateRangeEnd)
+		}
+		if previousEnd.Before(previousStart) {
+			return Period{}, fmt.Errorf("compare_date_range_end %q is before compare_date_range_start %q", r.CompareDateRangeEnd, r.CompareDateRangeStart)
+		}
+	default:
+		return Period{}, fmt.Errorf("invalid compare_to %q: must be one of previous_period, previous_year, custom", r.CompareTo)
+	}
+
+	return Period{
+		CurrentStart:  start.Format(dateLayout),
+		CurrentEnd:    end.Format(dateLayout),
+		PreviousStart: previousStart.Format(dateLayout),
+		PreviousEnd:   previousEnd.Format(dateLayout),
+	}, nil
+}
+
+// Delta returns current minus previous for every integer and float field of a metrics struct.
+// Optional *int64 amounts are only subtracted when both are set. Other fields are left at
+// their zero value.
+func Delta[T any](current, previous T) T {
+	var delta T
+	cur := reflect.ValueOf(current)
+	prev := reflect.ValueOf(previous)
+	out := reflect.ValueOf(&delta).Elem()
+	if out.Kind() != reflect.Struct {
+		return delta
+	}
+
+	for i := 0; i < out.NumField(); i++ {
+		field := out.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			field.SetInt(cur.Field(i).Int() - prev.Field(i).Int())
+		case reflect.Float32, reflect.Float64:
+			field.SetFloat(cur.Field(i).Float() - prev.Field(i).Float())
+		case reflect.Pointer:
+			if field.Type().Elem().Kind() != reflect.Int64 || cur.Field(i).IsNil() || prev.Field(i).IsNil() {
+				continue
+			}
+			value := cur.Field(i).Elem().Int() - prev.Field(i).Elem().Int()
+			field.Set(reflect.ValueOf(&value))
+		}
+	}
+	return delta
+}
+
+// PercentChange returns the relative change, in percent, of every integer and float field
+// of a metrics struct, keyed by the field's JSON name. The value is nil when the previous
+// value is zero and the change is therefore undefined.
+func PercentChange[T any](current, previous T) map[string]*float64 {
+	changes := make(map[string]*float64)
+	cur := reflect.ValueOf(current)
+	prev := reflect.ValueOf(previous)
+	if cur.Kind() != reflect.Struct {
+		return changes
+	}
+
+	typ := cur.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		var curValue, prevValue float64
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int32, reflect.Int64:
+			curValue, prevValue = float64(cur.Field(i).Int()), float64(prev.Field(i).Int())
+		case reflect.Float32, reflect.Float64:
+			curValue, prevValue = cur.Field(i).Float(), prev.Field(i).Float()
+		case reflect.Pointer:
+			if field.Type.Elem().Kind() != reflect.Int64 || cur.Field(i).IsNil() || prev.Field(i).IsNil() {
+				continue
+			}
+			curValue, prevValue = float64(cur.Field(i).Elem().Int()), float64(prev.Field(i).Elem().Int())
+		default:
+			continue
+		}
+
+		name := jsonName(field)
+		if name == "" {
+			continue
+		}
+		if prevValue == 0 {
+			changes[name] = nil
+			continue
+		}
+		change := (curValue - prevValue) / prevValue * 100.0
+		changes[name] = &change
+	}
+	return changes
+}
+
+func jsonName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return ""
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,81 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/log"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+)
+
+// CachedLookup fetches customer.currency_code once per customer. The currency of
+// an account cannot change, so entries never expire.
+type CachedLookup struct {
+	client        *googleads.Client
+	logger        log.Logger
+	loginResolver logincustomer.Resolver
+
+	mu    sync.Mutex
+	codes map[string]string
+}
+
+func NewCachedLookup(client *googleads.Client, logger log.Logger, loginResolver logincustomer.Resolver) *CachedLookup {
+	return &CachedLookup{
+		client:        client,
+		logger:        logger,
+		loginResolver: loginResolver,
+		codes:         make(map[string]string),
+	}
+}
+
+func (l *CachedLookup) CurrencyCode(ctx context.Context, customerID, loginCustomerID string) (string, error) {
+	customerID = logincustomer.NormalizeCustomerID(customerID)
+	if customerID == "" {
+		return "", fmt.Errorf("currency: customer ID is required")
+	}
+
+	l.mu.Lock()
+	code, ok := l.codes[customerID]
+	l.mu.Unlock()
+	if ok {
+		return code, nil
+	}
+
+	code, err := l.fetch(ctx, customerID, loginCustomerID)
+	if err != nil {
+		return "", err
+	}
+
+	l.mu.Lock()
+	l.codes[customerID] = code
+	l.mu.Unlock()
+
+	return code, nil
+}
+
+func (l *CachedLookup) fetch(ctx context.Context, customerID, loginCustomerIDOverride string) (string, error) {
+	loginCustomerID, err := l.loginResolver.Resolve(ctx, customerID, loginCustomerIDOverride)
+	if err != nil {
+		return "", fmt.Errorf("currency: resolving login customer ID: %w", err)
+	}
+
+	query := gaql.NewQueryBuilder("customer").Select("customer.currency_code").Build()
+	request := &services.SearchGoogleAdsRequest{CustomerId: customerID, Query: query}
+	protoResp, err := l.client.Search(ctx, request, loginCustomerID)
+	if err != nil {
+		return "", fmt.Errorf("currency: %w", err)
+	}
+
+	for _, row := range protoResp.GetResults() {
+		if code := row.GetCustomer().GetCurrencyCode(); code != "" {
+			return code, nil
+		}
+	}
+
+	return "", fmt.Errorf("currency: customer %s has no currency code", customerID)
+}
@@ -0,0 +1,204 @@
+package currency
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// MoneyFormat selects how money amounts are returned to clients.
+type MoneyFormat string
+
+const (
+	FormatMicros  MoneyFormat = "micros"  // Amounts in micros only (the default)
+	FormatDecimal MoneyFormat = "decimal" // Decimal amounts in the account currency only
+	FormatBoth    MoneyFormat = "both"    // Micros and decimal amounts
+)
+
+const microsSuffix = "_micros"
+
+// minorUnits lists the currencies that do not use two decimal places.
+var minorUnits = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// ParseMoneyFormat validates a money_format value. An empty value means micros.
+func ParseMoneyFormat(raw string) (MoneyFormat, error) {
+	switch format := MoneyFormat(strings.ToLower(strings.TrimSpace(raw))); format {
+	case "":
+		return FormatMicros, nil
+	case FormatMicros, FormatDecimal, FormatBoth:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid money_format %q: must be one of micros, decimal, both", raw)
+	}
+}
+
+// IncludesDecimal reports whether decimal amounts are returned.
+func (f MoneyFormat) IncludesDecimal() bool {
+	return f == FormatDecimal || f == FormatBoth
+}
+
+// FormatAmount renders an amount in micros as a decimal amount in the currency,
+// rounded to its minor unit, e.g. 12345678 USD -> "12.35 USD".
+func FormatAmount(micros int64, code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	digits, ok := minorUnits[code]
+	if !ok {
+		digits = 2
+	}
+
+	sign := ""
+	if micros < 0 {
+		sign = "-"
+		micros = -micros
+	}
+
+	scale := int64(1)
+	for range 6 - digits {
+		scale *= 10
+	}
+	rounded := (micros + scale/2) / scale
+
+	unit := int64(1)
+	for range digits {
+		unit *= 10
+	}
+
+	amount := fmt.Sprintf("%s%d", sign, rounded/unit)
+	if digits > 0 {
+		amount += fmt.Sprintf(".%0*d", digits, rounded%unit)
+	}
+	if code != "" {
+		amount += " " + code
+	}
+	return amount
+}
+
+// Micros returns a pointer to an amount in micros, for output fields that
+// money_format can omit.
+func Micros(micros int64) *int64 {
+	return &micros
+}
+
+// RoundMicros returns a pointer to a fractional amount in micros, such as
+// metrics.cost_per_conversion, rounded to a whole micro so it formats like the
+// other money fields.
+func RoundMicros(micros float64) *int64 {
+	return Micros(int64(math.Round(micros)))
+}
+
+// Apply formats the money amounts of an output value in place. Every *int64 field
+// whose JSON name ends in "_micros" gets its decimal amount written to the string
+// field named without the suffix (cost_micros -> cost); with FormatDecimal the
+// micros field is then cleared. Nested structs, pointers and slices are walked.
+// Amounts that already have a decimal value are left alone, so parts of an output
+// can be formatted with their own currency first.
+func Apply(v any, format MoneyFormat, code string) {
+	if !format.IncludesDecimal() {
+		return
+	}
+	apply(reflect.ValueOf(v), format, code)
+}
+
+func apply(v reflect.Value, format MoneyFormat, code string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			apply(v.Elem(), format, code)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			apply(v.Index(i), format, code)
+		}
+	case reflect.Struct:
+		applyStruct(v, format, code)
+	}
+}
+
+func applyStruct(v reflect.Value, format MoneyFormat, code string) {
+	typ := v.Type()
+	decimalFields := make(map[string]reflect.Value)
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() && typ.Field(i).Type.Kind() == reflect.String {
+			decimalFields[jsonName(typ.Field(i))] = v.Field(i)
+		}
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		value := v.Field(i)
+		name := jsonName(field)
+		if field.Type != reflect.TypeFor[*int64]() || !strings.HasSuffix(name, microsSuffix) {
+			apply(value, format, code)
+			continue
+		}
+
+		decimal, ok := decimalFields[strings.TrimSuffix(name, microsSuffix)]
+		if !ok || value.IsNil() || decimal.String() != "" || !decimal.CanSet() {
+			continue
+		}
+		decimal.SetString(FormatAmount(value.Elem().Int(), code))
+		if format == FormatDecimal && value.CanSet() {
+			value.SetZero()
+		}
+	}
+}
+
+// Omit clears the money amounts of an output value in place: every *int64 field
+// whose JSON name ends in "_micros" and its decimal string field. It is used for
+// totals added across currencies, which have no meaningful amount.
+func Omit(v any) {
+	omit(reflect.ValueOf(v))
+}
+
+func omit(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			omit(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			omit(v.Index(i))
+		}
+	case reflect.Struct:
+		typ := v.Type()
+		micros := make(map[string]bool)
+		for i := 0; i < typ.NumField(); i++ {
+			if name := jsonName(typ.Field(i)); typ.Field(i).Type == reflect.TypeFor[*int64]() && strings.HasSuffix(name, microsSuffix) {
+				micros[strings.TrimSuffix(name, microsSuffix)] = true
+			}
+		}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if !field.IsExported() || !v.Field(i).CanSet() {
+				continue
+			}
+			name := jsonName(field)
+			switch {
+			case field.Type == reflect.TypeFor[*int64]() && strings.HasSuffix(name, microsSuffix):
+				v.Field(i).SetZero()
+			case field.Type.Kind() == reflect.String && micros[name]:
+				v.Field(i).SetZero()
+			default:
+				omit(v.Field(i))
+			}
+		}
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
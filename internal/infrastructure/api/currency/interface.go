@@ -0,0 +1,9 @@
+package currency
+
+import "context"
+
+// Lookup defines the interface for resolving the currency of a Google Ads account
+type Lookup interface {
+	// CurrencyCode returns the ISO 4217 currency code of customerID.
+	CurrencyCode(ctx context.Context, customerID, loginCustomerID string) (string, error)
+}
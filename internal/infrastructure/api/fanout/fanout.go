@@ -0,0 +1,122 @@
+package fanout
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+)
+
+// AllAccounts is the customer_ids value that expands to every client account below the manager.
+const AllAccounts = "all"
+
+// Options bounds a fan-out across accounts.
+type Options struct {
+	MaxConcurrency int           // Accounts queried at the same time
+	AccountTimeout time.Duration // Deadline for each account; 0 disables it
+}
+
+// Result is the outcome of one account. Err is set when the account failed.
+type Result[T any] struct {
+	CustomerID string
+	Value      T
+	Err        error
+}
+
+// Run calls fn for every customer with a bounded worker pool and a per-account
+// timeout. Results are returned in the order of customerIDs; a failing account
+// does not stop the others.
+func Run[T any](ctx context.Context, customerIDs []string, options Options, fn func(ctx context.Context, customerID string) (T, error)) []Result[T] {
+	results := make([]Result[T], len(customerIDs))
+
+	workers := options.MaxConcurrency
+	if workers <= 0 {
+		workers = 1
+	}
+	workers = min(workers, len(customerIDs))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = runOne(ctx, customerIDs[i], options.AccountTimeout, fn)
+			}
+		}()
+	}
+
+	for i := range customerIDs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+func runOne[T any](ctx context.Context, customerID string, timeout time.Duration, fn func(ctx context.Context, customerID string) (T, error)) Result[T] {
+	result := Result[T]{CustomerID: customerID}
+	if err := ctx.Err(); err != nil {
+		result.Err = err
+		return result
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	result.Value, result.Err = fn(ctx, customerID)
+	if result.Err != nil && ctx.Err() == context.DeadlineExceeded {
+		result.Err = fmt.Errorf("account timed out after %s: %w", timeout, result.Err)
+	}
+	return result
+}
+
+// ResolveCustomerIDs normalizes and deduplicates customerIDs. The single value
+// "all" expands to every enabled client account below loginCustomerID, or below
+// the configured manager when it is empty.
+func ResolveCustomerIDs(ctx context.Context, lister logincustomer.AccountLister, customerIDs []string, loginCustomerID string) ([]string, error) {
+	if len(customerIDs) == 1 && strings.EqualFold(strings.TrimSpace(customerIDs[0]), AllAccounts) {
+		accounts, err := lister.ClientAccounts(ctx, loginCustomerID)
+		if err != nil {
+			return nil, err
+		}
+		if len(accounts) == 0 {
+			return nil, fmt.Errorf("no enabled client accounts found below the manager account")
+		}
+		return accounts, nil
+	}
+
+	resolved := make([]string, 0, len(customerIDs))
+	for _, customerID := range customerIDs {
+		if strings.EqualFold(strings.TrimSpace(customerID), AllAccounts) {
+			return nil, fmt.Errorf("%q cannot be combined with other customer IDs", AllAccounts)
+		}
+
+		normalized := logincustomer.NormalizeCustomerID(customerID)
+		if normalized == "" {
+			continue
+		}
+		for _, r := range normalized {
+			if r < '0' || r > '9' {
+				return nil, fmt.Errorf("invalid customer ID %q: must be numeric", customerID)
+			}
+		}
+		if !slices.Contains(resolved, normalized) {
+			resolved = append(resolved, normalized)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return nil, fmt.Errorf("at least one customer ID is required")
+	}
+	return resolved, nil
+}
@@ -0,0 +1,92 @@
+package gaql
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// orderByMetrics maps the supported order_by values to their GAQL field.
+var orderByMetrics = map[string]string{
+	"clicks":              "metrics.clicks",
+	"impressions":         "metrics.impressions",
+	"ctr":                 "metrics.ctr",
+	"average_cpc":         "metrics.average_cpc",
+	"cost_micros":         "metrics.cost_micros",
+	"conversions":         "metrics.conversions",
+	"conversions_value":   "metrics.conversions_value",
+	"cost_per_conversion": "metrics.cost_per_conversion",
+	"interactions":        "metrics.interactions",
+}
+
+// MetricThresholds filters rows on their metrics. Zero values are not applied.
+type MetricThresholds struct {
+	MinCostMicros  int64
+	MinImpressions int64
+	MinClicks      int64
+	MinConversions float64
+	MaxCPAMicros   int64 // Also requires conversions, since rows without any have no CPA
+}
+
+// WhereMetricThresholds adds a WHERE condition for every threshold that is set.
+func (qb *QueryBuilder) WhereMetricThresholds(t MetricThresholds) error {
+	if t.MinCostMicros < 0 || t.MinImpressions < 0 || t.MinClicks < 0 || t.MinConversions < 0 || t.MaxCPAMicros < 0 {
+		return fmt.Errorf("metric thresholds cannot be negative")
+	}
+
+	if t.MinCostMicros > 0 {
+		qb.Where(fmt.Sprintf("metrics.cost_micros >= %d", t.MinCostMicros))
+	}
+	if t.MinImpressions > 0 {
+		qb.Where(fmt.Sprintf("metrics.impressions >= %d", t.MinImpressions))
+	}
+	if t.MinClicks > 0 {
+		qb.Where(fmt.Sprintf("metrics.clicks >= %d", t.MinClicks))
+	}
+	if t.MinConversions > 0 {
+		qb.Where(fmt.Sprintf("metrics.conversions >= %g", t.MinConversions))
+	}
+	if t.MaxCPAMicros > 0 {
+		qb.Where("metrics.conversions > 0")
+		qb.Where(fmt.Sprintf("metrics.cost_per_conversion <= %d", t.MaxCPAMicros))
+	}
+	return nil
+}
+
+// OrderByMetric adds an ORDER BY clause on a metric and, when limit is positive, a LIMIT.
+// orderBy is a metric name optionally followed by ASC or DESC, e.g. "cost_micros desc";
+// the direction defaults to DESC so "top N by" queries need only the metric name.
+// Valid metrics: clicks, impressions, ctr, average_cpc, cost_micros, conversions,
+// conversions_value, cost_per_conversion, interactions
+func (qb *QueryBuilder) OrderByMetric(orderBy string, limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid limit %d: must be positive", limit)
+	}
+
+	if orderBy = strings.TrimSpace(orderBy); orderBy != "" {
+		parts := strings.Fields(strings.ToLower(orderBy))
+		field, ok := orderByMetrics[strings.TrimPrefix(parts[0], "metrics.")]
+		if !ok || len(parts) > 2 {
+			return fmt.Errorf("invalid order_by %q: must be one of clicks, impressions, ctr, average_cpc, cost_micros, conversions, conversions_value, cost_per_conversion, interactions, optionally followed by asc or desc", orderBy)
+		}
+
+		direction := "DESC"
+		if len(parts) == 2 {
+			direction = strings.ToUpper(parts[1])
+			if direction != "ASC" && direction != "DESC" {
+				return fmt.Errorf("invalid order_by direction %q: must be asc or desc", parts[1])
+			}
+		}
+
+		// GAQL only orders by selected fields
+		if !slices.Contains(qb.selects, field) {
+			qb.Select(field)
+		}
+		qb.OrderBy(field + " " + direction)
+	}
+
+	if limit > 0 {
+		qb.Limit(limit)
+	}
+	return nil
+}
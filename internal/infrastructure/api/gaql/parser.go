@@ -0,0 +1,236 @@
+package gaql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	fieldNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_]*(\.[a-z0-9_]+)*$`)
+
+	// clauseKeywords are the keywords allowed to start a clause after FROM.
+	clauseKeywords = map[string]bool{
+		"WHERE":      true,
+		"ORDER":      true,
+		"LIMIT":      true,
+		"PARAMETERS": true,
+	}
+
+	rowFieldsOnce sync.Once
+	rowFields     map[string]bool
+	resources     map[string]bool
+)
+
+// Query is a validated read-only GAQL query.
+type Query struct {
+	Resource string
+	Fields   []string
+	Text     string
+}
+
+type tokenKind int
+
+const (
+	tokenWord tokenKind = iota
+	tokenLiteral
+	tokenSymbol
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+// ParseQuery validates a raw GAQL query and returns its normalized form.
+// Only a single SELECT statement over a known resource is accepted; string
+// literals are tokenized so their content can never be read as GAQL syntax.
+func ParseQuery(raw string) (Query, error) {
+	text := strings.TrimSpace(raw)
+	if text == "" {
+		return Query{}, fmt.Errorf("query is required")
+	}
+
+	tokens, err := tokenize(text)
+	if err != nil {
+		return Query{}, err
+	}
+
+	if len(tokens) == 0 || !isKeyword(tokens[0], "SELECT") {
+		return Query{}, fmt.Errorf("only SELECT queries are allowed")
+	}
+
+	fromIndex := -1
+	for i := 1; i < len(tokens); i++ {
+		if isKeyword(tokens[i], "FROM") {
+			fromIndex = i
+			break
+		}
+	}
+	if fromIndex == -1 {
+		return Query{}, fmt.Errorf("query is missing a FROM clause")
+	}
+
+	fields, err := parseSelectFields(tokens[1:fromIndex])
+	if err != nil {
+		return Query{}, err
+	}
+
+	if fromIndex+1 >= len(tokens) || tokens[fromIndex+1].kind != tokenWord {
+		return Query{}, fmt.Errorf("query is missing a resource after FROM")
+	}
+	resource := strings.ToLower(tokens[fromIndex+1].value)
+	if !IsKnownResource(resource) {
+		return Query{}, fmt.Errorf("unknown resource %q", resource)
+	}
+
+	rest := tokens[fromIndex+2:]
+	if len(rest) > 0 && (rest[0].kind != tokenWord || !clauseKeywords[strings.ToUpper(rest[0].value)]) {
+		return Query{}, fmt.Errorf("unexpected %q after resource %q", rest[0].value, resource)
+	}
+	for _, tok := range rest {
+		if isKeyword(tok, "SELECT") || isKeyword(tok, "FROM") {
+			return Query{}, fmt.Errorf("nested or multiple statements are not allowed")
+		}
+	}
+
+	return Query{
+		Resource: resource,
+		Fields:   fields,
+		Text:     text,
+	}, nil
+}
+
+// IsKnownResource reports whether name is a resource that can be queried with GAQL.
+func IsKnownResource(name string) bool {
+	loadRowFields()
+	return resources[name]
+}
+
+// IsKnownField reports whether the top-level segment of a dotted field name
+// (e.g. "campaign" in "campaign.id") is selectable from a GoogleAdsRow.
+func IsKnownField(name string) bool {
+	loadRowFields()
+	top, _, _ := strings.Cut(name, ".")
+	return rowFields[top]
+}
+
+func parseSelectFields(tokens []token) ([]string, error) {
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("SELECT clause must list at least one field")
+	}
+
+	var fields []string
+	expectField := true
+	for _, tok := range tokens {
+		if expectField {
+			if tok.kind != tokenWord {
+				return nil, fmt.Errorf("unexpected %q in SELECT clause", tok.value)
+			}
+			name := strings.ToLower(tok.value)
+			if !fieldNameRegex.MatchString(name) {
+				return nil, fmt.Errorf("invalid field name %q", tok.value)
+			}
+			if !IsKnownField(name) {
+				return nil, fmt.Errorf("unknown field %q", tok.value)
+			}
+			fields = append(fields, name)
+			expectField = false
+			continue
+		}
+
+		if tok.kind != tokenSymbol || tok.value != "," {
+			return nil, fmt.Errorf("unexpected %q in SELECT clause: fields must be comma separated", tok.value)
+		}
+		expectField = true
+	}
+
+	if expectField {
+		return nil, fmt.Errorf("SELECT clause ends with a trailing comma")
+	}
+
+	return fields, nil
+}
+
+func tokenize(text string) ([]token, error) {
+	var tokens []token
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '\'' || r == '"':
+			end, err := scanLiteral(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenLiteral, value: string(runes[i : end+1])})
+			i = end + 1
+		case r == ';':
+			return nil, fmt.Errorf("multiple statements are not allowed")
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-',
+			r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			return nil, fmt.Errorf("comments are not allowed")
+		case isWordRune(r):
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: string(runes[start:i])})
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, value: string(r)})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+// scanLiteral returns the index of the quote closing the literal opened at start.
+func scanLiteral(runes []rune, start int) (int, error) {
+	quote := runes[start]
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case quote:
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string literal starting at position %d", start)
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || r == '.' ||
+		(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func isKeyword(tok token, keyword string) bool {
+	return tok.kind == tokenWord && strings.EqualFold(tok.value, keyword)
+}
+
+// loadRowFields indexes the GoogleAdsRow descriptor once. Every message field of the
+// row is selectable; those whose type lives in the resources package can be used in FROM.
+func loadRowFields() {
+	rowFieldsOnce.Do(func() {
+		rowFields = make(map[string]bool)
+		resources = make(map[string]bool)
+
+		descriptor := (&services.GoogleAdsRow{}).ProtoReflect().Descriptor()
+		fields := descriptor.Fields()
+		for i := 0; i < fields.Len(); i++ {
+			field := fields.Get(i)
+			name := string(field.Name())
+			rowFields[name] = true
+			if field.Kind() == protoreflect.MessageKind && strings.HasSuffix(string(field.Message().ParentFile().Package()), ".resources") {
+				resources[name] = true
+			}
+		}
+	})
+}
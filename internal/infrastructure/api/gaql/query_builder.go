@@ -612,3 +612,151 @@ func (qb *QueryBuilder) WhereAdTypes(adTypes []string) error {
 	return nil
 }
 
+
+// WhereAdGroupCriterionStatus adds a WHERE clause to filter by ad_group_criterion.status
+// Valid statuses: ENABLED, PAUSED, REMOVED
+func (qb *QueryBuilder) WhereAdGroupCriterionStatus(statuses []string) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	validStatuses := map[string]bool{
+		"ENABLED": true,
+		"PAUSED":  true,
+		"REMOVED": true,
+	}
+
+	normalized := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		trimmed := strings.TrimSpace(strings.ToUpper(status))
+		if trimmed == "" {
+			continue
+		}
+
+		if !validStatuses[trimmed] {
+			return fmt.Errorf("invalid ad group criterion status %q: must be one of ENABLED, PAUSED, REMOVED", status)
+		}
+
+		normalized = append(normalized, trimmed)
+	}
+
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	// Deduplicate statuses
+	seen := make(map[string]bool)
+	var unique []string
+	for _, status := range normalized {
+		if !seen[status] {
+			seen[status] = true
+			unique = append(unique, status)
+		}
+	}
+
+	if len(unique) == 1 {
+		qb.Where(fmt.Sprintf("ad_group_criterion.status = %s", unique[0]))
+	} else {
+		qb.Where(fmt.Sprintf("ad_group_criterion.status IN (%s)", strings.Join(unique, ",")))
+	}
+	return nil
+}
+
+// WhereKeywordMatchTypes adds a WHERE clause to filter by ad_group_criterion.keyword.match_type
+// Valid match types: EXACT, PHRASE, BROAD
+func (qb *QueryBuilder) WhereKeywordMatchTypes(matchTypes []string) error {
+	if len(matchTypes) == 0 {
+		return nil
+	}
+
+	validMatchTypes := map[string]bool{
+		"EXACT":  true,
+		"PHRASE": true,
+		"BROAD":  true,
+	}
+
+	normalized := make([]string, 0, len(matchTypes))
+	for _, matchType := range matchTypes {
+		trimmed := strings.TrimSpace(strings.ToUpper(matchType))
+		if trimmed == "" {
+			continue
+		}
+
+		if !validMatchTypes[trimmed] {
+			return fmt.Errorf("invalid keyword match type %q: must be one of EXACT, PHRASE, BROAD", matchType)
+		}
+
+		normalized = append(normalized, trimmed)
+	}
+
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	// Deduplicate match types
+	seen := make(map[string]bool)
+	var unique []string
+	for _, matchType := range normalized {
+		if !seen[matchType] {
+			seen[matchType] = true
+			unique = append(unique, matchType)
+		}
+	}
+
+	if len(unique) == 1 {
+		qb.Where(fmt.Sprintf("ad_group_criterion.keyword.match_type = %s", unique[0]))
+	} else {
+		qb.Where(fmt.Sprintf("ad_group_criterion.keyword.match_type IN (%s)", strings.Join(unique, ",")))
+	}
+	return nil
+}
+
+// WhereSearchTermStatus adds a WHERE clause to filter by search_term_view.status
+// Valid statuses: ADDED, EXCLUDED, ADDED_EXCLUDED, NONE
+func (qb *QueryBuilder) WhereSearchTermStatus(statuses []string) error {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	validStatuses := map[string]bool{
+		"ADDED":          true,
+		"EXCLUDED":       true,
+		"ADDED_EXCLUDED": true,
+		"NONE":           true,
+	}
+
+	normalized := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		trimmed := strings.TrimSpace(strings.ToUpper(status))
+		if trimmed == "" {
+			continue
+		}
+
+		if !validStatuses[trimmed] {
+			return fmt.Errorf("invalid search term status %q: must be one of ADDED, EXCLUDED, ADDED_EXCLUDED, NONE", status)
+		}
+
+		normalized = append(normalized, trimmed)
+	}
+
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	// Deduplicate statuses
+	seen := make(map[string]bool)
+	var unique []string
+	for _, status := range normalized {
+		if !seen[status] {
+			seen[status] = true
+			unique = append(unique, status)
+		}
+	}
+
+	if len(unique) == 1 {
+		qb.Where(fmt.Sprintf("search_term_view.status = %s", unique[0]))
+	} else {
+		qb.Where(fmt.Sprintf("search_term_view.status IN (%s)", strings.Join(unique, ",")))
+	}
+	return nil
+}
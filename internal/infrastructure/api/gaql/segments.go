@@ -0,0 +1,81 @@
+package gaql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+)
+
+// segmentFields maps the supported segment_by values to their GAQL field.
+var segmentFields = map[string]string{
+	"date":            "segments.date",
+	"week":            "segments.week",
+	"month":           "segments.month",
+	"quarter":         "segments.quarter",
+	"day_of_week":     "segments.day_of_week",
+	"hour":            "segments.hour",
+	"device":          "segments.device",
+	"ad_network_type": "segments.ad_network_type",
+}
+
+// timeSegments are the segments that require a finite date range in the WHERE clause.
+var timeSegments = map[string]bool{
+	"date":    true,
+	"week":    true,
+	"month":   true,
+	"quarter": true,
+}
+
+// NormalizeSegment validates a segment_by value and returns it in canonical form.
+// Valid segments: date, week, month, quarter, day_of_week, hour, device, ad_network_type
+func NormalizeSegment(segmentBy string) (string, error) {
+	normalized := strings.ToLower(strings.TrimSpace(segmentBy))
+	if normalized == "" {
+		return "", nil
+	}
+
+	if _, ok := segmentFields[normalized]; !ok {
+		return "", fmt.Errorf("invalid segment %q: must be one of date, week, month, quarter, day_of_week, hour, device, ad_network_type", segmentBy)
+	}
+	return normalized, nil
+}
+
+// SegmentField returns the GAQL field selected for a normalized segment.
+func SegmentField(segmentBy string) string {
+	return segmentFields[segmentBy]
+}
+
+// IsTimeSegment reports whether the segment is a calendar period (date, week, month, quarter).
+func IsTimeSegment(segmentBy string) bool {
+	return timeSegments[segmentBy]
+}
+
+// SegmentValue extracts the value of a normalized segment from a row.
+// Enum values are returned lowercased, e.g. "mobile" for segments.device.
+func SegmentValue(row *services.GoogleAdsRow, segmentBy string) string {
+	segments := row.GetSegments()
+	if segments == nil {
+		return ""
+	}
+
+	switch segmentBy {
+	case "date":
+		return segments.GetDate()
+	case "week":
+		return segments.GetWeek()
+	case "month":
+		return segments.GetMonth()
+	case "quarter":
+		return segments.GetQuarter()
+	case "day_of_week":
+		return strings.ToLower(segments.GetDayOfWeek().String())
+	case "hour":
+		return fmt.Sprintf("%d", segments.GetHour())
+	case "device":
+		return strings.ToLower(segments.GetDevice().String())
+	case "ad_network_type":
+		return strings.ToLower(segments.GetAdNetworkType().String())
+	}
+	return ""
+}
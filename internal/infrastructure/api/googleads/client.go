@@ -0,0 +1,141 @@
+package googleads
+
+import (
+	"context"
+	"strconv"
+
+	"google-ads-mcp/internal/infrastructure/api/transport"
+	"google-ads-mcp/internal/infrastructure/log"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+)
+
+// Client is the single entry point to GoogleAdsService. It sends every request
+// through the configured transport, which owns the endpoint, headers and error
+// decoding, and logs the request ID of every call, so services only build queries
+// and map rows.
+type Client struct {
+	transport   transport.Transport
+	logger      log.Logger
+	afterMutate []func(customerID string)
+}
+
+func NewClient(transport transport.Transport, logger log.Logger) *Client {
+	return &Client{
+		transport: transport,
+		logger:    logger,
+	}
+}
+
+// AfterMutate registers fn to be called with the customer ID of every mutate that
+// succeeds and is not validate-only, such as a cache dropping the results it holds
+// for that customer. It must be called before the client is used.
+func (c *Client) AfterMutate(fn func(customerID string)) {
+	c.afterMutate = append(c.afterMutate, fn)
+}
+
+// Search returns one page of results. The API pages by 10,000 rows; a request page
+// size splits those pages into smaller ones and is never sent to the API.
+func (c *Client) Search(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string) (*services.SearchGoogleAdsResponse, error) {
+	if request.GetPageSize() > 0 {
+		return c.searchPage(ctx, request, loginCustomerID)
+	}
+
+	return c.search(ctx, request, loginCustomerID)
+}
+
+func (c *Client) search(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string) (*services.SearchGoogleAdsResponse, error) {
+	response, requestID, err := c.transport.Search(ctx, request, loginCustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info(ctx, "google ads search", map[string]string{
+		"request_id":  requestID,
+		"customer_id": request.GetCustomerId(),
+		"rows":        strconv.Itoa(len(response.GetResults())),
+	})
+
+	return response, nil
+}
+
+// SearchPages walks the pages of request, starting from its page token, and calls fn
+// with each page. It stops after the last page or when fn returns false, and returns
+// the last page read, whose NextPageToken resumes the search.
+func (c *Client) SearchPages(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string, fn func(*services.SearchGoogleAdsResponse) (bool, error)) (*services.SearchGoogleAdsResponse, error) {
+	request = proto.Clone(request).(*services.SearchGoogleAdsRequest)
+	for {
+		page, err := c.Search(ctx, request, loginCustomerID)
+		if err != nil {
+			return nil, err
+		}
+
+		more, err := fn(page)
+		if err != nil {
+			return nil, err
+		}
+		if !more || page.GetNextPageToken() == "" {
+			return page, nil
+		}
+		request.PageToken = page.GetNextPageToken()
+	}
+}
+
+// SearchStream calls fn with every row of the query as it arrives; an error from fn
+// stops the stream. It returns the number of rows streamed.
+func (c *Client) SearchStream(ctx context.Context, request *services.SearchGoogleAdsStreamRequest, loginCustomerID string, fn func(*services.GoogleAdsRow) error) (int64, error) {
+	rows, requestID, err := c.transport.SearchStream(ctx, request, loginCustomerID, fn)
+	if err != nil {
+		return rows, err
+	}
+
+	c.logger.Info(ctx, "google ads search stream", map[string]string{
+		"request_id":  requestID,
+		"customer_id": request.GetCustomerId(),
+		"rows":        strconv.FormatInt(rows, 10),
+	})
+
+	return rows, nil
+}
+
+// Mutate applies the operations of request through googleAds:mutate. It returns
+// the request ID along with the response, as mutate tools report it to the caller.
+func (c *Client) Mutate(ctx context.Context, request *services.MutateGoogleAdsRequest, loginCustomerID string) (*services.MutateGoogleAdsResponse, string, error) {
+	response, requestID, err := c.transport.Mutate(ctx, request, loginCustomerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.mutated(ctx, "google ads mutate", requestID, request.GetCustomerId(), len(request.GetMutateOperations()), request.GetValidateOnly())
+	return response, requestID, nil
+}
+
+// MutateCampaigns applies the campaign operations of request through
+// campaigns:mutate, returning the request ID like Mutate.
+func (c *Client) MutateCampaigns(ctx context.Context, request *services.MutateCampaignsRequest, loginCustomerID string) (*services.MutateCampaignsResponse, string, error) {
+	response, requestID, err := c.transport.MutateCampaigns(ctx, request, loginCustomerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.mutated(ctx, "google ads campaigns mutate", requestID, request.GetCustomerId(), len(request.GetOperations()), request.GetValidateOnly())
+	return response, requestID, nil
+}
+
+// mutated logs a successful mutate and, unless it only validated, calls the
+// AfterMutate callbacks.
+func (c *Client) mutated(ctx context.Context, message, requestID, customerID string, operations int, validateOnly bool) {
+	c.logger.Info(ctx, message, map[string]string{
+		"request_id":    requestID,
+		"customer_id":   customerID,
+		"operations":    strconv.Itoa(operations),
+		"validate_only": strconv.FormatBool(validateOnly),
+	})
+
+	if !validateOnly {
+		for _, fn := range c.afterMutate {
+			fn(customerID)
+		}
+	}
+}
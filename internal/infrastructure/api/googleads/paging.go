@@ -0,0 +1,74 @@
+package googleads
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+)
+
+// searchPage serves a page of request.PageSize rows. The API rejects page_size
+// since v17 and always returns pages of 10,000 rows, so the server page is fetched
+// without it and sliced here. The page token returned is a cursor holding the server
+// page token and the offset of the next row in that page.
+func (c *Client) searchPage(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string) (*services.SearchGoogleAdsResponse, error) {
+	serverToken, offset, err := decodeCursor(request.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+
+	serverRequest := proto.Clone(request).(*services.SearchGoogleAdsRequest)
+	serverRequest.PageSize = 0
+	serverRequest.PageToken = serverToken
+	page, err := c.search(ctx, serverRequest, loginCustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := page.GetResults()
+	if offset > len(rows) {
+		return nil, fmt.Errorf("invalid page token: offset %d is past the end of the page", offset)
+	}
+	end := min(offset+int(request.GetPageSize()), len(rows))
+
+	response := proto.Clone(page).(*services.SearchGoogleAdsResponse)
+	response.Results = rows[offset:end]
+	switch {
+	case end < len(rows):
+		response.NextPageToken = encodeCursor(serverToken, end)
+	case page.GetNextPageToken() != "":
+		response.NextPageToken = encodeCursor(page.GetNextPageToken(), 0)
+	default:
+		response.NextPageToken = ""
+	}
+
+	return response, nil
+}
+
+func encodeCursor(serverToken string, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset) + ":" + serverToken))
+}
+
+// decodeCursor reads a page token returned by searchPage; an empty token starts at
+// the first row of the first server page.
+func decodeCursor(token string) (string, int, error) {
+	if token == "" {
+		return "", 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid page token %q: page tokens must come from a search with the same page_size", token)
+	}
+	rawOffset, serverToken, ok := strings.Cut(string(data), ":")
+	offset, err := strconv.Atoi(rawOffset)
+	if !ok || err != nil || offset < 0 {
+		return "", 0, fmt.Errorf("invalid page token %q: page tokens must come from a search with the same page_size", token)
+	}
+
+	return serverToken, offset, nil
+}
@@ -0,0 +1,96 @@
+package kpi
+
+import "math"
+
+// Inputs are the raw metrics the KPIs are derived from. Cost is in micros of the
+// account currency; conversion value is in currency units, as the API reports it.
+type Inputs struct {
+	Clicks           int64
+	Impressions      int64
+	Interactions     int64
+	CostMicros       int64
+	Conversions      float64
+	ConversionsValue float64
+}
+
+// KPIs are the metrics derived from Inputs, shared by every tool so campaigns, ad
+// groups, ads, keywords and search terms use one definition. A KPI whose
+// denominator is zero is reported as 0, never as infinity or NaN.
+type KPIs struct {
+	ROAS                     float64 // Conversion value per unit of cost
+	CPAMicros                int64   // Cost per conversion
+	CPMMicros                int64   // Cost per thousand impressions
+	CostPerInteractionMicros int64   // Cost per interaction
+	ValuePerClick            float64 // Conversion value per click, in currency units
+}
+
+// Compute derives every KPI from the raw metrics.
+func Compute(in Inputs) KPIs {
+	return KPIs{
+		ROAS:                     ROAS(in.ConversionsValue, in.CostMicros),
+		CPAMicros:                CPA(in.CostMicros, in.Conversions),
+		CPMMicros:                CPM(in.CostMicros, in.Impressions),
+		CostPerInteractionMicros: CostPerInteraction(in.CostMicros, in.Interactions),
+		ValuePerClick:            ValuePerClick(in.ConversionsValue, in.Clicks),
+	}
+}
+
+// ROAS returns the conversion value earned per unit of cost.
+func ROAS(conversionsValue float64, costMicros int64) float64 {
+	if costMicros <= 0 {
+		return 0
+	}
+	return conversionsValue / (float64(costMicros) / 1e6)
+}
+
+// CPA returns the cost per conversion in micros.
+func CPA(costMicros int64, conversions float64) int64 {
+	if conversions <= 0 {
+		return 0
+	}
+	return roundMicros(float64(costMicros) / conversions)
+}
+
+// CPM returns the cost per thousand impressions in micros.
+func CPM(costMicros, impressions int64) int64 {
+	if impressions <= 0 {
+		return 0
+	}
+	return roundMicros(float64(costMicros) * 1000 / float64(impressions))
+}
+
+// CostPerInteraction returns the cost per interaction in micros.
+func CostPerInteraction(costMicros, interactions int64) int64 {
+	if interactions <= 0 {
+		return 0
+	}
+	return roundMicros(float64(costMicros) / float64(interactions))
+}
+
+// AverageCPC returns the cost per click in micros.
+func AverageCPC(costMicros, clicks int64) int64 {
+	if clicks <= 0 {
+		return 0
+	}
+	return roundMicros(float64(costMicros) / float64(clicks))
+}
+
+// ValuePerClick returns the conversion value per click.
+func ValuePerClick(conversionsValue float64, clicks int64) float64 {
+	if clicks <= 0 {
+		return 0
+	}
+	return conversionsValue / float64(clicks)
+}
+
+// ConversionRate returns the conversions per click, in percent.
+func ConversionRate(conversions float64, clicks int64) float64 {
+	if clicks <= 0 {
+		return 0
+	}
+	return conversions / float64(clicks) * 100.0
+}
+
+func roundMicros(micros float64) int64 {
+	return int64(math.Round(micros))
+}
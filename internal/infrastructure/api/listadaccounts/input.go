@@ -2,6 +2,12 @@ package listadaccounts
 
 // Filters captures the parameters used to search for accounts.
 type Filters struct {
-	AccountIDs   []string
-	AccountNames []string
+	AccountIDs      []string
+	AccountNames    []string
+	PageToken       string
+	PageSize        int32
+	FetchAll        bool
+	IncludeManagers bool
+	MaxDepth        int
+	LoginCustomerID string
 }
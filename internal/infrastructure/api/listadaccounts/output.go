@@ -8,6 +8,10 @@ type Account struct {
 	TimeZone     string
 	Status       string
 	ResourceName string
+	Manager      bool
+	Level        int64    // Distance from the configured root customer, 0 for the root itself
+	ParentID     string   // Manager the account was found under; empty outside hierarchy mode
+	Path         []string // Customer IDs from the root down to the account, in hierarchy mode
 }
 
 // Result aggregates the accounts outcome along with pagination metadata.
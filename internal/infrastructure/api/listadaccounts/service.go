@@ -3,114 +3,237 @@ package listadaccounts
 import (
 	"context"
 	"fmt"
-	"net/url"
+	"slices"
 	"strings"
 
 	"google-ads-mcp/internal/infrastructure/api/gaql"
-	"google-ads-mcp/internal/infrastructure/auth"
-	infrahttp "google-ads-mcp/internal/infrastructure/http"
-	"google-ads-mcp/internal/infrastructure/log"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
 
 	"github.com/shenzhencenter/google-ads-pb/services"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
 )
 
-const (
-	defaultBaseURL    = "https://googleads.googleapis.com"
-	defaultAPIVersion = "v22"
-)
+// defaultMaxDepth bounds the hierarchy walk when no max_depth is given.
+const defaultMaxDepth = 10
 
 type Service struct {
-	client         *infrahttp.Client
-	logger         log.Logger
-	tokenManager   auth.TokenProvider
-	endpoint       string
-	developerToken string
-	customerID     string
+	client       *googleads.Client
+	customerID   string
+	maxFetchRows int
 }
 
-func NewService(client *infrahttp.Client, logger log.Logger, tokenManager auth.TokenProvider, customerID, developerToken string) *Service {
-	baseURL, _ := url.Parse(defaultBaseURL)
-	version := strings.TrimPrefix(strings.TrimSpace(defaultAPIVersion), "/")
-	path := strings.TrimSuffix(baseURL.Path, "/")
-	path = fmt.Sprintf("%s/%s/customers/%s/googleAds:search", path, version, customerID)
-	baseURL.Path = path
-
+func NewService(client *googleads.Client, customerID string, maxFetchRows int) *Service {
 	return &Service{
-		client:         client,
-		logger:         logger,
-		tokenManager:   tokenManager,
-		endpoint:       baseURL.String(),
-		developerToken: developerToken,
-		customerID:     customerID,
+		client:       client,
+		customerID:   customerID,
+		maxFetchRows: maxFetchRows,
 	}
 }
 
 func (s *Service) ListAccounts(ctx context.Context, filters Filters) (Result, error) {
+	if filters.IncludeManagers {
+		return s.listHierarchy(ctx, filters)
+	}
+
 	query, err := s.buildQuery(filters)
 	if err != nil {
 		return Result{}, err
 	}
 
+	// A login_customer_id override lists the accounts below that manager instead.
+	customerID := s.customerID
+	loginCustomerID := logincustomer.NormalizeCustomerID(filters.LoginCustomerID)
+	if loginCustomerID != "" {
+		customerID = loginCustomerID
+	}
+
+	accounts := make([]Account, 0)
+
+	// fetch_all from the first page streams every row in a single response. Accounts
+	// past the cap are counted but not kept, since a stream cannot be resumed.
+	if filters.FetchAll && filters.PageToken == "" {
+		streamRequest := &services.SearchGoogleAdsStreamRequest{CustomerId: customerID, Query: query}
+		rowCount, err := s.client.SearchStream(ctx, streamRequest, loginCustomerID, func(row *services.GoogleAdsRow) error {
+			if len(accounts) >= s.maxFetchRows {
+				return nil
+			}
+			if account := mapRowToAccount(row); account != nil {
+				accounts = append(accounts, *account)
+			}
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("listadaccounts: %w", err)
+		}
+
+		return Result{
+			Accounts:          accounts,
+			TotalResultsCount: rowCount,
+		}, nil
+	}
+
 	request := &services.SearchGoogleAdsRequest{
-		Query: query,
+		CustomerId: customerID,
+		Query:      query,
+		PageToken:  filters.PageToken,
+		PageSize:   filters.PageSize,
 	}
 
-	accessToken, err := s.tokenManager.GetAccessToken(ctx)
+	// Walk pages server-side when fetch_all resumes from a page token. The row cap is
+	// checked between pages so no row is dropped and the caller can resume from NextPageToken.
+	protoResp, err := s.client.SearchPages(ctx, request, loginCustomerID, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.Results {
+			account := mapRowToAccount(row)
+			if account != nil {
+				accounts = append(accounts, *account)
+			}
+		}
+		return filters.FetchAll && len(accounts) < s.maxFetchRows, nil
+	})
 	if err != nil {
-		return Result{}, fmt.Errorf("listadaccounts: failed to get access token: %w", err)
+		return Result{}, fmt.Errorf("listadaccounts: %w", err)
 	}
 
-	headers := map[string]string{
-		"Content-Type":    "application/json",
-		"Authorization":   "Bearer " + accessToken,
-		"developer-token": s.developerToken,
+	return Result{
+		Accounts:          accounts,
+		NextPageToken:     protoResp.GetNextPageToken(),
+		TotalResultsCount: protoResp.GetTotalResultsCount(),
+	}, nil
+}
+
+// listHierarchy walks the manager tree below the configured customer, or below the
+// login_customer_id override when set. Each manager is queried for its direct links
+// (customer_client.level <= 1) while authenticating as the root manager through
+// login-customer-id, which grants access to the whole tree.
+// Accounts are returned depth-first, so every account follows its parent. An account
+// linked under several managers is listed once, under the first manager reached.
+func (s *Service) listHierarchy(ctx context.Context, filters Filters) (Result, error) {
+	if filters.PageToken != "" {
+		return Result{}, fmt.Errorf("listadaccounts: page_token cannot be combined with include_managers")
+	}
+	if len(filters.AccountIDs) > 0 || len(filters.AccountNames) > 0 {
+		return Result{}, fmt.Errorf("listadaccounts: account_ids and account_names cannot be combined with include_managers")
 	}
 
-	protoRequest := ProtoJSONRequest{Message: request}
-	response, err := s.client.Post(ctx, s.endpoint, protoRequest, headers)
-	if err != nil {
-		return Result{}, fmt.Errorf("listadaccounts: executing request: %w", err)
+	maxDepth := filters.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultMaxDepth
 	}
 
-	if response.StatusCode >= 400 {
-		return Result{}, fmt.Errorf("listadaccounts: api error status %d body %s", response.StatusCode, string(response.Body))
+	rootID := s.customerID
+	if loginCustomerID := logincustomer.NormalizeCustomerID(filters.LoginCustomerID); loginCustomerID != "" {
+		rootID = loginCustomerID
 	}
 
-	var protoResp services.SearchGoogleAdsResponse
-	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(response.Body, &protoResp); err != nil {
-		return Result{}, fmt.Errorf("listadaccounts: unmarshal response: %w", err)
+	root, children, err := s.listDirectLinks(ctx, rootID, rootID)
+	if err != nil {
+		return Result{}, err
+	}
+	if root == nil {
+		return Result{}, fmt.Errorf("listadaccounts: customer %s not found", rootID)
 	}
 
-	accounts := make([]Account, 0, len(protoResp.Results))
-	for _, row := range protoResp.Results {
-		custClient := row.GetCustomerClient()
-		if custClient == nil {
-			continue
-		}
+	root.Path = []string{root.CustomerID}
+	accounts := []Account{*root}
+	listed := map[string]bool{root.CustomerID: true}
 
-		accounts = append(accounts, Account{
-			CustomerID:   custClient.GetClientCustomer(),
-			CustomerName: custClient.GetDescriptiveName(),
-			CurrencyCode: custClient.GetCurrencyCode(),
-			TimeZone:     custClient.GetTimeZone(),
-			Status:       custClient.GetStatus().String(),
-			ResourceName: custClient.GetResourceName(),
-		})
+	var walk func(parent Account, children []Account) error
+	walk = func(parent Account, children []Account) error {
+		for _, child := range children {
+			if listed[child.CustomerID] {
+				continue
+			}
+			listed[child.CustomerID] = true
+
+			child.ParentID = parent.CustomerID
+			child.Level = parent.Level + 1
+			child.Path = append(slices.Clone(parent.Path), child.CustomerID)
+			accounts = append(accounts, child)
+
+			if !child.Manager || child.Level >= int64(maxDepth) {
+				continue
+			}
+
+			_, grandchildren, err := s.listDirectLinks(ctx, strings.TrimPrefix(child.CustomerID, "customers/"), rootID)
+			if err != nil {
+				return err
+			}
+			if err := walk(child, grandchildren); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
-	s.logger.Info(ctx, "google ads search", map[string]string{
-		"request_id": getHeaderValue(response.Headers, "request-id"),
-	})
+	if err := walk(*root, children); err != nil {
+		return Result{}, err
+	}
 
 	return Result{
 		Accounts:          accounts,
-		NextPageToken:     protoResp.GetNextPageToken(),
-		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		TotalResultsCount: int64(len(accounts)),
 	}, nil
 }
 
+// listDirectLinks returns a manager (level 0) and the accounts directly linked to it (level 1).
+func (s *Service) listDirectLinks(ctx context.Context, managerID, loginCustomerID string) (*Account, []Account, error) {
+	query := gaql.NewQueryBuilder("customer_client").
+		Select(
+			"customer_client.client_customer",
+			"customer_client.descriptive_name",
+			"customer_client.currency_code",
+			"customer_client.time_zone",
+			"customer_client.level",
+			"customer_client.manager",
+			"customer_client.status",
+		).
+		Where("customer_client.status = ENABLED").
+		Where("customer_client.level <= 1").
+		Build()
+
+	request := &services.SearchGoogleAdsRequest{CustomerId: managerID, Query: query}
+
+	var self *Account
+	var children []Account
+	_, err := s.client.SearchPages(ctx, request, loginCustomerID, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.Results {
+			account := mapRowToAccount(row)
+			if account == nil {
+				continue
+			}
+			if account.Level == 0 {
+				self = account
+				continue
+			}
+			children = append(children, *account)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("listadaccounts: %w", err)
+	}
+
+	return self, children, nil
+}
+
+func mapRowToAccount(row *services.GoogleAdsRow) *Account {
+	custClient := row.GetCustomerClient()
+	if custClient == nil {
+		return nil
+	}
+
+	return &Account{
+		CustomerID:   custClient.GetClientCustomer(),
+		CustomerName: custClient.GetDescriptiveName(),
+		CurrencyCode: custClient.GetCurrencyCode(),
+		TimeZone:     custClient.GetTimeZone(),
+		Status:       custClient.GetStatus().String(),
+		ResourceName: custClient.GetResourceName(),
+		Manager:      custClient.GetManager(),
+		Level:        custClient.GetLevel(),
+	}
+}
+
 func (s *Service) buildQuery(filters Filters) (string, error) {
 	qb := gaql.NewQueryBuilder("customer_client").
 		Select(
@@ -137,22 +260,10 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		qb.WhereAccountNames(filters.AccountNames)
 	}
 
-	return qb.Build(), nil
-}
-
-// ProtoJSONRequest wraps a protobuf message to provide custom JSON marshaling
-type ProtoJSONRequest struct {
-	Message proto.Message
-}
-
-// MarshalJSON implements json.Marshaler interface to use protobuf JSON marshaling
-func (p ProtoJSONRequest) MarshalJSON() ([]byte, error) {
-	return protojson.MarshalOptions{EmitUnpopulated: false}.Marshal(p.Message)
-}
-
-func getHeaderValue(headers map[string][]string, key string) string {
-	if values, exists := headers[key]; exists && len(values) > 0 {
-		return values[0]
+	// Limit how deep below the root customer accounts are listed
+	if filters.MaxDepth > 0 {
+		qb.Where(fmt.Sprintf("customer_client.level <= %d", filters.MaxDepth))
 	}
-	return ""
+
+	return qb.Build(), nil
 }
@@ -0,0 +1,241 @@
+package logincustomer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+	"google-ads-mcp/internal/infrastructure/log"
+
+	"github.com/shenzhencenter/google-ads-pb/enums"
+	"github.com/shenzhencenter/google-ads-pb/services"
+)
+
+const (
+	// RefreshInterval is how long a walked hierarchy is used before it is walked
+	// again, so relinked accounts pick up their new manager.
+	RefreshInterval = 10 * time.Minute
+
+	// maxDepth bounds the hierarchy walk below the root manager.
+	maxDepth = 10
+)
+
+// CachedResolver maps every account below the root manager to the manager it is
+// directly linked to, by walking customer_client links and caching the result for
+// the refresh interval. Concurrent callers share one walk, which runs without
+// holding the lock.
+type CachedResolver struct {
+	client          *googleads.Client
+	logger          log.Logger
+	rootCustomerID  string
+	refreshInterval time.Duration
+
+	mu       sync.Mutex
+	cached   *hierarchy
+	loadedAt time.Time
+	loading  *load // The walk in flight, if any
+}
+
+// hierarchy is the result of one walk below a manager.
+type hierarchy struct {
+	managers map[string]string // account -> shallowest manager it is linked to
+	clients  []string          // enabled non-manager accounts, in walk order
+}
+
+// load is a walk of the root hierarchy shared by the callers waiting for it.
+type load struct {
+	done      chan struct{}
+	hierarchy *hierarchy
+	err       error
+}
+
+// link is an account directly linked to a manager.
+type link struct {
+	customerID string
+	manager    bool
+	enabled    bool
+}
+
+func NewCachedResolver(client *googleads.Client, logger log.Logger, rootCustomerID string) *CachedResolver {
+	return &CachedResolver{
+		client:          client,
+		logger:          logger,
+		rootCustomerID:  NormalizeCustomerID(rootCustomerID),
+		refreshInterval: RefreshInterval,
+	}
+}
+
+// Resolve returns the override when set, the direct manager of customerID when it is
+// part of the root hierarchy, and the root manager otherwise, including when the
+// hierarchy cannot be walked: the API then decides with the root manager.
+func (r *CachedResolver) Resolve(ctx context.Context, customerID, override string) (string, error) {
+	if override = NormalizeCustomerID(override); override != "" {
+		return override, nil
+	}
+
+	customerID = NormalizeCustomerID(customerID)
+	if customerID == "" || customerID == r.rootCustomerID {
+		return r.rootCustomerID, nil
+	}
+
+	h, err := r.rootHierarchy(ctx)
+	if err != nil {
+		r.logger.Warn(ctx, "google ads login customer hierarchy not loaded, using the root manager", map[string]string{
+			"customer_id": customerID,
+			"error":       err.Error(),
+		})
+		return r.rootCustomerID, nil
+	}
+
+	if manager, ok := h.managers[customerID]; ok {
+		return manager, nil
+	}
+	return r.rootCustomerID, nil
+}
+
+// ClientAccounts returns the enabled non-manager accounts below managerID, or below
+// the root manager when managerID is empty. The root hierarchy is served from cache.
+func (r *CachedResolver) ClientAccounts(ctx context.Context, managerID string) ([]string, error) {
+	managerID = NormalizeCustomerID(managerID)
+	if managerID != "" && managerID != r.rootCustomerID {
+		h, err := r.walk(ctx, managerID)
+		if err != nil {
+			return nil, fmt.Errorf("logincustomer: walking account hierarchy: %w", err)
+		}
+		return h.clients, nil
+	}
+
+	h, err := r.rootHierarchy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Clone(h.clients), nil
+}
+
+// rootHierarchy returns the cached root hierarchy, walking it again once it is older
+// than the refresh interval. Callers arriving during a walk wait for its result.
+func (r *CachedResolver) rootHierarchy(ctx context.Context) (*hierarchy, error) {
+	r.mu.Lock()
+	if r.cached != nil && time.Since(r.loadedAt) < r.refreshInterval {
+		h := r.cached
+		r.mu.Unlock()
+		return h, nil
+	}
+
+	current := r.loading
+	if current == nil {
+		current = &load{done: make(chan struct{})}
+		r.loading = current
+		r.mu.Unlock()
+
+		h, err := r.walk(ctx, r.rootCustomerID)
+		if err != nil {
+			err = fmt.Errorf("logincustomer: walking account hierarchy: %w", err)
+		}
+
+		r.mu.Lock()
+		if err == nil {
+			r.cached = h
+			r.loadedAt = time.Now()
+		}
+		r.loading = nil
+		r.mu.Unlock()
+
+		current.hierarchy, current.err = h, err
+		close(current.done)
+		return h, err
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-current.done:
+		return current.hierarchy, current.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// NormalizeCustomerID strips the "customers/" prefix and dashes from a customer ID.
+func NormalizeCustomerID(customerID string) string {
+	customerID = strings.TrimPrefix(strings.TrimSpace(customerID), "customers/")
+	return strings.ReplaceAll(customerID, "-", "")
+}
+
+// walk visits the hierarchy below rootID breadth-first so every account is mapped
+// to the shallowest manager it is linked to. Every manager is queried while
+// authenticating as rootID, which grants access to the whole tree, as
+// list_ad_accounts does.
+func (r *CachedResolver) walk(ctx context.Context, rootID string) (*hierarchy, error) {
+	h := &hierarchy{managers: make(map[string]string)}
+	visited := map[string]bool{rootID: true}
+	queue := []string{rootID}
+
+	for depth := 0; depth < maxDepth && len(queue) > 0; depth++ {
+		var next []string
+		for _, managerID := range queue {
+			links, err := r.listDirectLinks(ctx, managerID, rootID)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, child := range links {
+				if _, ok := h.managers[child.customerID]; ok || child.customerID == rootID {
+					continue
+				}
+				h.managers[child.customerID] = managerID
+				if child.manager {
+					if !visited[child.customerID] {
+						visited[child.customerID] = true
+						next = append(next, child.customerID)
+					}
+				} else if child.enabled {
+					h.clients = append(h.clients, child.customerID)
+				}
+			}
+		}
+		queue = next
+	}
+
+	r.logger.Info(ctx, "google ads login customer hierarchy loaded", map[string]string{
+		"root_customer_id": rootID,
+		"accounts":         fmt.Sprintf("%d", len(h.managers)),
+	})
+
+	return h, nil
+}
+
+// listDirectLinks returns the accounts directly linked to a manager, authenticating
+// as loginCustomerID.
+func (r *CachedResolver) listDirectLinks(ctx context.Context, managerID, loginCustomerID string) ([]link, error) {
+	query := gaql.NewQueryBuilder("customer_client").
+		Select("customer_client.client_customer", "customer_client.manager", "customer_client.status").
+		Where("customer_client.level = 1").
+		Build()
+
+	request := &services.SearchGoogleAdsRequest{CustomerId: managerID, Query: query}
+	var links []link
+	_, err := r.client.SearchPages(ctx, request, loginCustomerID, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.GetResults() {
+			custClient := row.GetCustomerClient()
+			if custClient == nil {
+				continue
+			}
+			links = append(links, link{
+				customerID: NormalizeCustomerID(custClient.GetClientCustomer()),
+				manager:    custClient.GetManager(),
+				enabled:    custClient.GetStatus() == enums.CustomerStatusEnum_ENABLED,
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
@@ -0,0 +1,17 @@
+package logincustomer
+
+import "context"
+
+// Resolver defines the interface for choosing the login-customer-id header of a request
+type Resolver interface {
+	// Resolve returns the manager account to authenticate as when calling customerID.
+	// A non-empty override is returned as is.
+	Resolve(ctx context.Context, customerID, override string) (string, error)
+}
+
+// AccountLister defines the interface for listing the accounts managed below a manager
+type AccountLister interface {
+	// ClientAccounts returns the enabled non-manager accounts below managerID,
+	// or below the configured root manager when managerID is empty.
+	ClientAccounts(ctx context.Context, managerID string) ([]string, error)
+}
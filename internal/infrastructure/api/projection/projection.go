@@ -0,0 +1,202 @@
+package projection
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+)
+
+// metricsPrefix marks the allowlist names that select a metric rather than an attribute.
+const metricsPrefix = "metrics."
+
+// metricObjects are the item keys that hold metric objects keyed by metric name.
+var metricObjects = []string{"metrics", "previous_metrics", "delta", "percent_change"}
+
+// Allowlist maps every field a tool can project to the GAQL fields that populate it.
+// Attribute names are the item's output keys, e.g. "campaign_name"; metric names are
+// prefixed with "metrics.", e.g. "metrics.clicks". Money fields are listed without
+// their "_micros" suffix so one name selects both the micros and the decimal amount.
+// Attributes the allowlist does not list, such as ids and resource names, are always
+// returned; metric objects only keep the selected metrics.
+type Allowlist map[string][]string
+
+// Selection is a validated set of allowlisted fields. The zero Selection selects every field.
+type Selection struct {
+	allowlist Allowlist
+	fields    map[string]bool
+	columns   []string
+}
+
+// Select validates the requested field names against the allowlist. Metric names may
+// omit the "metrics." prefix and money fields may keep their "_micros" suffix.
+// No names selects every field.
+func (a Allowlist) Select(names []string) (Selection, error) {
+	if len(names) == 0 {
+		return Selection{}, nil
+	}
+
+	s := Selection{allowlist: a, fields: make(map[string]bool, len(names))}
+	for _, name := range names {
+		field, ok := a.lookup(name)
+		if !ok {
+			return Selection{}, fmt.Errorf("invalid field %q: must be one of %s", name, strings.Join(a.Names(), ", "))
+		}
+		if s.fields[field] {
+			continue
+		}
+		s.fields[field] = true
+		for _, column := range a[field] {
+			if !slices.Contains(s.columns, column) {
+				s.columns = append(s.columns, column)
+			}
+		}
+	}
+	return s, nil
+}
+
+// Names returns the allowlisted field names, sorted.
+func (a Allowlist) Names() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (a Allowlist) lookup(name string) (string, bool) {
+	name = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "_micros")
+	if _, ok := a[name]; ok {
+		return name, true
+	}
+	if _, ok := a[metricsPrefix+name]; ok && !strings.HasPrefix(name, metricsPrefix) {
+		return metricsPrefix + name, true
+	}
+	return "", false
+}
+
+// All reports whether every field is selected.
+func (s Selection) All() bool {
+	return len(s.fields) == 0
+}
+
+// Columns returns the GAQL fields to select: the required fields followed by the
+// fields of every selected name. For the zero Selection it returns all unchanged.
+func (s Selection) Columns(required, all []string) []string {
+	if s.All() {
+		return all
+	}
+
+	columns := slices.Clone(required)
+	for _, column := range s.columns {
+		if !slices.Contains(columns, column) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// Marshal encodes v as JSON and removes the unselected fields from the items found
+// at each path. A path names an array of items, e.g. "ads"; dotted paths descend
+// through objects and arrays, e.g. "accounts.campaigns".
+func (s Selection) Marshal(v any, paths ...string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || s.All() {
+		return data, err
+	}
+
+	// Decode numbers as json.Number so micros amounts keep their precision
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var document map[string]any
+	if err := decoder.Decode(&document); err != nil {
+		return nil, fmt.Errorf("projection: %w", err)
+	}
+
+	for _, path := range paths {
+		s.projectPath(document, strings.Split(path, "."))
+	}
+	return json.Marshal(document)
+}
+
+func (s Selection) projectPath(value any, path []string) {
+	switch v := value.(type) {
+	case []any:
+		for _, element := range v {
+			s.projectPath(element, path)
+		}
+	case map[string]any:
+		if len(path) == 0 {
+			s.project(v)
+			return
+		}
+		s.projectPath(v[path[0]], path[1:])
+	}
+}
+
+// project removes the unselected attributes of an item and keeps only the selected
+// metrics in its metric objects, including those of its segments and comparison.
+func (s Selection) project(item map[string]any) {
+	for key, value := range item {
+		switch key {
+		case "segments":
+			s.projectPath(value, nil)
+		case "comparison":
+			if comparison, ok := value.(map[string]any); ok {
+				s.projectMetrics(comparison)
+			}
+		default:
+			field := strings.TrimSuffix(key, "_micros")
+			if _, known := s.allowlist[field]; known && !s.fields[field] {
+				delete(item, key)
+			}
+		}
+	}
+	s.projectMetrics(item)
+}
+
+func (s Selection) projectMetrics(parent map[string]any) {
+	for _, key := range metricObjects {
+		metrics, ok := parent[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		for name := range metrics {
+			if !s.fields[metricsPrefix+strings.TrimSuffix(name, "_micros")] {
+				delete(metrics, name)
+			}
+		}
+		if len(metrics) == 0 {
+			delete(parent, key)
+		}
+	}
+}
+
+// OutputSchema infers the output schema of T without required properties below the
+// top level, so outputs projected to a subset of their fields still validate.
+func OutputSchema[T any]() (*jsonschema.Schema, error) {
+	schema, err := jsonschema.For[T](&jsonschema.ForOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("projection: %w", err)
+	}
+	for _, property := range schema.Properties {
+		relax(property)
+	}
+	return schema, nil
+}
+
+func relax(schema *jsonschema.Schema) {
+	if schema == nil {
+		return
+	}
+	schema.Required = nil
+	for _, property := range schema.Properties {
+		relax(property)
+	}
+	relax(schema.Items)
+	relax(schema.AdditionalProperties)
+}
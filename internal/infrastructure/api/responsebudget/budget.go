@@ -0,0 +1,161 @@
+package responsebudget
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+)
+
+// bytesPerToken is the rough size of a token in JSON output, used to turn a token
+// budget into bytes.
+const bytesPerToken = 4
+
+// Budget caps the size of the JSON a tool returns. The zero Budget is unlimited.
+type Budget struct {
+	maxBytes int
+}
+
+// New returns a budget of maxBytes bytes or maxTokens estimated tokens, whichever is
+// smaller. Zero disables a limit; both zero gives an unlimited budget.
+func New(maxBytes, maxTokens int) Budget {
+	if maxTokens > 0 && (maxBytes <= 0 || maxTokens*bytesPerToken < maxBytes) {
+		maxBytes = maxTokens * bytesPerToken
+	}
+	return Budget{maxBytes: max(maxBytes, 0)}
+}
+
+// MaxBytes returns the size limit in bytes; 0 means unlimited.
+func (b Budget) MaxBytes() int {
+	return b.maxBytes
+}
+
+// Truncation marks a response whose rows were dropped to fit the budget. Tool outputs
+// embed it so the marker sits at the top level next to their rows.
+type Truncation struct {
+	Truncated bool     `json:"truncated,omitempty"`
+	Summary   *Summary `json:"truncation,omitempty"`
+}
+
+// Summary counts the rows kept and dropped. Totals cover every row, including the
+// dropped ones, for tools whose rows carry metrics.
+type Summary struct {
+	ReturnedRows int     `json:"returned_rows"`
+	OmittedRows  int     `json:"omitted_rows"`
+	MaxBytes     int     `json:"max_bytes"`
+	Totals       *Totals `json:"totals,omitempty"`
+}
+
+// Totals rolls up the metrics of every row. Money amounts are in micros.
+type Totals struct {
+	Rows                     int     `json:"rows"`
+	Clicks                   int64   `json:"clicks"`
+	Impressions              int64   `json:"impressions"`
+	Interactions             int64   `json:"interactions"`
+	CostMicros               int64   `json:"cost_micros"`
+	Conversions              float64 `json:"conversions"`
+	ConversionsValue         float64 `json:"conversions_value"`
+	CTR                      float64 `json:"ctr"`
+	AverageCPCMicros         int64   `json:"average_cpc_micros"`
+	ConversionRate           float64 `json:"conversion_rate"`
+	ROAS                     float64 `json:"roas"`
+	CPAMicros                int64   `json:"cpa_micros"`
+	CPMMicros                int64   `json:"cpm_micros"`
+	CostPerInteractionMicros int64   `json:"cost_per_interaction_micros"`
+	ValuePerClick            float64 `json:"value_per_click"`
+}
+
+// Sum adds up the metrics of rows and derives the ratios from the sums.
+func Sum(rows int, inputs []kpi.Inputs) *Totals {
+	var sum kpi.Inputs
+	for _, in := range inputs {
+		sum.Clicks += in.Clicks
+		sum.Impressions += in.Impressions
+		sum.Interactions += in.Interactions
+		sum.CostMicros += in.CostMicros
+		sum.Conversions += in.Conversions
+		sum.ConversionsValue += in.ConversionsValue
+	}
+
+	var ctr float64
+	if sum.Impressions > 0 {
+		ctr = float64(sum.Clicks) / float64(sum.Impressions)
+	}
+
+	kpis := kpi.Compute(sum)
+	return &Totals{
+		Rows:                     rows,
+		Clicks:                   sum.Clicks,
+		Impressions:              sum.Impressions,
+		Interactions:             sum.Interactions,
+		CostMicros:               sum.CostMicros,
+		Conversions:              sum.Conversions,
+		ConversionsValue:         sum.ConversionsValue,
+		CTR:                      ctr,
+		AverageCPCMicros:         kpi.AverageCPC(sum.CostMicros, sum.Clicks),
+		ConversionRate:           kpi.ConversionRate(sum.Conversions, sum.Clicks),
+		ROAS:                     kpis.ROAS,
+		CPAMicros:                kpis.CPAMicros,
+		CPMMicros:                kpis.CPMMicros,
+		CostPerInteractionMicros: kpis.CostPerInteractionMicros,
+		ValuePerClick:            kpis.ValuePerClick,
+	}
+}
+
+// payloadCopies is the number of times a tool result carries its JSON: the MCP SDK
+// sends the typed output as structured content next to the text content.
+const payloadCopies = 2
+
+// Marshal encodes output, a pointer to a tool output, as JSON. When the encoding is
+// over budget it keeps the longest prefix of *rows that fits, so the rows kept only
+// depend on their order, and marks *truncation with the counts and totals. totals
+// may be nil for rows without metrics.
+func Marshal[R any](b Budget, output any, rows *[]R, truncation *Truncation, totals func() *Totals) ([]byte, error) {
+	all := *rows
+	return MarshalFunc(b, output, len(all), func(n int) { *rows = all[:n] }, truncation, totals)
+}
+
+// MarshalFunc is Marshal for outputs whose rows are not held in one slice, such as
+// rows grouped by account. keep(n) must leave only the first n of the total rows in
+// output. Since the result carries the JSON both as text and as structured content,
+// each copy is held to an equal share of the budget.
+func MarshalFunc(b Budget, output any, total int, keep func(n int), truncation *Truncation, totals func() *Totals) ([]byte, error) {
+	maxBytes := b.maxBytes / payloadCopies
+	data, err := json.Marshal(output)
+	if err != nil || b.maxBytes == 0 || len(data) <= maxBytes {
+		return data, err
+	}
+
+	summary := &Summary{MaxBytes: b.maxBytes}
+	if totals != nil {
+		summary.Totals = totals()
+	}
+	*truncation = Truncation{Truncated: true, Summary: summary}
+
+	encode := func(n int) ([]byte, error) {
+		keep(n)
+		summary.ReturnedRows = n
+		summary.OmittedRows = total - n
+		data, err := json.Marshal(output)
+		if err != nil {
+			return nil, fmt.Errorf("responsebudget: %w", err)
+		}
+		return data, nil
+	}
+
+	// Binary search for the most rows that fit; all of them are known not to fit
+	low, high := 0, total-1
+	for low < high {
+		mid := (low + high + 1) / 2
+		data, err := encode(mid)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) <= maxBytes {
+			low = mid
+		} else {
+			high = mid - 1
+		}
+	}
+	return encode(low)
+}
@@ -0,0 +1,12 @@
+package rungaqlquery
+
+// Filters captures the parameters used to run a raw GAQL query.
+type Filters struct {
+	CustomerID      string
+	LoginCustomerID string
+	Query           string
+	PageToken       string
+	PageSize        int32
+	FetchAll        bool
+	BypassCache     bool // Skips the search cache and refreshes it with the fetched results
+}
@@ -0,0 +1,16 @@
+package rungaqlquery
+
+import "google-ads-mcp/internal/infrastructure/api/searchcache"
+
+// Row is a GoogleAdsRow flattened into GAQL field paths (e.g. "campaign.id").
+type Row map[string]any
+
+// Result aggregates the query rows along with pagination metadata.
+type Result struct {
+	Resource          string
+	Fields            []string
+	Rows              []Row
+	NextPageToken     string
+	TotalResultsCount int64
+	Cache             searchcache.Status // Whether the rows came from the search cache; empty when caching is disabled
+}
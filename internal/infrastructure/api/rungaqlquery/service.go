@@ -0,0 +1,135 @@
+package rungaqlquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type Service struct {
+	client        *searchcache.Client
+	loginResolver logincustomer.Resolver
+	maxFetchRows  int
+}
+
+func NewService(client *searchcache.Client, loginResolver logincustomer.Resolver, maxFetchRows int) *Service {
+	return &Service{
+		client:        client,
+		loginResolver: loginResolver,
+		maxFetchRows:  maxFetchRows,
+	}
+}
+
+func (s *Service) RunQuery(ctx context.Context, filters Filters) (Result, error) {
+	query, err := gaql.ParseQuery(filters.Query)
+	if err != nil {
+		return Result{}, fmt.Errorf("rungaqlquery: invalid query: %w", err)
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, filters.CustomerID, filters.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("rungaqlquery: resolving login customer ID: %w", err)
+	}
+
+	rows := make([]Row, 0)
+
+	// fetch_all from the first page streams every row in a single response. Rows
+	// past the cap are counted but not kept, since a stream cannot be resumed.
+	if filters.FetchAll && filters.PageToken == "" {
+		streamRequest := &services.SearchGoogleAdsStreamRequest{CustomerId: filters.CustomerID, Query: query.Text}
+		rowCount, cacheStatus, err := s.client.SearchStream(ctx, streamRequest, loginCustomerID, filters.BypassCache, func(protoRow *services.GoogleAdsRow) error {
+			if len(rows) >= s.maxFetchRows {
+				return nil
+			}
+			row, err := flattenRow(protoRow)
+			if err != nil {
+				return fmt.Errorf("flatten row: %w", err)
+			}
+			rows = append(rows, row)
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("rungaqlquery: %w", err)
+		}
+
+		return Result{
+			Resource:          query.Resource,
+			Fields:            query.Fields,
+			Rows:              rows,
+			TotalResultsCount: rowCount,
+			Cache:             cacheStatus,
+		}, nil
+	}
+
+	request := &services.SearchGoogleAdsRequest{
+		CustomerId: filters.CustomerID,
+		Query:      query.Text,
+		PageToken:  filters.PageToken,
+		PageSize:   filters.PageSize,
+	}
+
+	// Walk pages server-side when fetch_all resumes from a page token. The row cap is
+	// checked between pages so no row is dropped and the caller can resume from NextPageToken.
+	protoResp, cacheStatus, err := s.client.SearchPages(ctx, request, loginCustomerID, filters.BypassCache, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, protoRow := range page.Results {
+			row, err := flattenRow(protoRow)
+			if err != nil {
+				return false, fmt.Errorf("flatten row: %w", err)
+			}
+			rows = append(rows, row)
+		}
+		return filters.FetchAll && len(rows) < s.maxFetchRows, nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("rungaqlquery: %w", err)
+	}
+
+	return Result{
+		Resource:          query.Resource,
+		Fields:            query.Fields,
+		Rows:              rows,
+		NextPageToken:     protoResp.GetNextPageToken(),
+		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		Cache:             cacheStatus,
+	}, nil
+}
+
+// flattenRow marshals a row with protojson (keeping proto field names) and flattens
+// nested messages into dotted GAQL paths. Repeated fields are kept as JSON arrays.
+func flattenRow(row *services.GoogleAdsRow) (Row, error) {
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+
+	var nested map[string]any
+	if err := json.Unmarshal(data, &nested); err != nil {
+		return nil, err
+	}
+
+	flat := make(Row)
+	flattenInto(flat, "", nested)
+	return flat, nil
+}
+
+func flattenInto(flat Row, prefix string, values map[string]any) {
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if child, ok := value.(map[string]any); ok {
+			flattenInto(flat, path, child)
+			continue
+		}
+		flat[path] = value
+	}
+}
@@ -0,0 +1,34 @@
+package searchadgroups
+
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
+// requiredFields identify an ad group and are selected whatever fields are requested.
+var requiredFields = []string{
+	"ad_group.id",
+	"ad_group.resource_name",
+}
+
+// Fields is the allowlist of output fields the caller can select, mapped to the GAQL
+// fields that populate them. Derived metrics list every field they are computed from.
+var Fields = projection.Allowlist{
+	"name":                         {"ad_group.name"},
+	"status":                       {"ad_group.status"},
+	"type":                         {"ad_group.type"},
+	"campaign_id":                  {"campaign.id"},
+	"campaign_name":                {"campaign.name"},
+	"campaign_resource_name":       {"campaign.resource_name"},
+	"metrics.clicks":               {"metrics.clicks"},
+	"metrics.impressions":          {"metrics.impressions"},
+	"metrics.ctr":                  {"metrics.ctr"},
+	"metrics.average_cpc":          {"metrics.average_cpc"},
+	"metrics.cost":                 {"metrics.cost_micros"},
+	"metrics.conversions":          {"metrics.conversions"},
+	"metrics.conversions_value":    {"metrics.conversions_value"},
+	"metrics.interactions":         {"metrics.interactions"},
+	"metrics.conversion_rate":      {"metrics.conversions", "metrics.clicks"},
+	"metrics.roas":                 {"metrics.conversions_value", "metrics.cost_micros"},
+	"metrics.cpa":                  {"metrics.cost_micros", "metrics.conversions"},
+	"metrics.cpm":                  {"metrics.cost_micros", "metrics.impressions"},
+	"metrics.cost_per_interaction": {"metrics.cost_micros", "metrics.interactions"},
+	"metrics.value_per_click":      {"metrics.conversions_value", "metrics.clicks"},
+}
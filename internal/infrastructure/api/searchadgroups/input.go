@@ -1,14 +1,32 @@
 package searchadgroups
 
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
 // Filters captures the parameters used to search for ad groups.
 type Filters struct {
-	CustomerID      string
-	AdGroupIDs      []string
-	AdGroupNames    []string
-	Statuses        []string
-	CampaignIDs     []string
-	CampaignNames   []string
-	DateRangeStart  string
-	DateRangeEnd    string
+	CustomerID            string
+	LoginCustomerID       string
+	AdGroupIDs            []string
+	AdGroupNames          []string
+	Statuses              []string
+	CampaignIDs           []string
+	CampaignNames         []string
+	MinCostMicros         int64
+	MinImpressions        int64
+	MinClicks             int64
+	MinConversions        float64
+	MaxCPAMicros          int64  // Cost per conversion ceiling; rows without conversions are excluded
+	OrderBy               string // Metric to sort by, optionally followed by ASC or DESC
+	Limit                 int
+	DateRangeStart        string
+	DateRangeEnd          string
+	SegmentBy             string
+	CompareTo             string
+	CompareDateRangeStart string
+	CompareDateRangeEnd   string
+	PageToken             string
+	PageSize              int32
+	FetchAll              bool
+	BypassCache           bool                 // Skips the search cache and refreshes it with the fetched results
+	Fields                projection.Selection // Output fields to select; the zero Selection selects them all
 }
-
@@ -1,25 +1,51 @@
 package searchadgroups
 
+import (
+	"google-ads-mcp/internal/infrastructure/api/comparison"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+)
+
 // AdGroup represents a normalized Google Ads ad group.
 type AdGroup struct {
-	ID                     string
-	ResourceName           string
-	Name                   string
-	Status                 string
-	Type                   string
-	CampaignID             string
-	CampaignName           string
-	CampaignResourceName   string
-	Metrics                AdGroupMetrics
+	ID                   string
+	ResourceName         string
+	Name                 string
+	Status               string
+	Type                 string
+	CampaignID           string
+	CampaignName         string
+	CampaignResourceName string
+	Metrics              AdGroupMetrics
+	Segments             []AdGroupSegment   // Populated instead of Metrics when SegmentBy is set
+	Comparison           *AdGroupComparison // Populated when a period comparison is requested
+	Incomplete           bool               // Set when more Segments of the ad group may follow on the next page
+}
+
+// AdGroupComparison holds the metrics of an ad group in the comparison period.
+type AdGroupComparison struct {
+	Presence        comparison.Presence
+	PreviousMetrics AdGroupMetrics
+}
+
+// AdGroupSegment holds the metrics of an ad group for a single segment value (e.g. one date or device).
+type AdGroupSegment struct {
+	Value   string
+	Metrics AdGroupMetrics
 }
 
 // AdGroupMetrics represents metrics for an ad group.
 type AdGroupMetrics struct {
-	Clicks      int64   // Total clicks
-	Impressions int64   // Total impressions
-	CTR         float64 // Click-through rate
-	AverageCPC  int64   // Average cost per click in micros
-	CostMicros  int64   // Total cost in micros
+	Clicks           int64    // Total clicks
+	Impressions      int64    // Total impressions
+	CTR              float64  // Click-through rate
+	AverageCPC       int64    // Average cost per click in micros
+	CostMicros       int64    // Total cost in micros
+	Conversions      float64  // Conversions
+	ConversionsValue float64  // Total conversion value
+	ConversionRate   float64  // Conversion rate (percentage)
+	Interactions     int64    // Total interactions (clicks + engagements)
+	KPIs             kpi.KPIs // Derived ratios (ROAS, CPA, CPM, ...)
 }
 
 // Result aggregates the ad groups outcome along with pagination metadata.
@@ -27,5 +53,7 @@ type Result struct {
 	AdGroups          []AdGroup
 	NextPageToken     string
 	TotalResultsCount int64
+	HasMore           bool               // Set when rows were left out: resume from NextPageToken, or narrow a fetch_all past the row cap
+	Cache             searchcache.Status // Whether the rows came from the search cache; empty when caching is disabled
+	ComparisonPeriod  *comparison.Period // Populated when a period comparison is requested
 }
-
@@ -3,125 +3,217 @@ package searchadgroups
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
 
+	"google-ads-mcp/internal/infrastructure/api/comparison"
 	"google-ads-mcp/internal/infrastructure/api/gaql"
-	"google-ads-mcp/internal/infrastructure/auth"
-	infrahttp "google-ads-mcp/internal/infrastructure/http"
-	"google-ads-mcp/internal/infrastructure/log"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
 
 	"github.com/shenzhencenter/google-ads-pb/services"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
-)
-
-const (
-	defaultBaseURL    = "https://googleads.googleapis.com"
-	defaultAPIVersion = "v22"
 )
 
 type Service struct {
-	client         *infrahttp.Client
-	logger         log.Logger
-	tokenManager   auth.TokenProvider
-	developerToken string
-	loginCustomerID string
+	client        *searchcache.Client
+	loginResolver logincustomer.Resolver
+	maxFetchRows  int
 }
 
-func NewService(client *infrahttp.Client, logger log.Logger, tokenManager auth.TokenProvider, loginCustomerID, developerToken string) *Service {
+func NewService(client *searchcache.Client, loginResolver logincustomer.Resolver, maxFetchRows int) *Service {
 	return &Service{
-		client:         client,
-		logger:         logger,
-		tokenManager:   tokenManager,
-		developerToken: developerToken,
-		loginCustomerID: loginCustomerID,
+		client:        client,
+		loginResolver: loginResolver,
+		maxFetchRows:  maxFetchRows,
 	}
 }
 
 func (s *Service) SearchAdGroups(ctx context.Context, filters Filters) (Result, error) {
-	// Build endpoint URL with customer ID from filters
-	endpoint, err := s.buildEndpoint(filters.CustomerID)
-	if err != nil {
-		return Result{}, fmt.Errorf("searchadgroups: invalid customer ID: %w", err)
+	request := comparison.Request{
+		CompareTo:             filters.CompareTo,
+		DateRangeStart:        filters.DateRangeStart,
+		DateRangeEnd:          filters.DateRangeEnd,
+		CompareDateRangeStart: filters.CompareDateRangeStart,
+		CompareDateRangeEnd:   filters.CompareDateRangeEnd,
 	}
-
-	query, err := s.buildQuery(filters)
-	if err != nil {
-		return Result{}, err
+	if request.Enabled() {
+		return s.compareAdGroups(ctx, filters, request)
 	}
 
-	request := &services.SearchGoogleAdsRequest{
-		Query: query,
-	}
+	return s.searchAdGroups(ctx, filters)
+}
 
-	accessToken, err := s.tokenManager.GetAccessToken(ctx)
+// compareAdGroups runs the query for both periods and matches ad groups by resource name.
+// AdGroups with data in only one of the periods are still returned; a period with
+// more ad groups than the fetch_all cap fails the comparison.
+func (s *Service) compareAdGroups(ctx context.Context, filters Filters, request comparison.Request) (Result, error) {
+	period, err := comparison.ResolvePeriod(request)
 	if err != nil {
-		return Result{}, fmt.Errorf("searchadgroups: failed to get access token: %w", err)
+		return Result{}, fmt.Errorf("searchadgroups: building query: %w", err)
+	}
+	if strings.TrimSpace(filters.SegmentBy) != "" {
+		return Result{}, fmt.Errorf("searchadgroups: segment_by cannot be combined with a period comparison")
+	}
+	if filters.PageToken != "" {
+		return Result{}, fmt.Errorf("searchadgroups: page_token cannot be used with a period comparison")
 	}
+	if filters.Limit > 0 {
+		return Result{}, fmt.Errorf("searchadgroups: limit cannot be used with a period comparison")
+	}
+
+	// Both periods are walked in full so every ad group can be matched
+	filters.FetchAll = true
 
-	headers := map[string]string{
-		"Content-Type":      "application/json",
-		"Authorization":     "Bearer " + accessToken,
-		"developer-token":    s.developerToken,
-		"login-customer-id":  s.loginCustomerID,
+	filters.DateRangeStart, filters.DateRangeEnd = period.CurrentStart, period.CurrentEnd
+	current, err := s.searchAdGroups(ctx, filters)
+	if err != nil {
+		return Result{}, err
 	}
 
-	protoRequest := ProtoJSONRequest{Message: request}
-	response, err := s.client.Post(ctx, endpoint, protoRequest, headers)
+	filters.DateRangeStart, filters.DateRangeEnd = period.PreviousStart, period.PreviousEnd
+	previous, err := s.searchAdGroups(ctx, filters)
 	if err != nil {
-		return Result{}, fmt.Errorf("searchadgroups: executing request: %w", err)
+		return Result{}, err
 	}
 
-	if response.StatusCode >= 400 {
-		return Result{}, fmt.Errorf("searchadgroups: api error status %d body %s", response.StatusCode, string(response.Body))
+	// A period cut off at the row cap would report its missing ad groups as present
+	// only in the other period
+	if current.HasMore || previous.HasMore {
+		return Result{}, fmt.Errorf("searchadgroups: a period has more than %d ad groups, the MAX_FETCH_ALL_ROWS cap; narrow the search with filters to compare periods", s.maxFetchRows)
 	}
 
-	var protoResp services.SearchGoogleAdsResponse
-	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(response.Body, &protoResp); err != nil {
-		return Result{}, fmt.Errorf("searchadgroups: unmarshal response: %w", err)
+	previousByName := make(map[string]AdGroup, len(previous.AdGroups))
+	for _, adGroup := range previous.AdGroups {
+		previousByName[adGroup.ResourceName] = adGroup
 	}
 
-	adGroups := make([]AdGroup, 0, len(protoResp.Results))
-	for _, row := range protoResp.Results {
-		adGroup := s.mapRowToAdGroup(row)
-		if adGroup != nil {
-			adGroups = append(adGroups, *adGroup)
+	adGroups := make([]AdGroup, 0, len(current.AdGroups))
+	for _, adGroup := range current.AdGroups {
+		adGroup.Comparison = &AdGroupComparison{Presence: comparison.PresenceCurrentOnly}
+		if prev, ok := previousByName[adGroup.ResourceName]; ok {
+			adGroup.Comparison = &AdGroupComparison{Presence: comparison.PresenceBoth, PreviousMetrics: prev.Metrics}
+			delete(previousByName, adGroup.ResourceName)
 		}
+		adGroups = append(adGroups, adGroup)
 	}
 
-	s.logger.Info(ctx, "google ads ad group search", map[string]string{
-		"request_id": getHeaderValue(response.Headers, "request-id"),
-	})
+	for _, adGroup := range previous.AdGroups {
+		if _, ok := previousByName[adGroup.ResourceName]; !ok {
+			continue
+		}
+		adGroup.Comparison = &AdGroupComparison{Presence: comparison.PresencePreviousOnly, PreviousMetrics: adGroup.Metrics}
+		adGroup.Metrics = AdGroupMetrics{}
+		adGroups = append(adGroups, adGroup)
+	}
 
 	return Result{
 		AdGroups:          adGroups,
-		NextPageToken:     protoResp.GetNextPageToken(),
-		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		TotalResultsCount: int64(len(adGroups)),
+		ComparisonPeriod:  &period,
+		Cache:             current.Cache.Merge(previous.Cache),
 	}, nil
 }
 
-func (s *Service) buildEndpoint(customerID string) (string, error) {
-	baseURL, err := url.Parse(defaultBaseURL)
+func (s *Service) searchAdGroups(ctx context.Context, filters Filters) (Result, error) {
+	var err error
+	filters.SegmentBy, err = gaql.NormalizeSegment(filters.SegmentBy)
+	if err != nil {
+		return Result{}, fmt.Errorf("searchadgroups: building query: %w", err)
+	}
+
+	query, err := s.buildQuery(filters)
+	if err != nil {
+		return Result{}, err
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, filters.CustomerID, filters.LoginCustomerID)
 	if err != nil {
-		return "", err
+		return Result{}, fmt.Errorf("searchadgroups: resolving login customer ID: %w", err)
+	}
+
+	adGroups := make([]AdGroup, 0)
+	// Segmented queries return one row per entity and segment value; rows are
+	// grouped back under their entity so each one is emitted only once.
+	adGroupIndex := make(map[string]int)
+	var hasMore bool
+	// addRow returns the index of the ad group the row was grouped under, or -1
+	addRow := func(row *services.GoogleAdsRow, keepNew bool) int {
+		adGroup := s.mapRowToAdGroup(row, filters.SegmentBy)
+		if adGroup == nil {
+			return -1
+		}
+		if i, ok := adGroupIndex[adGroup.ResourceName]; ok {
+			adGroups[i].Segments = append(adGroups[i].Segments, adGroup.Segments...)
+			return i
+		}
+		if !keepNew {
+			hasMore = true
+			return -1
+		}
+		adGroupIndex[adGroup.ResourceName] = len(adGroups)
+		adGroups = append(adGroups, *adGroup)
+		return len(adGroups) - 1
+	}
+
+	// fetch_all from the first page streams every row in a single response. Ad groups
+	// past the cap are counted but not kept, since a stream cannot be resumed; the
+	// segments of kept ad groups are all kept, so none of them is incomplete.
+	if filters.FetchAll && filters.PageToken == "" {
+		streamRequest := &services.SearchGoogleAdsStreamRequest{CustomerId: filters.CustomerID, Query: query}
+		rowCount, cacheStatus, err := s.client.SearchStream(ctx, streamRequest, loginCustomerID, filters.BypassCache, func(row *services.GoogleAdsRow) error {
+			addRow(row, len(adGroups) < s.maxFetchRows)
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("searchadgroups: %w", err)
+		}
+
+		return Result{
+			AdGroups:          adGroups,
+			TotalResultsCount: rowCount,
+			HasMore:           hasMore,
+			Cache:             cacheStatus,
+		}, nil
 	}
 
-	// Validate customer ID format (should be numeric)
-	customerID = strings.TrimSpace(customerID)
-	if customerID == "" {
-		return "", fmt.Errorf("customer ID is required")
+	request := &services.SearchGoogleAdsRequest{
+		CustomerId: filters.CustomerID,
+		Query:      query,
+		PageToken:  filters.PageToken,
+		PageSize:   filters.PageSize,
 	}
 
-	// Remove any "customers/" prefix if present
-	customerID = strings.TrimPrefix(customerID, "customers/")
+	// Walk pages server-side when fetch_all resumes from a page token. The row cap is
+	// checked between pages so no row is dropped and the caller can resume from NextPageToken.
+	var lastPage []int
+	protoResp, cacheStatus, err := s.client.SearchPages(ctx, request, loginCustomerID, filters.BypassCache, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		lastPage = lastPage[:0]
+		for _, row := range page.Results {
+			if i := addRow(row, true); i >= 0 {
+				lastPage = append(lastPage, i)
+			}
+		}
+		return filters.FetchAll && len(adGroups) < s.maxFetchRows, nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("searchadgroups: %w", err)
+	}
 
-	version := strings.TrimPrefix(strings.TrimSpace(defaultAPIVersion), "/")
-	path := strings.TrimSuffix(baseURL.Path, "/")
-	path = fmt.Sprintf("%s/%s/customers/%s/googleAds:search", path, version, customerID)
-	baseURL.Path = path
+	// The segment rows of the ad groups on the last page may continue on the next one
+	nextPageToken := protoResp.GetNextPageToken()
+	if nextPageToken != "" && filters.SegmentBy != "" {
+		for _, i := range lastPage {
+			adGroups[i].Incomplete = true
+		}
+	}
 
-	return baseURL.String(), nil
+	return Result{
+		AdGroups:          adGroups,
+		NextPageToken:     nextPageToken,
+		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		HasMore:           nextPageToken != "",
+		Cache:             cacheStatus,
+	}, nil
 }
 
 func (s *Service) buildQuery(filters Filters) (string, error) {
@@ -140,6 +232,17 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		"metrics.ctr",
 		"metrics.average_cpc",
 		"metrics.cost_micros",
+		"metrics.conversions",
+		"metrics.conversions_value",
+		"metrics.interactions",
+	}
+
+	// Narrow the SELECT to the requested fields
+	fields = filters.Fields.Columns(requiredFields, fields)
+
+	// Add the segment field when a time series or breakdown is requested
+	if filters.SegmentBy != "" {
+		fields = append(fields, gaql.SegmentField(filters.SegmentBy))
 	}
 
 	qb := gaql.NewQueryBuilder("ad_group").Select(fields...)
@@ -197,10 +300,27 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		qb.WhereCampaignNames(filters.CampaignNames)
 	}
 
+	// Add metric threshold filters if present
+	thresholds := gaql.MetricThresholds{
+		MinCostMicros:  filters.MinCostMicros,
+		MinImpressions: filters.MinImpressions,
+		MinClicks:      filters.MinClicks,
+		MinConversions: filters.MinConversions,
+		MaxCPAMicros:   filters.MaxCPAMicros,
+	}
+	if err := qb.WhereMetricThresholds(thresholds); err != nil {
+		return "", fmt.Errorf("searchadgroups: building query: %w", err)
+	}
+
+	// Sort and cap server-side so "top N" requests only return N rows
+	if err := qb.OrderByMetric(filters.OrderBy, filters.Limit); err != nil {
+		return "", fmt.Errorf("searchadgroups: building query: %w", err)
+	}
+
 	return qb.Build(), nil
 }
 
-func (s *Service) mapRowToAdGroup(row *services.GoogleAdsRow) *AdGroup {
+func (s *Service) mapRowToAdGroup(row *services.GoogleAdsRow, segmentBy string) *AdGroup {
 	adGroupResource := row.GetAdGroup()
 	if adGroupResource == nil {
 		return nil
@@ -216,9 +336,10 @@ func (s *Service) mapRowToAdGroup(row *services.GoogleAdsRow) *AdGroup {
 		campaignResourceName = campaignResource.GetResourceName()
 	}
 
-	var clicks, impressions int64
+	var clicks, impressions, interactions int64
 	var ctr, averageCPC float64
 	var costMicros int64
+	var conversions, conversionsValue float64
 
 	if metricsResource != nil {
 		clicks = metricsResource.GetClicks()
@@ -226,9 +347,12 @@ func (s *Service) mapRowToAdGroup(row *services.GoogleAdsRow) *AdGroup {
 		ctr = metricsResource.GetCtr()
 		averageCPC = metricsResource.GetAverageCpc()
 		costMicros = metricsResource.GetCostMicros()
+		conversions = metricsResource.GetConversions()
+		conversionsValue = metricsResource.GetConversionsValue()
+		interactions = metricsResource.GetInteractions()
 	}
 
-	return &AdGroup{
+	adGroup := &AdGroup{
 		ID:                   fmt.Sprintf("%d", adGroupResource.GetId()),
 		ResourceName:         adGroupResource.GetResourceName(),
 		Name:                 adGroupResource.GetName(),
@@ -238,29 +362,31 @@ func (s *Service) mapRowToAdGroup(row *services.GoogleAdsRow) *AdGroup {
 		CampaignName:         campaignName,
 		CampaignResourceName: campaignResourceName,
 		Metrics: AdGroupMetrics{
-			Clicks:      clicks,
-			Impressions: impressions,
-			CTR:         ctr,
-			AverageCPC:  int64(averageCPC * 1000000), // Convert to micros
-			CostMicros:  costMicros,
+			Clicks:           clicks,
+			Impressions:      impressions,
+			CTR:              ctr,
+			AverageCPC:       int64(averageCPC), // Already in micros
+			CostMicros:       costMicros,
+			Conversions:      conversions,
+			ConversionsValue: conversionsValue,
+			ConversionRate:   kpi.ConversionRate(conversions, clicks),
+			Interactions:     interactions,
+			KPIs: kpi.Compute(kpi.Inputs{
+				Clicks:           clicks,
+				Impressions:      impressions,
+				Interactions:     interactions,
+				CostMicros:       costMicros,
+				Conversions:      conversions,
+				ConversionsValue: conversionsValue,
+			}),
 		},
 	}
-}
-
-// ProtoJSONRequest wraps a protobuf message to provide custom JSON marshaling
-type ProtoJSONRequest struct {
-	Message proto.Message
-}
 
-// MarshalJSON implements json.Marshaler interface to use protobuf JSON marshaling
-func (p ProtoJSONRequest) MarshalJSON() ([]byte, error) {
-	return protojson.MarshalOptions{EmitUnpopulated: false}.Marshal(p.Message)
-}
-
-func getHeaderValue(headers map[string][]string, key string) string {
-	if values, exists := headers[key]; exists && len(values) > 0 {
-		return values[0]
+	// Move the row metrics under its segment value when the query is segmented
+	if segmentBy != "" {
+		adGroup.Segments = []AdGroupSegment{{Value: gaql.SegmentValue(row, segmentBy), Metrics: adGroup.Metrics}}
+		adGroup.Metrics = AdGroupMetrics{}
 	}
-	return ""
-}
 
+	return adGroup
+}
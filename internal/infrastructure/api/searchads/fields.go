@@ -0,0 +1,63 @@
+package searchads
+
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
+// requiredFields identify an ad and are selected whatever fields are requested.
+var requiredFields = []string{
+	"ad_group_ad.ad.id",
+	"ad_group_ad.ad.resource_name",
+	"ad_group_ad.resource_name",
+}
+
+// Fields is the allowlist of output fields the caller can select, mapped to the GAQL
+// fields that populate them. Derived metrics list every field they are computed from.
+var Fields = projection.Allowlist{
+	"name":                   {"ad_group_ad.ad.name"},
+	"type":                   {"ad_group_ad.ad.type"},
+	"status":                 {"ad_group_ad.status"},
+	"final_urls":             {"ad_group_ad.ad.final_urls"},
+	"approval_status":        {"ad_group_ad.policy_summary.approval_status"},
+	"campaign_id":            {"campaign.id"},
+	"campaign_name":          {"campaign.name"},
+	"campaign_resource_name": {"campaign.resource_name"},
+	"ad_group_id":            {"ad_group.id"},
+	"ad_group_name":          {"ad_group.name"},
+	"ad_group_resource_name": {"ad_group.resource_name"},
+	"expanded_text_ad": {
+		"ad_group_ad.ad.type",
+		"ad_group_ad.ad.expanded_text_ad.headline_part1",
+		"ad_group_ad.ad.expanded_text_ad.headline_part2",
+		"ad_group_ad.ad.expanded_text_ad.headline_part3",
+		"ad_group_ad.ad.expanded_text_ad.description",
+		"ad_group_ad.ad.expanded_text_ad.description2",
+		"ad_group_ad.ad.expanded_text_ad.path1",
+		"ad_group_ad.ad.expanded_text_ad.path2",
+	},
+	"responsive_search_ad": {
+		"ad_group_ad.ad.type",
+		"ad_group_ad.ad.responsive_search_ad.headlines",
+		"ad_group_ad.ad.responsive_search_ad.descriptions",
+		"ad_group_ad.ad.responsive_search_ad.path1",
+		"ad_group_ad.ad.responsive_search_ad.path2",
+	},
+	"metrics.clicks":                                 {"metrics.clicks"},
+	"metrics.impressions":                            {"metrics.impressions"},
+	"metrics.ctr":                                    {"metrics.ctr"},
+	"metrics.average_cpc":                            {"metrics.average_cpc"},
+	"metrics.cost":                                   {"metrics.cost_micros"},
+	"metrics.conversions":                            {"metrics.conversions"},
+	"metrics.conversions_value":                      {"metrics.conversions_value"},
+	"metrics.cost_per_conversion":                    {"metrics.cost_per_conversion"},
+	"metrics.conversion_rate":                        {"metrics.conversions", "metrics.clicks"},
+	"metrics.all_conversions":                        {"metrics.all_conversions"},
+	"metrics.all_conversions_value":                  {"metrics.all_conversions_value"},
+	"metrics.all_conversions_from_interactions_rate": {"metrics.all_conversions_from_interactions_rate"},
+	"metrics.cost_per_all_conversions":               {"metrics.cost_per_all_conversions"},
+	"metrics.interactions":                           {"metrics.interactions"},
+	"metrics.engagement_rate":                        {"metrics.engagement_rate"},
+	"metrics.roas":                                   {"metrics.conversions_value", "metrics.cost_micros"},
+	"metrics.cpa":                                    {"metrics.cost_micros", "metrics.conversions"},
+	"metrics.cpm":                                    {"metrics.cost_micros", "metrics.impressions"},
+	"metrics.cost_per_interaction":                   {"metrics.cost_micros", "metrics.interactions"},
+	"metrics.value_per_click":                        {"metrics.conversions_value", "metrics.clicks"},
+}
@@ -1,15 +1,33 @@
 package searchads
 
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
 // Filters captures the parameters used to search for ads.
 type Filters struct {
-	CustomerID      string
-	CampaignIDs     []string
-	CampaignNames   []string
-	AdGroupIDs      []string
-	AdGroupNames    []string
-	Statuses        []string
-	AdTypes         []string
-	DateRangeStart  string
-	DateRangeEnd    string
+	CustomerID            string
+	LoginCustomerID       string
+	CampaignIDs           []string
+	CampaignNames         []string
+	AdGroupIDs            []string
+	AdGroupNames          []string
+	Statuses              []string
+	AdTypes               []string
+	MinCostMicros         int64
+	MinImpressions        int64
+	MinClicks             int64
+	MinConversions        float64
+	MaxCPAMicros          int64  // Cost per conversion ceiling; rows without conversions are excluded
+	OrderBy               string // Metric to sort by, optionally followed by ASC or DESC
+	Limit                 int
+	DateRangeStart        string
+	DateRangeEnd          string
+	SegmentBy             string
+	CompareTo             string
+	CompareDateRangeStart string
+	CompareDateRangeEnd   string
+	PageToken             string
+	PageSize              int32
+	FetchAll              bool
+	BypassCache           bool                 // Skips the search cache and refreshes it with the fetched results
+	Fields                projection.Selection // Output fields to select; the zero Selection selects them all
 }
-
@@ -1,24 +1,33 @@
 package searchads
 
+import (
+	"google-ads-mcp/internal/infrastructure/api/comparison"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+)
+
 // Ad represents a normalized Google Ads ad.
 type Ad struct {
-	ID                  string
-	ResourceName        string
-	Name                string
-	Type                string
-	Status              string
-	FinalURLs           []string
-	ApprovalStatus      string
-	CampaignID          string
-	CampaignName        string
+	ID                   string
+	ResourceName         string
+	Name                 string
+	Type                 string
+	Status               string
+	FinalURLs            []string
+	ApprovalStatus       string
+	CampaignID           string
+	CampaignName         string
 	CampaignResourceName string
-	AdGroupID           string
-	AdGroupName         string
+	AdGroupID            string
+	AdGroupName          string
 	AdGroupResourceName  string
-	ExpandedTextAd      *ExpandedTextAd
-	ResponsiveSearchAd  *ResponsiveSearchAd
-	CallOnlyAd          *CallOnlyAd
-	Metrics             AdMetrics
+	ExpandedTextAd       *ExpandedTextAd
+	ResponsiveSearchAd   *ResponsiveSearchAd
+	CallOnlyAd           *CallOnlyAd
+	Metrics              AdMetrics
+	Segments             []AdSegment   // Populated instead of Metrics when SegmentBy is set
+	Comparison           *AdComparison // Populated when a period comparison is requested
+	Incomplete           bool          // Set when more Segments of the ad may follow on the next page
 }
 
 // ExpandedTextAd represents fields specific to expanded text ads.
@@ -28,16 +37,16 @@ type ExpandedTextAd struct {
 	HeadlinePart3 string
 	Description   string
 	Description2  string
-	Path1          string
-	Path2          string
+	Path1         string
+	Path2         string
 }
 
 // ResponsiveSearchAd represents fields specific to responsive search ads.
 type ResponsiveSearchAd struct {
 	Headlines    []string
 	Descriptions []string
-	Path1         string
-	Path2         string
+	Path1        string
+	Path2        string
 }
 
 // CallOnlyAd represents fields specific to call-only ads.
@@ -51,6 +60,18 @@ type CallOnlyAd struct {
 	DisableCallConversion bool
 }
 
+// AdComparison holds the metrics of an ad in the comparison period.
+type AdComparison struct {
+	Presence        comparison.Presence
+	PreviousMetrics AdMetrics
+}
+
+// AdSegment holds the metrics of an ad for a single segment value (e.g. one date or device).
+type AdSegment struct {
+	Value   string
+	Metrics AdMetrics
+}
+
 // AdMetrics represents comprehensive metrics for an ad.
 type AdMetrics struct {
 	Clicks                             int64
@@ -72,13 +93,16 @@ type AdMetrics struct {
 	SearchRankLostImpressionShare      float64
 	VideoViews                         int64
 	VideoViewRate                      float64
-	AverageCPV                         int64 // in micros
+	AverageCPV                         int64    // in micros
+	KPIs                               kpi.KPIs // Derived ratios (ROAS, CPA, CPM, ...)
 }
 
 // Result aggregates the ads outcome along with pagination metadata.
 type Result struct {
-	Ads             []Ad
-	NextPageToken   string
+	Ads               []Ad
+	NextPageToken     string
 	TotalResultsCount int64
+	HasMore           bool               // Set when rows were left out: resume from NextPageToken, or narrow a fetch_all past the row cap
+	Cache             searchcache.Status // Whether the rows came from the search cache; empty when caching is disabled
+	ComparisonPeriod  *comparison.Period // Populated when a period comparison is requested
 }
-
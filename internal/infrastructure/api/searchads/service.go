@@ -3,125 +3,217 @@ package searchads
 import (
 	"context"
 	"fmt"
-	"net/url"
 	"strings"
 
+	"google-ads-mcp/internal/infrastructure/api/comparison"
 	"google-ads-mcp/internal/infrastructure/api/gaql"
-	"google-ads-mcp/internal/infrastructure/auth"
-	infrahttp "google-ads-mcp/internal/infrastructure/http"
-	"google-ads-mcp/internal/infrastructure/log"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
 
 	"github.com/shenzhencenter/google-ads-pb/services"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
-)
-
-const (
-	defaultBaseURL    = "https://googleads.googleapis.com"
-	defaultAPIVersion = "v22"
 )
 
 type Service struct {
-	client          *infrahttp.Client
-	logger          log.Logger
-	tokenManager    auth.TokenProvider
-	developerToken  string
-	loginCustomerID string
+	client        *searchcache.Client
+	loginResolver logincustomer.Resolver
+	maxFetchRows  int
 }
 
-func NewService(client *infrahttp.Client, logger log.Logger, tokenManager auth.TokenProvider, loginCustomerID, developerToken string) *Service {
+func NewService(client *searchcache.Client, loginResolver logincustomer.Resolver, maxFetchRows int) *Service {
 	return &Service{
-		client:          client,
-		logger:          logger,
-		tokenManager:    tokenManager,
-		developerToken:  developerToken,
-		loginCustomerID: loginCustomerID,
+		client:        client,
+		loginResolver: loginResolver,
+		maxFetchRows:  maxFetchRows,
 	}
 }
 
 func (s *Service) SearchAds(ctx context.Context, filters Filters) (Result, error) {
-	// Build endpoint URL with customer ID from filters
-	endpoint, err := s.buildEndpoint(filters.CustomerID)
-	if err != nil {
-		return Result{}, fmt.Errorf("searchads: invalid customer ID: %w", err)
+	request := comparison.Request{
+		CompareTo:             filters.CompareTo,
+		DateRangeStart:        filters.DateRangeStart,
+		DateRangeEnd:          filters.DateRangeEnd,
+		CompareDateRangeStart: filters.CompareDateRangeStart,
+		CompareDateRangeEnd:   filters.CompareDateRangeEnd,
 	}
-
-	query, err := s.buildQuery(filters)
-	if err != nil {
-		return Result{}, err
+	if request.Enabled() {
+		return s.compareAds(ctx, filters, request)
 	}
 
-	request := &services.SearchGoogleAdsRequest{
-		Query: query,
-	}
+	return s.searchAds(ctx, filters)
+}
 
-	accessToken, err := s.tokenManager.GetAccessToken(ctx)
+// compareAds runs the query for both periods and matches ads by resource name.
+// Ads with data in only one of the periods are still returned; a period with more
+// ads than the fetch_all cap fails the comparison.
+func (s *Service) compareAds(ctx context.Context, filters Filters, request comparison.Request) (Result, error) {
+	period, err := comparison.ResolvePeriod(request)
 	if err != nil {
-		return Result{}, fmt.Errorf("searchads: failed to get access token: %w", err)
+		return Result{}, fmt.Errorf("searchads: building query: %w", err)
+	}
+	if strings.TrimSpace(filters.SegmentBy) != "" {
+		return Result{}, fmt.Errorf("searchads: segment_by cannot be combined with a period comparison")
+	}
+	if filters.PageToken != "" {
+		return Result{}, fmt.Errorf("searchads: page_token cannot be used with a period comparison")
 	}
+	if filters.Limit > 0 {
+		return Result{}, fmt.Errorf("searchads: limit cannot be used with a period comparison")
+	}
+
+	// Both periods are walked in full so every ad can be matched
+	filters.FetchAll = true
 
-	headers := map[string]string{
-		"Content-Type":      "application/json",
-		"Authorization":     "Bearer " + accessToken,
-		"developer-token":   s.developerToken,
-		"login-customer-id":  s.loginCustomerID,
+	filters.DateRangeStart, filters.DateRangeEnd = period.CurrentStart, period.CurrentEnd
+	current, err := s.searchAds(ctx, filters)
+	if err != nil {
+		return Result{}, err
 	}
 
-	protoRequest := ProtoJSONRequest{Message: request}
-	response, err := s.client.Post(ctx, endpoint, protoRequest, headers)
+	filters.DateRangeStart, filters.DateRangeEnd = period.PreviousStart, period.PreviousEnd
+	previous, err := s.searchAds(ctx, filters)
 	if err != nil {
-		return Result{}, fmt.Errorf("searchads: executing request: %w", err)
+		return Result{}, err
 	}
 
-	if response.StatusCode >= 400 {
-		return Result{}, fmt.Errorf("searchads: api error status %d body %s", response.StatusCode, string(response.Body))
+	// A period cut off at the row cap would report its missing ads as present
+	// only in the other period
+	if current.HasMore || previous.HasMore {
+		return Result{}, fmt.Errorf("searchads: a period has more than %d ads, the MAX_FETCH_ALL_ROWS cap; narrow the search with filters to compare periods", s.maxFetchRows)
 	}
 
-	var protoResp services.SearchGoogleAdsResponse
-	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(response.Body, &protoResp); err != nil {
-		return Result{}, fmt.Errorf("searchads: unmarshal response: %w", err)
+	previousByName := make(map[string]Ad, len(previous.Ads))
+	for _, ad := range previous.Ads {
+		previousByName[ad.ResourceName] = ad
 	}
 
-	ads := make([]Ad, 0, len(protoResp.Results))
-	for _, row := range protoResp.Results {
-		ad := s.mapRowToAd(row)
-		if ad != nil {
-			ads = append(ads, *ad)
+	ads := make([]Ad, 0, len(current.Ads))
+	for _, ad := range current.Ads {
+		ad.Comparison = &AdComparison{Presence: comparison.PresenceCurrentOnly}
+		if prev, ok := previousByName[ad.ResourceName]; ok {
+			ad.Comparison = &AdComparison{Presence: comparison.PresenceBoth, PreviousMetrics: prev.Metrics}
+			delete(previousByName, ad.ResourceName)
 		}
+		ads = append(ads, ad)
 	}
 
-	s.logger.Info(ctx, "google ads search", map[string]string{
-		"request_id": getHeaderValue(response.Headers, "request-id"),
-	})
+	for _, ad := range previous.Ads {
+		if _, ok := previousByName[ad.ResourceName]; !ok {
+			continue
+		}
+		ad.Comparison = &AdComparison{Presence: comparison.PresencePreviousOnly, PreviousMetrics: ad.Metrics}
+		ad.Metrics = AdMetrics{}
+		ads = append(ads, ad)
+	}
 
 	return Result{
-		Ads:              ads,
-		NextPageToken:     protoResp.GetNextPageToken(),
-		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		Ads:               ads,
+		TotalResultsCount: int64(len(ads)),
+		ComparisonPeriod:  &period,
+		Cache:             current.Cache.Merge(previous.Cache),
 	}, nil
 }
 
-func (s *Service) buildEndpoint(customerID string) (string, error) {
-	baseURL, err := url.Parse(defaultBaseURL)
+func (s *Service) searchAds(ctx context.Context, filters Filters) (Result, error) {
+	var err error
+	filters.SegmentBy, err = gaql.NormalizeSegment(filters.SegmentBy)
 	if err != nil {
-		return "", err
+		return Result{}, fmt.Errorf("searchads: building query: %w", err)
 	}
 
-	// Validate customer ID format (should be numeric)
-	customerID = strings.TrimSpace(customerID)
-	if customerID == "" {
-		return "", fmt.Errorf("customer ID is required")
+	query, err := s.buildQuery(filters)
+	if err != nil {
+		return Result{}, err
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, filters.CustomerID, filters.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("searchads: resolving login customer ID: %w", err)
+	}
+
+	ads := make([]Ad, 0)
+	// Segmented queries return one row per entity and segment value; rows are
+	// grouped back under their entity so each one is emitted only once.
+	adIndex := make(map[string]int)
+	var hasMore bool
+	// addRow returns the index of the ad the row was grouped under, or -1
+	addRow := func(row *services.GoogleAdsRow, keepNew bool) int {
+		ad := s.mapRowToAd(row, filters.SegmentBy)
+		if ad == nil {
+			return -1
+		}
+		if i, ok := adIndex[ad.ResourceName]; ok {
+			ads[i].Segments = append(ads[i].Segments, ad.Segments...)
+			return i
+		}
+		if !keepNew {
+			hasMore = true
+			return -1
+		}
+		adIndex[ad.ResourceName] = len(ads)
+		ads = append(ads, *ad)
+		return len(ads) - 1
+	}
+
+	// fetch_all from the first page streams every row in a single response. Ads
+	// past the cap are counted but not kept, since a stream cannot be resumed; the
+	// segments of kept ads are all kept, so none of them is incomplete.
+	if filters.FetchAll && filters.PageToken == "" {
+		streamRequest := &services.SearchGoogleAdsStreamRequest{CustomerId: filters.CustomerID, Query: query}
+		rowCount, cacheStatus, err := s.client.SearchStream(ctx, streamRequest, loginCustomerID, filters.BypassCache, func(row *services.GoogleAdsRow) error {
+			addRow(row, len(ads) < s.maxFetchRows)
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("searchads: %w", err)
+		}
+
+		return Result{
+			Ads:               ads,
+			TotalResultsCount: rowCount,
+			HasMore:           hasMore,
+			Cache:             cacheStatus,
+		}, nil
 	}
 
-	// Remove any "customers/" prefix if present
-	customerID = strings.TrimPrefix(customerID, "customers/")
+	request := &services.SearchGoogleAdsRequest{
+		CustomerId: filters.CustomerID,
+		Query:      query,
+		PageToken:  filters.PageToken,
+		PageSize:   filters.PageSize,
+	}
+
+	// Walk pages server-side when fetch_all resumes from a page token. The row cap is
+	// checked between pages so no row is dropped and the caller can resume from NextPageToken.
+	var lastPage []int
+	protoResp, cacheStatus, err := s.client.SearchPages(ctx, request, loginCustomerID, filters.BypassCache, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		lastPage = lastPage[:0]
+		for _, row := range page.Results {
+			if i := addRow(row, true); i >= 0 {
+				lastPage = append(lastPage, i)
+			}
+		}
+		return filters.FetchAll && len(ads) < s.maxFetchRows, nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("searchads: %w", err)
+	}
 
-	version := strings.TrimPrefix(strings.TrimSpace(defaultAPIVersion), "/")
-	path := strings.TrimSuffix(baseURL.Path, "/")
-	path = fmt.Sprintf("%s/%s/customers/%s/googleAds:search", path, version, customerID)
-	baseURL.Path = path
+	// The segment rows of the ads on the last page may continue on the next one
+	nextPageToken := protoResp.GetNextPageToken()
+	if nextPageToken != "" && filters.SegmentBy != "" {
+		for _, i := range lastPage {
+			ads[i].Incomplete = true
+		}
+	}
 
-	return baseURL.String(), nil
+	return Result{
+		Ads:               ads,
+		NextPageToken:     nextPageToken,
+		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		HasMore:           nextPageToken != "",
+		Cache:             cacheStatus,
+	}, nil
 }
 
 func (s *Service) buildQuery(filters Filters) (string, error) {
@@ -194,6 +286,14 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		// "metrics.average_cpv",
 	}
 
+	// Narrow the SELECT to the requested fields
+	fields = filters.Fields.Columns(requiredFields, fields)
+
+	// Add the segment field when a time series or breakdown is requested
+	if filters.SegmentBy != "" {
+		fields = append(fields, gaql.SegmentField(filters.SegmentBy))
+	}
+
 	qb := gaql.NewQueryBuilder("ad_group_ad").Select(fields...)
 
 	// Default to LAST_7_DAYS if no date range is provided (for metrics)
@@ -256,10 +356,27 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		}
 	}
 
+	// Add metric threshold filters if present
+	thresholds := gaql.MetricThresholds{
+		MinCostMicros:  filters.MinCostMicros,
+		MinImpressions: filters.MinImpressions,
+		MinClicks:      filters.MinClicks,
+		MinConversions: filters.MinConversions,
+		MaxCPAMicros:   filters.MaxCPAMicros,
+	}
+	if err := qb.WhereMetricThresholds(thresholds); err != nil {
+		return "", fmt.Errorf("searchads: building query: %w", err)
+	}
+
+	// Sort and cap server-side so "top N" requests only return N rows
+	if err := qb.OrderByMetric(filters.OrderBy, filters.Limit); err != nil {
+		return "", fmt.Errorf("searchads: building query: %w", err)
+	}
+
 	return qb.Build(), nil
 }
 
-func (s *Service) mapRowToAd(row *services.GoogleAdsRow) *Ad {
+func (s *Service) mapRowToAd(row *services.GoogleAdsRow, segmentBy string) *Ad {
 	adGroupAdResource := row.GetAdGroupAd()
 	if adGroupAdResource == nil {
 		return nil
@@ -320,7 +437,7 @@ func (s *Service) mapRowToAd(row *services.GoogleAdsRow) *Ad {
 		ctr = metricsResource.GetCtr()
 		averageCPC = metricsResource.GetAverageCpc()
 		costMicros = metricsResource.GetCostMicros()
-		averageCPCInMicros = int64(averageCPC * 1000000)
+		averageCPCInMicros = int64(averageCPC) // Already in micros
 		conversions = metricsResource.GetConversions()
 		conversionsValue = metricsResource.GetConversionsValue()
 		costPerConversion = metricsResource.GetCostPerConversion()
@@ -335,26 +452,22 @@ func (s *Service) mapRowToAd(row *services.GoogleAdsRow) *Ad {
 		// They are only available at campaign/ad group level
 	}
 
-	// Calculate conversion rate
-	var conversionRate float64
-	if clicks > 0 {
-		conversionRate = (conversions / float64(clicks)) * 100.0
-	}
+	conversionRate := kpi.ConversionRate(conversions, clicks)
 
 	ad := &Ad{
-		ID:                  fmt.Sprintf("%d", adResource.GetId()),
-		ResourceName:        adGroupAdResource.GetResourceName(),
-		Name:                adResource.GetName(),
-		Type:                adType,
-		Status:              strings.ToLower(strings.TrimPrefix(adGroupAdResource.GetStatus().String(), "AD_GROUP_AD_STATUS_")),
-		FinalURLs:           finalURLs,
-		ApprovalStatus:      approvalStatus,
-		CampaignID:          campaignID,
-		CampaignName:        campaignName,
+		ID:                   fmt.Sprintf("%d", adResource.GetId()),
+		ResourceName:         adGroupAdResource.GetResourceName(),
+		Name:                 adResource.GetName(),
+		Type:                 adType,
+		Status:               strings.ToLower(strings.TrimPrefix(adGroupAdResource.GetStatus().String(), "AD_GROUP_AD_STATUS_")),
+		FinalURLs:            finalURLs,
+		ApprovalStatus:       approvalStatus,
+		CampaignID:           campaignID,
+		CampaignName:         campaignName,
 		CampaignResourceName: campaignResourceName,
-		AdGroupID:           adGroupID,
-		AdGroupName:         adGroupName,
-		AdGroupResourceName: adGroupResourceName,
+		AdGroupID:            adGroupID,
+		AdGroupName:          adGroupName,
+		AdGroupResourceName:  adGroupResourceName,
 		Metrics: AdMetrics{
 			Clicks:                             clicks,
 			Impressions:                        impressions,
@@ -373,13 +486,21 @@ func (s *Service) mapRowToAd(row *services.GoogleAdsRow) *Ad {
 			EngagementRate:                     engagementRate,
 			// SearchImpressionShare and SearchRankLostImpressionShare set to 0
 			// These metrics are not available at ad level (AD_GROUP_AD resource)
-			SearchImpressionShare:              0,
-			SearchRankLostImpressionShare:      0,
+			SearchImpressionShare:         0,
+			SearchRankLostImpressionShare: 0,
 			// VideoViews, VideoViewRate, AverageCPV set to 0 - not queried for compatibility
 			// with Search campaigns that don't support video metrics
-			VideoViews:                         0,
-			VideoViewRate:                      0,
-			AverageCPV:                         0,
+			VideoViews:    0,
+			VideoViewRate: 0,
+			AverageCPV:    0,
+			KPIs: kpi.Compute(kpi.Inputs{
+				Clicks:           clicks,
+				Impressions:      impressions,
+				Interactions:     interactions,
+				CostMicros:       costMicros,
+				Conversions:      conversions,
+				ConversionsValue: conversionsValue,
+			}),
 		},
 	}
 
@@ -440,23 +561,11 @@ func (s *Service) mapRowToAd(row *services.GoogleAdsRow) *Ad {
 		}
 	}
 
-	return ad
-}
-
-// ProtoJSONRequest wraps a protobuf message to provide custom JSON marshaling
-type ProtoJSONRequest struct {
-	Message proto.Message
-}
-
-// MarshalJSON implements json.Marshaler interface to use protobuf JSON marshaling
-func (p ProtoJSONRequest) MarshalJSON() ([]byte, error) {
-	return protojson.MarshalOptions{EmitUnpopulated: false}.Marshal(p.Message)
-}
-
-func getHeaderValue(headers map[string][]string, key string) string {
-	if values, exists := headers[key]; exists && len(values) > 0 {
-		return values[0]
+	// Move the row metrics under its segment value when the query is segmented
+	if segmentBy != "" {
+		ad.Segments = []AdSegment{{Value: gaql.SegmentValue(row, segmentBy), Metrics: ad.Metrics}}
+		ad.Metrics = AdMetrics{}
 	}
-	return ""
-}
 
+	return ad
+}
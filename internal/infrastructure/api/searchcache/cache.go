@@ -0,0 +1,286 @@
+package searchcache
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/log"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// maxEntryRows keeps large streams out of the cache; they are fetched every time.
+const maxEntryRows = 10000
+
+// Options configures a Cache.
+type Options struct {
+	MaxEntries int           // Least recently used entries are evicted past this count
+	TodayTTL   time.Duration // Lifetime of results whose date range includes today
+	PastTTL    time.Duration // Lifetime of results whose date range ended before today
+	Dir        string        // Keeps entries on disk across restarts; empty caches in memory only
+}
+
+// Cache is an LRU cache of search results. Entries expire after a TTL picked by
+// whether their date range includes today, since past metrics rarely change.
+type Cache struct {
+	options Options
+	logger  log.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Most recently used first
+}
+
+type entry struct {
+	key       string
+	storedAt  time.Time
+	expiresAt time.Time
+	response  *services.SearchGoogleAdsResponse
+}
+
+// diskEntry is the file format of a persisted entry.
+type diskEntry struct {
+	Key       string          `json:"key"`
+	StoredAt  time.Time       `json:"stored_at"`
+	ExpiresAt time.Time       `json:"expires_at"`
+	Response  json.RawMessage `json:"response"`
+}
+
+// New returns a cache holding up to options.MaxEntries results. With options.Dir
+// set, the unexpired entries saved there by a previous run are loaded.
+func New(options Options, logger log.Logger) (*Cache, error) {
+	c := &Cache{
+		options: options,
+		logger:  logger,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+
+	if options.Dir != "" {
+		if err := os.MkdirAll(options.Dir, 0o700); err != nil {
+			return nil, fmt.Errorf("searchcache: creating cache directory: %w", err)
+		}
+		if err := c.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// ttl returns the lifetime of the results of query. Results holding attributes
+// that can be mutated expire like today's metrics.
+func (c *Cache) ttl(query string) time.Duration {
+	if IncludesToday(query, c.now()) || SelectsAttributes(query) {
+		return c.options.TodayTTL
+	}
+	return c.options.PastTTL
+}
+
+// get returns the unexpired response stored under key. Callers must not modify it.
+func (c *Cache) get(key string) (*services.SearchGoogleAdsResponse, bool) {
+	c.mu.Lock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	e := element.Value.(*entry)
+	if !c.now().Before(e.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		c.mu.Unlock()
+		c.remove(key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	c.mu.Unlock()
+	return e.response, true
+}
+
+// put stores response under key for the TTL of query.
+func (c *Cache) put(ctx context.Context, key, query string, response *services.SearchGoogleAdsResponse) {
+	ttl := c.ttl(query)
+	if ttl <= 0 {
+		return
+	}
+
+	now := c.now()
+	e := &entry{key: key, storedAt: now, expiresAt: now.Add(ttl), response: response}
+	evicted := c.insert(e)
+
+	for _, key := range evicted {
+		c.remove(key)
+	}
+	if err := c.save(e); err != nil {
+		c.logger.Warn(ctx, "search cache entry not saved", map[string]string{"error": err.Error()})
+	}
+}
+
+// insert adds e as the most recently used entry and returns the keys it evicted.
+func (c *Cache) insert(e *entry) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[e.key]; ok {
+		element.Value = e
+		c.order.MoveToFront(element)
+		return nil
+	}
+	c.entries[e.key] = c.order.PushFront(e)
+
+	var evicted []string
+	for c.order.Len() > c.options.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		key := oldest.Value.(*entry).key
+		delete(c.entries, key)
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
+
+// EvictCustomer drops every entry of customerID, whatever its login customer, query
+// and the spelling of the customer ID it was searched with, so results changed by a
+// mutate are fetched again.
+func (c *Cache) EvictCustomer(customerID string) {
+	customerID = logincustomer.NormalizeCustomerID(customerID)
+	c.mu.Lock()
+	var evicted []string
+	for key, element := range c.entries {
+		if keyCustomerID(key) == customerID {
+			c.order.Remove(element)
+			delete(c.entries, key)
+			evicted = append(evicted, key)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, key := range evicted {
+		c.remove(key)
+	}
+}
+
+// load reads the entries saved in the cache directory, oldest first so the most
+// recent ones end up most recently used, and deletes the expired ones.
+func (c *Cache) load() error {
+	paths, err := filepath.Glob(filepath.Join(c.options.Dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("searchcache: listing cache directory: %w", err)
+	}
+
+	now := c.now()
+	var loaded []*entry
+	for _, path := range paths {
+		e, err := readEntry(path)
+		if err != nil || !now.Before(e.expiresAt) {
+			os.Remove(path)
+			continue
+		}
+		loaded = append(loaded, e)
+	}
+
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].storedAt.Before(loaded[j].storedAt) })
+	for _, e := range loaded {
+		for _, key := range c.insert(e) {
+			c.remove(key)
+		}
+	}
+
+	return nil
+}
+
+func readEntry(path string) (*entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var saved diskEntry
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	var response services.SearchGoogleAdsResponse
+	if err := protojson.Unmarshal(saved.Response, &response); err != nil {
+		return nil, err
+	}
+
+	return &entry{key: saved.Key, storedAt: saved.StoredAt, expiresAt: saved.ExpiresAt, response: &response}, nil
+}
+
+// save writes e to the cache directory, replacing the file atomically.
+func (c *Cache) save(e *entry) error {
+	if c.options.Dir == "" {
+		return nil
+	}
+
+	response, err := protojson.Marshal(e.response)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(diskEntry{Key: e.key, StoredAt: e.storedAt, ExpiresAt: e.expiresAt, Response: response})
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(c.options.Dir, "entry-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), c.path(e.key))
+}
+
+// remove deletes the file of an evicted or expired entry.
+func (c *Cache) remove(key string) {
+	if c.options.Dir != "" {
+		os.Remove(c.path(key))
+	}
+}
+
+// path names entry files by a hash of their key, which holds customer IDs and GAQL.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.options.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// cacheKey identifies a search by everything that changes its result. Customer IDs
+// are normalized so "123-456-7890" and "customers/1234567890" share an entry.
+func cacheKey(method, customerID, loginCustomerID, query, pageToken string, pageSize int32) string {
+	customerID = logincustomer.NormalizeCustomerID(customerID)
+	loginCustomerID = logincustomer.NormalizeCustomerID(loginCustomerID)
+	return strings.Join([]string{method, customerID, loginCustomerID, NormalizeQuery(query), pageToken, fmt.Sprint(pageSize)}, "\x00")
+}
+
+// keyCustomerID returns the customer ID a cacheKey was built with.
+func keyCustomerID(key string) string {
+	parts := strings.SplitN(key, "\x00", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
@@ -0,0 +1,138 @@
+package searchcache
+
+import (
+	"context"
+
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+)
+
+// Status reports whether a tool's results came from the cache.
+type Status string
+
+const (
+	StatusHit    Status = "hit"    // Every search was answered from the cache
+	StatusMiss   Status = "miss"   // At least one search reached the Google Ads API
+	StatusBypass Status = "bypass" // The caller skipped the cache
+)
+
+// Merge combines the statuses of several searches behind one result: a bypass
+// wins over a miss, which wins over a hit. The empty status, returned when caching
+// is disabled, is ignored.
+func (s Status) Merge(other Status) Status {
+	for _, status := range []Status{StatusBypass, StatusMiss, StatusHit} {
+		if s == status || other == status {
+			return status
+		}
+	}
+	return ""
+}
+
+// Client answers searches from the cache and sends the others through the Google
+// Ads client, storing their results. A nil cache disables caching.
+type Client struct {
+	client *googleads.Client
+	cache  *Cache
+}
+
+// NewClient returns a client searching through client. A non-nil cache also drops
+// the results of a customer after each successful mutate of that customer.
+func NewClient(client *googleads.Client, cache *Cache) *Client {
+	if cache != nil {
+		client.AfterMutate(cache.EvictCustomer)
+	}
+
+	return &Client{
+		client: client,
+		cache:  cache,
+	}
+}
+
+// SearchPages walks the pages of request like googleads.Client.SearchPages. Each page
+// is cached on its own, keyed by its page token and size. With bypass set the pages
+// are fetched from the API and the cache is refreshed with them.
+func (c *Client) SearchPages(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string, bypass bool, fn func(*services.SearchGoogleAdsResponse) (bool, error)) (*services.SearchGoogleAdsResponse, Status, error) {
+	if c.cache == nil {
+		page, err := c.client.SearchPages(ctx, request, loginCustomerID, fn)
+		return page, "", err
+	}
+
+	status := StatusHit
+	if bypass {
+		status = StatusBypass
+	}
+
+	request = proto.Clone(request).(*services.SearchGoogleAdsRequest)
+	for {
+		key := cacheKey("search", request.GetCustomerId(), loginCustomerID, request.GetQuery(), request.GetPageToken(), request.GetPageSize())
+
+		var page *services.SearchGoogleAdsResponse
+		ok := false
+		if !bypass {
+			page, ok = c.cache.get(key)
+		}
+		if !ok {
+			var err error
+			page, err = c.client.Search(ctx, request, loginCustomerID)
+			if err != nil {
+				return nil, status, err
+			}
+			c.cache.put(ctx, key, request.GetQuery(), page)
+			status = status.Merge(StatusMiss)
+		}
+
+		more, err := fn(page)
+		if err != nil {
+			return nil, status, err
+		}
+		if !more || page.GetNextPageToken() == "" {
+			return page, status, nil
+		}
+		request.PageToken = page.GetNextPageToken()
+	}
+}
+
+// SearchStream streams the rows of request like googleads.Client.SearchStream. A
+// completed stream of up to maxEntryRows rows is cached and replayed to fn on a hit.
+func (c *Client) SearchStream(ctx context.Context, request *services.SearchGoogleAdsStreamRequest, loginCustomerID string, bypass bool, fn func(*services.GoogleAdsRow) error) (int64, Status, error) {
+	if c.cache == nil {
+		rows, err := c.client.SearchStream(ctx, request, loginCustomerID, fn)
+		return rows, "", err
+	}
+
+	key := cacheKey("stream", request.GetCustomerId(), loginCustomerID, request.GetQuery(), "", 0)
+	if !bypass {
+		if cached, ok := c.cache.get(key); ok {
+			for _, row := range cached.GetResults() {
+				if err := fn(row); err != nil {
+					return 0, StatusHit, err
+				}
+			}
+			return cached.GetTotalResultsCount(), StatusHit, nil
+		}
+	}
+
+	status := StatusMiss
+	if bypass {
+		status = StatusBypass
+	}
+
+	var rows []*services.GoogleAdsRow
+	count, err := c.client.SearchStream(ctx, request, loginCustomerID, func(row *services.GoogleAdsRow) error {
+		if len(rows) < maxEntryRows+1 {
+			rows = append(rows, row)
+		}
+		return fn(row)
+	})
+	if err != nil {
+		return count, status, err
+	}
+
+	if len(rows) <= maxEntryRows {
+		c.cache.put(ctx, key, request.GetQuery(), &services.SearchGoogleAdsResponse{Results: rows, TotalResultsCount: count})
+	}
+
+	return count, status, nil
+}
@@ -0,0 +1,118 @@
+package searchcache
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	duringPattern  = regexp.MustCompile(`(?i)\bsegments\.date\s+DURING\s+(\w+)`)
+	betweenPattern = regexp.MustCompile(`(?i)\bsegments\.date\s+BETWEEN\s+'(\d{4}-\d{2}-\d{2})'\s+AND\s+'(\d{4}-\d{2}-\d{2})'`)
+	beforePattern  = regexp.MustCompile(`(?i)\bsegments\.date\s*(?:<=|<|=)\s*'(\d{4}-\d{2}-\d{2})'`)
+	selectPattern  = regexp.MustCompile(`(?is)^\s*SELECT\s+(.*?)\s+FROM\s`)
+)
+
+// dateRangeEnds returns the last day of the predefined GAQL date ranges that end
+// before today, given the account's today.
+var dateRangeEnds = map[string]func(today time.Time) time.Time{
+	"YESTERDAY":    func(today time.Time) time.Time { return today.AddDate(0, 0, -1) },
+	"LAST_7_DAYS":  func(today time.Time) time.Time { return today.AddDate(0, 0, -1) },
+	"LAST_14_DAYS": func(today time.Time) time.Time { return today.AddDate(0, 0, -1) },
+	"LAST_30_DAYS": func(today time.Time) time.Time { return today.AddDate(0, 0, -1) },
+	"LAST_MONTH": func(today time.Time) time.Time {
+		return today.AddDate(0, 0, -today.Day())
+	},
+	"LAST_WEEK_MON_SUN": func(today time.Time) time.Time {
+		return today.AddDate(0, 0, -daysSinceMonday(today)-1)
+	},
+	"LAST_WEEK_SUN_SAT": func(today time.Time) time.Time {
+		return today.AddDate(0, 0, -int(today.Weekday())-1)
+	},
+	"LAST_BUSINESS_WEEK": func(today time.Time) time.Time {
+		return today.AddDate(0, 0, -daysSinceMonday(today)-3)
+	},
+}
+
+func daysSinceMonday(day time.Time) int {
+	return (int(day.Weekday()) + 6) % 7
+}
+
+// NormalizeQuery collapses the whitespace of query outside string literals, so the
+// same query formatted differently shares a cache entry.
+func NormalizeQuery(query string) string {
+	var b strings.Builder
+	var quote rune
+	space := false
+	for _, r := range strings.TrimSpace(query) {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// IncludesToday reports whether the date range of query may cover today, so its
+// metrics are still changing. Queries without a segments.date condition cover every
+// day. The account time zone is unknown, so a range ending yesterday in UTC counts
+// as including today. Predefined DURING ranges follow the same rule, taking the
+// account's today to be at most a day ahead of UTC, so DURING YESTERDAY includes
+// today.
+func IncludesToday(query string, now time.Time) bool {
+	cutoff := now.UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	latestToday := now.UTC().AddDate(0, 0, 1)
+
+	for _, match := range duringPattern.FindAllStringSubmatch(query, -1) {
+		end, ok := dateRangeEnds[strings.ToUpper(match[1])]
+		if ok && end(latestToday).Format(time.DateOnly) < cutoff {
+			return false
+		}
+	}
+	for _, match := range betweenPattern.FindAllStringSubmatch(query, -1) {
+		if match[2] < cutoff {
+			return false
+		}
+	}
+	for _, match := range beforePattern.FindAllStringSubmatch(query, -1) {
+		if match[1] < cutoff {
+			return false
+		}
+	}
+	return true
+}
+
+// SelectsAttributes reports whether query selects a resource attribute, such as
+// campaign.status or campaign_budget.amount_micros, which can change at any time
+// unlike the metrics of a past day. Metrics, segments, IDs and resource names do not
+// count.
+func SelectsAttributes(query string) bool {
+	match := selectPattern.FindStringSubmatch(query)
+	if match == nil {
+		return true
+	}
+
+	for _, field := range strings.Split(match[1], ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if strings.HasPrefix(field, "metrics.") || strings.HasPrefix(field, "segments.") {
+			continue
+		}
+		if strings.HasSuffix(field, ".id") || strings.HasSuffix(field, ".resource_name") {
+			continue
+		}
+		return true
+	}
+	return false
+}
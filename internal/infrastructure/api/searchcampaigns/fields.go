@@ -0,0 +1,44 @@
+package searchcampaigns
+
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
+// requiredFields identify a campaign and are selected whatever fields are requested.
+// The currency code is needed to format and convert money amounts.
+var requiredFields = []string{
+	"campaign.id",
+	"campaign.resource_name",
+	"customer.currency_code",
+}
+
+// Fields is the allowlist of output fields the caller can select, mapped to the GAQL
+// fields that populate them. Derived metrics list every field they are computed from.
+var Fields = projection.Allowlist{
+	"name":                          {"campaign.name"},
+	"status":                        {"campaign.status"},
+	"advertising_channel_type":      {"campaign.advertising_channel_type"},
+	"bidding_strategy_type":         {"campaign.bidding_strategy_type"},
+	"budget_amount":                 {"campaign_budget.amount_micros"},
+	"optimization_score":            {"campaign.optimization_score"},
+	"metrics.clicks":                {"metrics.clicks"},
+	"metrics.impressions":           {"metrics.impressions"},
+	"metrics.ctr":                   {"metrics.ctr"},
+	"metrics.average_cpc":           {"metrics.average_cpc"},
+	"metrics.cost":                  {"metrics.cost_micros"},
+	"metrics.conversions":           {"metrics.conversions"},
+	"metrics.conversions_value":     {"metrics.conversions_value"},
+	"metrics.cost_per_conversion":   {"metrics.cost_per_conversion"},
+	"metrics.all_conversions":       {"metrics.all_conversions"},
+	"metrics.all_conversions_value": {"metrics.all_conversions_value"},
+	"metrics.all_conversions_from_interactions_rate": {"metrics.all_conversions_from_interactions_rate"},
+	"metrics.cost_per_all_conversions":               {"metrics.cost_per_all_conversions"},
+	"metrics.interactions":                           {"metrics.interactions"},
+	"metrics.engagement_rate":                        {"metrics.engagement_rate"},
+	"metrics.search_impression_share":                {"metrics.search_impression_share"},
+	"metrics.search_rank_lost_impression_share":      {"metrics.search_rank_lost_impression_share"},
+	"metrics.conversion_rate":                        {"metrics.conversions", "metrics.clicks"},
+	"metrics.roas":                                   {"metrics.conversions_value", "metrics.cost_micros"},
+	"metrics.cpa":                                    {"metrics.cost_micros", "metrics.conversions"},
+	"metrics.cpm":                                    {"metrics.cost_micros", "metrics.impressions"},
+	"metrics.cost_per_interaction":                   {"metrics.cost_micros", "metrics.interactions"},
+	"metrics.value_per_click":                        {"metrics.conversions_value", "metrics.clicks"},
+}
@@ -1,12 +1,32 @@
 package searchcampaigns
 
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
 // Filters captures the parameters used to search for campaigns.
 type Filters struct {
-	CustomerID      string
-	CampaignIDs     []string
-	CampaignNames   []string
-	Statuses        []string
-	DateRangeStart  string
-	DateRangeEnd    string
+	CustomerID            string
+	CustomerIDs           []string // Fans out across these accounts; the single value "all" expands to every client account
+	LoginCustomerID       string
+	CampaignIDs           []string
+	CampaignNames         []string
+	Statuses              []string
+	MinCostMicros         int64
+	MinImpressions        int64
+	MinClicks             int64
+	MinConversions        float64
+	MaxCPAMicros          int64  // Cost per conversion ceiling; rows without conversions are excluded
+	OrderBy               string // Metric to sort by, optionally followed by ASC or DESC
+	Limit                 int
+	DateRangeStart        string
+	DateRangeEnd          string
+	SegmentBy             string
+	CompareTo             string
+	CompareDateRangeStart string
+	CompareDateRangeEnd   string
+	PageToken             string
+	PageSize              int32
+	FetchAll              bool
+	BypassCache           bool                 // Skips the search cache and refreshes it with the fetched results
+	ReportingCurrency     string               // Converts multi-account results to this currency; empty uses the configured default
+	Fields                projection.Selection // Output fields to select; the zero Selection selects them all
 }
-
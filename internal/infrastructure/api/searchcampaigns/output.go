@@ -1,5 +1,13 @@
 package searchcampaigns
 
+import (
+	"google-ads-mcp/internal/infrastructure/api/comparison"
+	"google-ads-mcp/internal/infrastructure/api/fanout"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+	"google-ads-mcp/internal/infrastructure/fx"
+)
+
 // Campaign represents a normalized Google Ads campaign.
 type Campaign struct {
 	ID                     string
@@ -11,27 +19,53 @@ type Campaign struct {
 	BudgetAmountMicros     int64
 	OptimizationScore      float64
 	Metrics                CampaignMetrics
+	Segments               []CampaignSegment   // Populated instead of Metrics when SegmentBy is set
+	Comparison             *CampaignComparison // Populated when a period comparison is requested
+	Converted              *ConvertedAmounts   // Populated when a reporting currency is requested
+	Incomplete             bool                // Set when more Segments of the campaign may follow on the next page
+}
+
+// ConvertedAmounts holds the money metrics of a campaign in the reporting currency,
+// summed over its segments when the campaign is segmented.
+type ConvertedAmounts struct {
+	CostMicros        int64
+	ConversionsValue  float64
+	AverageCPC        int64   // Average cost per click in micros
+	CostPerConversion float64 // Cost per conversion in micros
+}
+
+// CampaignComparison holds the metrics of a campaign in the comparison period.
+type CampaignComparison struct {
+	Presence        comparison.Presence
+	PreviousMetrics CampaignMetrics
+}
+
+// CampaignSegment holds the metrics of a campaign for a single segment value (e.g. one date or device).
+type CampaignSegment struct {
+	Value   string
+	Metrics CampaignMetrics
 }
 
 // CampaignMetrics represents metrics for a campaign.
 type CampaignMetrics struct {
-	Clicks                             int64   // Total clicks
-	Impressions                        int64   // Total impressions
-	CTR                                float64 // Click-through rate
-	AverageCPC                         int64   // Average cost per click in micros
-	CostMicros                         int64   // Total cost in micros
-	Conversions                        float64 // Conversions
-	ConversionsValue                   float64 // Total conversion value
-	CostPerConversion                  float64 // Cost per conversion in currency units
-	ConversionRate                     float64 // Conversion rate (percentage)
-	AllConversions                     float64 // All conversions (including estimated)
-	AllConversionsValue                float64 // Total value of all conversions
-	AllConversionsFromInteractionsRate float64 // All conversions rate from interactions
-	CostPerAllConversions              float64 // Cost per all conversions in currency units
-	Interactions                       int64   // Total interactions (clicks + engagements)
-	EngagementRate                     float64 // Engagement rate (percentage)
-	SearchImpressionShare              float64 // Search impression share (percentage)
-	SearchRankLostImpressionShare      float64 // Search rank lost impression share (percentage)
+	Clicks                             int64    // Total clicks
+	Impressions                        int64    // Total impressions
+	CTR                                float64  // Click-through rate
+	AverageCPC                         int64    // Average cost per click in micros
+	CostMicros                         int64    // Total cost in micros
+	Conversions                        float64  // Conversions
+	ConversionsValue                   float64  // Total conversion value
+	CostPerConversion                  float64  // Cost per conversion in currency units
+	ConversionRate                     float64  // Conversion rate (percentage)
+	AllConversions                     float64  // All conversions (including estimated)
+	AllConversionsValue                float64  // Total value of all conversions
+	AllConversionsFromInteractionsRate float64  // All conversions rate from interactions
+	CostPerAllConversions              float64  // Cost per all conversions in currency units
+	Interactions                       int64    // Total interactions (clicks + engagements)
+	EngagementRate                     float64  // Engagement rate (percentage)
+	SearchImpressionShare              float64  // Search impression share (percentage)
+	SearchRankLostImpressionShare      float64  // Search rank lost impression share (percentage)
+	KPIs                               kpi.KPIs // Derived ratios (ROAS, CPA, CPM, ...)
 }
 
 // Result aggregates the campaigns outcome along with pagination metadata.
@@ -39,4 +73,41 @@ type Result struct {
 	Campaigns         []Campaign
 	NextPageToken     string
 	TotalResultsCount int64
+	HasMore           bool               // Set when rows were left out: resume from NextPageToken, or narrow a fetch_all past the row cap
+	Cache             searchcache.Status // Whether the rows came from the search cache; empty when caching is disabled
+	ComparisonPeriod  *comparison.Period // Populated when a period comparison is requested
+	CurrencyCode      string             // Currency of the account, empty when no row was returned
+	Rate              *fx.Rate           // Rate to the reporting currency, when one is requested
+	ConversionErr     error              // Set when the account could not be converted to the reporting currency
+}
+
+// MultiAccountResult aggregates a campaign search across several accounts.
+type MultiAccountResult struct {
+	Accounts          []fanout.Result[Result] // One entry per account, in request order; Err is set for failed accounts
+	Rollup            Rollup
+	ReportingCurrency string             // Empty when no conversion was requested
+	Cache             searchcache.Status // Merged over the succeeded accounts
+}
+
+// Rollup sums the campaign metrics of every account that succeeded. Metrics amounts
+// are added as reported, in each account's own currency; Converted holds the totals
+// in the reporting currency.
+type Rollup struct {
+	AccountCount   int
+	SucceededCount int
+	FailedCount    int
+	CampaignCount  int64
+	Metrics        CampaignMetrics  // Ratios are recomputed from the totals; share metrics are left at zero
+	Converted      *ConvertedRollup // Set when a reporting currency is requested
+}
+
+// ConvertedRollup sums the converted money metrics of the accounts that could be converted.
+type ConvertedRollup struct {
+	CurrencyCode        string
+	CostMicros          int64
+	ConversionsValue    float64
+	AverageCPC          int64   // Average cost per click in micros
+	CostPerConversion   float64 // Cost per conversion in micros
+	RateDates           []string
+	UnconvertedAccounts []string // Accounts left out of the converted totals
 }
@@ -3,125 +3,391 @@ package searchcampaigns
 import (
 	"context"
 	"fmt"
-	"net/url"
+	"slices"
 	"strings"
 
+	"google-ads-mcp/internal/infrastructure/api/comparison"
+	"google-ads-mcp/internal/infrastructure/api/fanout"
 	"google-ads-mcp/internal/infrastructure/api/gaql"
-	"google-ads-mcp/internal/infrastructure/auth"
-	infrahttp "google-ads-mcp/internal/infrastructure/http"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+	"google-ads-mcp/internal/infrastructure/fx"
 	"google-ads-mcp/internal/infrastructure/log"
 
 	"github.com/shenzhencenter/google-ads-pb/services"
-	"google.golang.org/protobuf/encoding/protojson"
-	"google.golang.org/protobuf/proto"
-)
-
-const (
-	defaultBaseURL    = "https://googleads.googleapis.com"
-	defaultAPIVersion = "v22"
 )
 
 type Service struct {
-	client          *infrahttp.Client
-	logger          log.Logger
-	tokenManager    auth.TokenProvider
-	developerToken  string
-	loginCustomerID string
+	client        *searchcache.Client
+	logger        log.Logger
+	loginResolver logincustomer.Resolver
+	accountLister logincustomer.AccountLister
+	fanOut        fanout.Options
+	converter     *fx.Converter
+	maxFetchRows  int
 }
 
-func NewService(client *infrahttp.Client, logger log.Logger, tokenManager auth.TokenProvider, loginCustomerID, developerToken string) *Service {
+func NewService(client *searchcache.Client, logger log.Logger, loginResolver logincustomer.Resolver, accountLister logincustomer.AccountLister, maxFetchRows int, fanOut fanout.Options, converter *fx.Converter) *Service {
 	return &Service{
-		client:          client,
-		logger:          logger,
-		tokenManager:    tokenManager,
-		developerToken:  developerToken,
-		loginCustomerID: loginCustomerID,
+		client:        client,
+		logger:        logger,
+		loginResolver: loginResolver,
+		accountLister: accountLister,
+		fanOut:        fanOut,
+		converter:     converter,
+		maxFetchRows:  maxFetchRows,
 	}
 }
 
 func (s *Service) SearchCampaigns(ctx context.Context, filters Filters) (Result, error) {
-	// Build endpoint URL with customer ID from filters
-	endpoint, err := s.buildEndpoint(filters.CustomerID)
+	request := comparison.Request{
+		CompareTo:             filters.CompareTo,
+		DateRangeStart:        filters.DateRangeStart,
+		DateRangeEnd:          filters.DateRangeEnd,
+		CompareDateRangeStart: filters.CompareDateRangeStart,
+		CompareDateRangeEnd:   filters.CompareDateRangeEnd,
+	}
+	if request.Enabled() {
+		return s.compareCampaigns(ctx, filters, request)
+	}
+
+	return s.searchCampaigns(ctx, filters)
+}
+
+// SearchCampaignsAcrossAccounts runs the campaign search for every account in
+// filters.CustomerIDs with a bounded worker pool. Failed accounts are reported in
+// the result instead of failing the whole search.
+func (s *Service) SearchCampaignsAcrossAccounts(ctx context.Context, filters Filters) (MultiAccountResult, error) {
+	if filters.PageToken != "" {
+		return MultiAccountResult{}, fmt.Errorf("searchcampaigns: page_token cannot be used with customer_ids")
+	}
+
+	reportingCurrency, err := s.converter.ReportingCurrency(filters.ReportingCurrency)
 	if err != nil {
-		return Result{}, fmt.Errorf("searchcampaigns: invalid customer ID: %w", err)
+		return MultiAccountResult{}, fmt.Errorf("searchcampaigns: %w", err)
 	}
 
-	query, err := s.buildQuery(filters)
+	customerIDs, err := fanout.ResolveCustomerIDs(ctx, s.accountLister, filters.CustomerIDs, filters.LoginCustomerID)
 	if err != nil {
-		return Result{}, err
+		return MultiAccountResult{}, fmt.Errorf("searchcampaigns: resolving customer IDs: %w", err)
 	}
 
-	request := &services.SearchGoogleAdsRequest{
-		Query: query,
+	results := fanout.Run(ctx, customerIDs, s.fanOut, func(ctx context.Context, customerID string) (Result, error) {
+		accountFilters := filters
+		accountFilters.CustomerID = customerID
+		accountFilters.CustomerIDs = nil
+		return s.SearchCampaigns(ctx, accountFilters)
+	})
+
+	rollup := Rollup{AccountCount: len(results)}
+	var cacheStatus searchcache.Status
+	var campaigns []Campaign
+	for _, result := range results {
+		if result.Err != nil {
+			rollup.FailedCount++
+			s.logger.Warn(ctx, "google ads campaign search failed for account", map[string]string{
+				"customer_id": result.CustomerID,
+				"error":       result.Err.Error(),
+			})
+			continue
+		}
+		rollup.SucceededCount++
+		rollup.CampaignCount += int64(len(result.Value.Campaigns))
+		cacheStatus = cacheStatus.Merge(result.Value.Cache)
+		campaigns = append(campaigns, result.Value.Campaigns...)
+	}
+	rollup.Metrics = sumCampaignMetrics(campaigns)
+
+	if reportingCurrency != "" {
+		rollup.Converted = s.convert(results, reportingCurrency, filters.DateRangeEnd)
+	}
+
+	return MultiAccountResult{
+		Accounts:          results,
+		Rollup:            rollup,
+		ReportingCurrency: reportingCurrency,
+		Cache:             cacheStatus,
+	}, nil
+}
+
+// convert fills in the converted amounts of every campaign of the succeeded accounts,
+// using the rate in effect on the last day of the report, and sums them. Without a
+// date range the latest rate is used.
+func (s *Service) convert(results []fanout.Result[Result], currency, date string) *ConvertedRollup {
+	rollup := &ConvertedRollup{CurrencyCode: currency}
+	var clicks int64
+	var conversions float64
+	for i := range results {
+		result := &results[i].Value
+		if results[i].Err != nil || len(result.Campaigns) == 0 {
+			continue
+		}
+
+		rate, err := s.converter.Rate(result.CurrencyCode, currency, date)
+		if err != nil {
+			result.ConversionErr = err
+			rollup.UnconvertedAccounts = append(rollup.UnconvertedAccounts, results[i].CustomerID)
+			continue
+		}
+		result.Rate = &rate
+
+		for j := range result.Campaigns {
+			total := sumCampaignMetrics(result.Campaigns[j : j+1])
+			converted := &ConvertedAmounts{
+				CostMicros:       rate.ConvertMicros(total.CostMicros),
+				ConversionsValue: rate.Convert(total.ConversionsValue),
+			}
+			converted.AverageCPC, converted.CostPerConversion = costRatios(converted.CostMicros, total.Clicks, total.Conversions)
+			result.Campaigns[j].Converted = converted
+
+			rollup.CostMicros += converted.CostMicros
+			rollup.ConversionsValue += converted.ConversionsValue
+			clicks += total.Clicks
+			conversions += total.Conversions
+		}
+		if rate.Date != "" && !slices.Contains(rollup.RateDates, rate.Date) {
+			rollup.RateDates = append(rollup.RateDates, rate.Date)
+		}
 	}
+	rollup.AverageCPC, rollup.CostPerConversion = costRatios(rollup.CostMicros, clicks, conversions)
+	slices.Sort(rollup.RateDates)
+
+	return rollup
+}
 
-	accessToken, err := s.tokenManager.GetAccessToken(ctx)
+// costRatios returns the average cost per click and the cost per conversion, in micros.
+func costRatios(costMicros, clicks int64, conversions float64) (int64, float64) {
+	return kpi.AverageCPC(costMicros, clicks), float64(kpi.CPA(costMicros, conversions))
+}
+
+// sumCampaignMetrics adds up the metrics of the campaigns, including their segments,
+// and recomputes the ratios from the totals.
+func sumCampaignMetrics(campaigns []Campaign) CampaignMetrics {
+	var total CampaignMetrics
+	add := func(m CampaignMetrics) {
+		total.Clicks += m.Clicks
+		total.Impressions += m.Impressions
+		total.CostMicros += m.CostMicros
+		total.Conversions += m.Conversions
+		total.ConversionsValue += m.ConversionsValue
+		total.AllConversions += m.AllConversions
+		total.AllConversionsValue += m.AllConversionsValue
+		total.Interactions += m.Interactions
+	}
+	for _, campaign := range campaigns {
+		add(campaign.Metrics)
+		for _, segment := range campaign.Segments {
+			add(segment.Metrics)
+		}
+	}
+
+	if total.Impressions > 0 {
+		total.CTR = float64(total.Clicks) / float64(total.Impressions)
+	}
+	total.ConversionRate = kpi.ConversionRate(total.Conversions, total.Clicks)
+	total.AverageCPC, total.CostPerConversion = costRatios(total.CostMicros, total.Clicks, total.Conversions)
+	if total.AllConversions > 0 {
+		total.CostPerAllConversions = float64(total.CostMicros) / total.AllConversions
+	}
+	if total.Interactions > 0 {
+		total.AllConversionsFromInteractionsRate = total.AllConversions / float64(total.Interactions)
+	}
+	total.KPIs = kpi.Compute(kpi.Inputs{
+		Clicks:           total.Clicks,
+		Impressions:      total.Impressions,
+		Interactions:     total.Interactions,
+		CostMicros:       total.CostMicros,
+		Conversions:      total.Conversions,
+		ConversionsValue: total.ConversionsValue,
+	})
+
+	return total
+}
+
+// compareCampaigns runs the query for both periods and matches campaigns by resource name.
+// Campaigns with data in only one of the periods are still returned; a period with
+// more campaigns than the fetch_all cap fails the comparison.
+func (s *Service) compareCampaigns(ctx context.Context, filters Filters, request comparison.Request) (Result, error) {
+	period, err := comparison.ResolvePeriod(request)
 	if err != nil {
-		return Result{}, fmt.Errorf("searchcampaigns: failed to get access token: %w", err)
+		return Result{}, fmt.Errorf("searchcampaigns: building query: %w", err)
+	}
+	if strings.TrimSpace(filters.SegmentBy) != "" {
+		return Result{}, fmt.Errorf("searchcampaigns: segment_by cannot be combined with a period comparison")
+	}
+	if filters.PageToken != "" {
+		return Result{}, fmt.Errorf("searchcampaigns: page_token cannot be used with a period comparison")
 	}
+	if filters.Limit > 0 {
+		return Result{}, fmt.Errorf("searchcampaigns: limit cannot be used with a period comparison")
+	}
+
+	// Both periods are walked in full so every campaign can be matched
+	filters.FetchAll = true
 
-	headers := map[string]string{
-		"Content-Type":      "application/json",
-		"Authorization":     "Bearer " + accessToken,
-		"developer-token":   s.developerToken,
-		"login-customer-id": s.loginCustomerID,
+	filters.DateRangeStart, filters.DateRangeEnd = period.CurrentStart, period.CurrentEnd
+	current, err := s.searchCampaigns(ctx, filters)
+	if err != nil {
+		return Result{}, err
 	}
 
-	protoRequest := ProtoJSONRequest{Message: request}
-	response, err := s.client.Post(ctx, endpoint, protoRequest, headers)
+	filters.DateRangeStart, filters.DateRangeEnd = period.PreviousStart, period.PreviousEnd
+	previous, err := s.searchCampaigns(ctx, filters)
 	if err != nil {
-		return Result{}, fmt.Errorf("searchcampaigns: executing request: %w", err)
+		return Result{}, err
 	}
 
-	if response.StatusCode >= 400 {
-		return Result{}, fmt.Errorf("searchcampaigns: api error status %d body %s", response.StatusCode, string(response.Body))
+	// A period cut off at the row cap would report its missing campaigns as present
+	// only in the other period
+	if current.HasMore || previous.HasMore {
+		return Result{}, fmt.Errorf("searchcampaigns: a period has more than %d campaigns, the MAX_FETCH_ALL_ROWS cap; narrow the search with filters to compare periods", s.maxFetchRows)
 	}
 
-	var protoResp services.SearchGoogleAdsResponse
-	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(response.Body, &protoResp); err != nil {
-		return Result{}, fmt.Errorf("searchcampaigns: unmarshal response: %w", err)
+	previousByName := make(map[string]Campaign, len(previous.Campaigns))
+	for _, campaign := range previous.Campaigns {
+		previousByName[campaign.ResourceName] = campaign
 	}
 
-	campaigns := make([]Campaign, 0, len(protoResp.Results))
-	for _, row := range protoResp.Results {
-		campaign := s.mapRowToCampaign(row)
-		if campaign != nil {
-			campaigns = append(campaigns, *campaign)
+	campaigns := make([]Campaign, 0, len(current.Campaigns))
+	for _, campaign := range current.Campaigns {
+		campaign.Comparison = &CampaignComparison{Presence: comparison.PresenceCurrentOnly}
+		if prev, ok := previousByName[campaign.ResourceName]; ok {
+			campaign.Comparison = &CampaignComparison{Presence: comparison.PresenceBoth, PreviousMetrics: prev.Metrics}
+			delete(previousByName, campaign.ResourceName)
 		}
+		campaigns = append(campaigns, campaign)
 	}
 
-	s.logger.Info(ctx, "google ads campaign search", map[string]string{
-		"request_id": getHeaderValue(response.Headers, "request-id"),
-	})
+	for _, campaign := range previous.Campaigns {
+		if _, ok := previousByName[campaign.ResourceName]; !ok {
+			continue
+		}
+		campaign.Comparison = &CampaignComparison{Presence: comparison.PresencePreviousOnly, PreviousMetrics: campaign.Metrics}
+		campaign.Metrics = CampaignMetrics{}
+		campaigns = append(campaigns, campaign)
+	}
+
+	currencyCode := current.CurrencyCode
+	if currencyCode == "" {
+		currencyCode = previous.CurrencyCode
+	}
 
 	return Result{
 		Campaigns:         campaigns,
-		NextPageToken:     protoResp.GetNextPageToken(),
-		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		TotalResultsCount: int64(len(campaigns)),
+		ComparisonPeriod:  &period,
+		Cache:             current.Cache.Merge(previous.Cache),
+		CurrencyCode:      currencyCode,
 	}, nil
 }
 
-func (s *Service) buildEndpoint(customerID string) (string, error) {
-	baseURL, err := url.Parse(defaultBaseURL)
+func (s *Service) searchCampaigns(ctx context.Context, filters Filters) (Result, error) {
+	var err error
+	filters.SegmentBy, err = gaql.NormalizeSegment(filters.SegmentBy)
 	if err != nil {
-		return "", err
+		return Result{}, fmt.Errorf("searchcampaigns: building query: %w", err)
 	}
 
-	// Validate customer ID format (should be numeric)
-	customerID = strings.TrimSpace(customerID)
-	if customerID == "" {
-		return "", fmt.Errorf("customer ID is required")
+	query, err := s.buildQuery(filters)
+	if err != nil {
+		return Result{}, err
 	}
 
-	// Remove any "customers/" prefix if present
-	customerID = strings.TrimPrefix(customerID, "customers/")
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, filters.CustomerID, filters.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("searchcampaigns: resolving login customer ID: %w", err)
+	}
 
-	version := strings.TrimPrefix(strings.TrimSpace(defaultAPIVersion), "/")
-	path := strings.TrimSuffix(baseURL.Path, "/")
-	path = fmt.Sprintf("%s/%s/customers/%s/googleAds:search", path, version, customerID)
-	baseURL.Path = path
+	campaigns := make([]Campaign, 0)
+	// Segmented queries return one row per entity and segment value; rows are
+	// grouped back under their entity so each one is emitted only once.
+	campaignIndex := make(map[string]int)
+	var currencyCode string
+	var hasMore bool
+	// addRow returns the index of the campaign the row was grouped under, or -1
+	addRow := func(row *services.GoogleAdsRow, keepNew bool) int {
+		if currencyCode == "" {
+			currencyCode = row.GetCustomer().GetCurrencyCode()
+		}
+		campaign := s.mapRowToCampaign(row, filters.SegmentBy)
+		if campaign == nil {
+			return -1
+		}
+		if i, ok := campaignIndex[campaign.ResourceName]; ok {
+			campaigns[i].Segments = append(campaigns[i].Segments, campaign.Segments...)
+			return i
+		}
+		if !keepNew {
+			hasMore = true
+			return -1
+		}
+		campaignIndex[campaign.ResourceName] = len(campaigns)
+		campaigns = append(campaigns, *campaign)
+		return len(campaigns) - 1
+	}
+
+	// fetch_all from the first page streams every row in a single response. Campaigns
+	// past the cap are counted but not kept, since a stream cannot be resumed; the
+	// segments of kept campaigns are all kept, so none of them is incomplete.
+	if filters.FetchAll && filters.PageToken == "" {
+		streamRequest := &services.SearchGoogleAdsStreamRequest{CustomerId: filters.CustomerID, Query: query}
+		rowCount, cacheStatus, err := s.client.SearchStream(ctx, streamRequest, loginCustomerID, filters.BypassCache, func(row *services.GoogleAdsRow) error {
+			addRow(row, len(campaigns) < s.maxFetchRows)
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("searchcampaigns: %w", err)
+		}
+
+		return Result{
+			Campaigns:         campaigns,
+			TotalResultsCount: rowCount,
+			HasMore:           hasMore,
+			Cache:             cacheStatus,
+			CurrencyCode:      currencyCode,
+		}, nil
+	}
 
-	return baseURL.String(), nil
+	request := &services.SearchGoogleAdsRequest{
+		CustomerId: filters.CustomerID,
+		Query:      query,
+		PageToken:  filters.PageToken,
+		PageSize:   filters.PageSize,
+	}
+
+	// Walk pages server-side when fetch_all resumes from a page token. The row cap is
+	// checked between pages so no row is dropped and the caller can resume from NextPageToken.
+	var lastPage []int
+	protoResp, cacheStatus, err := s.client.SearchPages(ctx, request, loginCustomerID, filters.BypassCache, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		lastPage = lastPage[:0]
+		for _, row := range page.Results {
+			if i := addRow(row, true); i >= 0 {
+				lastPage = append(lastPage, i)
+			}
+		}
+		return filters.FetchAll && len(campaigns) < s.maxFetchRows, nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("searchcampaigns: %w", err)
+	}
+
+	// The segment rows of the campaigns on the last page may continue on the next one
+	nextPageToken := protoResp.GetNextPageToken()
+	if nextPageToken != "" && filters.SegmentBy != "" {
+		for _, i := range lastPage {
+			campaigns[i].Incomplete = true
+		}
+	}
+
+	return Result{
+		Campaigns:         campaigns,
+		NextPageToken:     nextPageToken,
+		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		HasMore:           nextPageToken != "",
+		Cache:             cacheStatus,
+		CurrencyCode:      currencyCode,
+	}, nil
 }
 
 func (s *Service) buildQuery(filters Filters) (string, error) {
@@ -135,6 +401,7 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		"campaign.bidding_strategy_type",
 		"campaign_budget.amount_micros",
 		"campaign.optimization_score",
+		"customer.currency_code",
 		"metrics.clicks",
 		"metrics.impressions",
 		"metrics.ctr",
@@ -153,6 +420,14 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		"metrics.search_rank_lost_impression_share",
 	}
 
+	// Narrow the SELECT to the requested fields
+	fields = filters.Fields.Columns(requiredFields, fields)
+
+	// Add the segment field when a time series or breakdown is requested
+	if filters.SegmentBy != "" {
+		fields = append(fields, gaql.SegmentField(filters.SegmentBy))
+	}
+
 	qb := gaql.NewQueryBuilder("campaign").Select(fields...)
 
 	// Exclude REMOVED campaigns by default unless explicitly requested
@@ -191,12 +466,32 @@ func (s *Service) buildQuery(filters Filters) (string, error) {
 		if err := qb.WhereDateRange(filters.DateRangeStart, filters.DateRangeEnd); err != nil {
 			return "", fmt.Errorf("searchcampaigns: building query: %w", err)
 		}
+	} else if gaql.IsTimeSegment(filters.SegmentBy) {
+		// Time segments require a finite date range, default to LAST_7_DAYS
+		qb.Where("segments.date DURING LAST_7_DAYS")
+	}
+
+	// Add metric threshold filters if present
+	thresholds := gaql.MetricThresholds{
+		MinCostMicros:  filters.MinCostMicros,
+		MinImpressions: filters.MinImpressions,
+		MinClicks:      filters.MinClicks,
+		MinConversions: filters.MinConversions,
+		MaxCPAMicros:   filters.MaxCPAMicros,
+	}
+	if err := qb.WhereMetricThresholds(thresholds); err != nil {
+		return "", fmt.Errorf("searchcampaigns: building query: %w", err)
+	}
+
+	// Sort and cap server-side so "top N" requests only return N rows
+	if err := qb.OrderByMetric(filters.OrderBy, filters.Limit); err != nil {
+		return "", fmt.Errorf("searchcampaigns: building query: %w", err)
 	}
 
 	return qb.Build(), nil
 }
 
-func (s *Service) mapRowToCampaign(row *services.GoogleAdsRow) *Campaign {
+func (s *Service) mapRowToCampaign(row *services.GoogleAdsRow, segmentBy string) *Campaign {
 	campaignResource := row.GetCampaign()
 	if campaignResource == nil {
 		return nil
@@ -233,7 +528,6 @@ func (s *Service) mapRowToCampaign(row *services.GoogleAdsRow) *Campaign {
 		conversions = metricsResource.GetConversions()
 		conversionsValue = metricsResource.GetConversionsValue()
 		costPerConversion = metricsResource.GetCostPerConversion()
-		// ConversionRate is calculated: conversions / clicks * 100 (if clicks > 0)
 		allConversions = metricsResource.GetAllConversions()
 		allConversionsValue = metricsResource.GetAllConversionsValue()
 		allConversionsFromInteractionsRate = metricsResource.GetAllConversionsFromInteractionsRate()
@@ -244,13 +538,9 @@ func (s *Service) mapRowToCampaign(row *services.GoogleAdsRow) *Campaign {
 		searchRankLostImpressionShare = metricsResource.GetSearchRankLostImpressionShare()
 	}
 
-	// Calculate conversion rate from conversions and clicks
-	var conversionRate float64
-	if clicks > 0 {
-		conversionRate = (conversions / float64(clicks)) * 100.0
-	}
+	conversionRate := kpi.ConversionRate(conversions, clicks)
 
-	return &Campaign{
+	campaign := &Campaign{
 		ID:                     fmt.Sprintf("%d", campaignResource.GetId()),
 		ResourceName:           campaignResource.GetResourceName(),
 		Name:                   campaignResource.GetName(),
@@ -263,7 +553,7 @@ func (s *Service) mapRowToCampaign(row *services.GoogleAdsRow) *Campaign {
 			Clicks:                             clicks,
 			Impressions:                        impressions,
 			CTR:                                ctr,
-			AverageCPC:                         int64(averageCPC * 1000000), // Convert to micros
+			AverageCPC:                         int64(averageCPC), // Already in micros
 			CostMicros:                         costMicros,
 			Conversions:                        conversions,
 			ConversionsValue:                   conversionsValue,
@@ -277,23 +567,22 @@ func (s *Service) mapRowToCampaign(row *services.GoogleAdsRow) *Campaign {
 			EngagementRate:                     engagementRate,
 			SearchImpressionShare:              searchImpressionShare,
 			SearchRankLostImpressionShare:      searchRankLostImpressionShare,
+			KPIs: kpi.Compute(kpi.Inputs{
+				Clicks:           clicks,
+				Impressions:      impressions,
+				Interactions:     interactions,
+				CostMicros:       costMicros,
+				Conversions:      conversions,
+				ConversionsValue: conversionsValue,
+			}),
 		},
 	}
-}
-
-// ProtoJSONRequest wraps a protobuf message to provide custom JSON marshaling
-type ProtoJSONRequest struct {
-	Message proto.Message
-}
 
-// MarshalJSON implements json.Marshaler interface to use protobuf JSON marshaling
-func (p ProtoJSONRequest) MarshalJSON() ([]byte, error) {
-	return protojson.MarshalOptions{EmitUnpopulated: false}.Marshal(p.Message)
-}
-
-func getHeaderValue(headers map[string][]string, key string) string {
-	if values, exists := headers[key]; exists && len(values) > 0 {
-		return values[0]
+	// Move the row metrics under its segment value when the query is segmented
+	if segmentBy != "" {
+		campaign.Segments = []CampaignSegment{{Value: gaql.SegmentValue(row, segmentBy), Metrics: campaign.Metrics}}
+		campaign.Metrics = CampaignMetrics{}
 	}
-	return ""
+
+	return campaign
 }
@@ -0,0 +1,53 @@
+package searchkeywords
+
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
+// requiredFields identify a keyword and are selected whatever fields are requested.
+var requiredFields = []string{
+	"ad_group_criterion.criterion_id",
+	"ad_group_criterion.resource_name",
+}
+
+// Fields is the allowlist of output fields the caller can select, mapped to the GAQL
+// fields that populate them. Derived metrics list every field they are computed from.
+var Fields = projection.Allowlist{
+	"text":                   {"ad_group_criterion.keyword.text"},
+	"match_type":             {"ad_group_criterion.keyword.match_type"},
+	"status":                 {"ad_group_criterion.status"},
+	"cpc_bid":                {"ad_group_criterion.cpc_bid_micros"},
+	"effective_cpc_bid":      {"ad_group_criterion.effective_cpc_bid_micros"},
+	"campaign_id":            {"campaign.id"},
+	"campaign_name":          {"campaign.name"},
+	"campaign_resource_name": {"campaign.resource_name"},
+	"ad_group_id":            {"ad_group.id"},
+	"ad_group_name":          {"ad_group.name"},
+	"ad_group_resource_name": {"ad_group.resource_name"},
+	"quality_info": {
+		"ad_group_criterion.quality_info.quality_score",
+		"ad_group_criterion.quality_info.creative_quality_score",
+		"ad_group_criterion.quality_info.post_click_quality_score",
+		"ad_group_criterion.quality_info.search_predicted_ctr",
+	},
+	"metrics.clicks":                                 {"metrics.clicks"},
+	"metrics.impressions":                            {"metrics.impressions"},
+	"metrics.ctr":                                    {"metrics.ctr"},
+	"metrics.average_cpc":                            {"metrics.average_cpc"},
+	"metrics.cost":                                   {"metrics.cost_micros"},
+	"metrics.conversions":                            {"metrics.conversions"},
+	"metrics.conversions_value":                      {"metrics.conversions_value"},
+	"metrics.cost_per_conversion":                    {"metrics.cost_per_conversion"},
+	"metrics.all_conversions":                        {"metrics.all_conversions"},
+	"metrics.all_conversions_value":                  {"metrics.all_conversions_value"},
+	"metrics.all_conversions_from_interactions_rate": {"metrics.all_conversions_from_interactions_rate"},
+	"metrics.cost_per_all_conversions":               {"metrics.cost_per_all_conversions"},
+	"metrics.interactions":                           {"metrics.interactions"},
+	"metrics.engagement_rate":                        {"metrics.engagement_rate"},
+	"metrics.search_impression_share":                {"metrics.search_impression_share"},
+	"metrics.search_rank_lost_impression_share":      {"metrics.search_rank_lost_impression_share"},
+	"metrics.conversion_rate":                        {"metrics.conversions", "metrics.clicks"},
+	"metrics.roas":                                   {"metrics.conversions_value", "metrics.cost_micros"},
+	"metrics.cpa":                                    {"metrics.cost_micros", "metrics.conversions"},
+	"metrics.cpm":                                    {"metrics.cost_micros", "metrics.impressions"},
+	"metrics.cost_per_interaction":                   {"metrics.cost_micros", "metrics.interactions"},
+	"metrics.value_per_click":                        {"metrics.conversions_value", "metrics.clicks"},
+}
@@ -0,0 +1,63 @@
+package searchkeywords
+
+import (
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+)
+
+// Keyword represents a normalized Google Ads keyword (a keyword ad group criterion).
+type Keyword struct {
+	CriterionID           string
+	ResourceName          string
+	Text                  string
+	MatchType             string
+	Status                string
+	CPCBidMicros          int64
+	EffectiveCPCBidMicros int64
+	CampaignID            string
+	CampaignName          string
+	CampaignResourceName  string
+	AdGroupID             string
+	AdGroupName           string
+	AdGroupResourceName   string
+	QualityInfo           QualityInfo
+	Metrics               KeywordMetrics
+}
+
+// QualityInfo represents the quality score and its components for a keyword.
+type QualityInfo struct {
+	QualityScore          int32  // Quality score from 1 to 10, 0 when not available
+	CreativeQualityScore  string // Ad relevance bucket
+	PostClickQualityScore string // Landing page experience bucket
+	SearchPredictedCTR    string // Expected click-through rate bucket
+}
+
+// KeywordMetrics represents metrics for a keyword.
+type KeywordMetrics struct {
+	Clicks                             int64    // Total clicks
+	Impressions                        int64    // Total impressions
+	CTR                                float64  // Click-through rate
+	AverageCPC                         int64    // Average cost per click in micros
+	CostMicros                         int64    // Total cost in micros
+	Conversions                        float64  // Conversions
+	ConversionsValue                   float64  // Total conversion value
+	CostPerConversion                  float64  // Cost per conversion in currency units
+	ConversionRate                     float64  // Conversion rate (percentage)
+	AllConversions                     float64  // All conversions (including estimated)
+	AllConversionsValue                float64  // Total value of all conversions
+	AllConversionsFromInteractionsRate float64  // All conversions rate from interactions
+	CostPerAllConversions              float64  // Cost per all conversions in currency units
+	Interactions                       int64    // Total interactions (clicks + engagements)
+	EngagementRate                     float64  // Engagement rate (percentage)
+	SearchImpressionShare              float64  // Search impression share (percentage)
+	SearchRankLostImpressionShare      float64  // Search rank lost impression share (percentage)
+	KPIs                               kpi.KPIs // Derived ratios (ROAS, CPA, CPM, ...)
+}
+
+// Result aggregates the keywords outcome along with pagination metadata.
+type Result struct {
+	Keywords          []Keyword
+	NextPageToken     string
+	TotalResultsCount int64
+	Cache             searchcache.Status // Whether the rows came from the search cache; empty when caching is disabled
+}
@@ -0,0 +1,334 @@
+package searchkeywords
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+)
+
+type Service struct {
+	client        *searchcache.Client
+	loginResolver logincustomer.Resolver
+	maxFetchRows  int
+}
+
+func NewService(client *searchcache.Client, loginResolver logincustomer.Resolver, maxFetchRows int) *Service {
+	return &Service{
+		client:        client,
+		loginResolver: loginResolver,
+		maxFetchRows:  maxFetchRows,
+	}
+}
+
+func (s *Service) SearchKeywords(ctx context.Context, filters Filters) (Result, error) {
+	query, err := s.buildQuery(filters)
+	if err != nil {
+		return Result{}, err
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, filters.CustomerID, filters.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("searchkeywords: resolving login customer ID: %w", err)
+	}
+
+	keywords := make([]Keyword, 0)
+
+	// fetch_all from the first page streams every row in a single response. Keywords
+	// past the cap are counted but not kept, since a stream cannot be resumed.
+	if filters.FetchAll && filters.PageToken == "" {
+		streamRequest := &services.SearchGoogleAdsStreamRequest{CustomerId: filters.CustomerID, Query: query}
+		rowCount, cacheStatus, err := s.client.SearchStream(ctx, streamRequest, loginCustomerID, filters.BypassCache, func(row *services.GoogleAdsRow) error {
+			if len(keywords) >= s.maxFetchRows {
+				return nil
+			}
+			if keyword := s.mapRowToKeyword(row); keyword != nil {
+				keywords = append(keywords, *keyword)
+			}
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("searchkeywords: %w", err)
+		}
+
+		return Result{
+			Keywords:          keywords,
+			TotalResultsCount: rowCount,
+			Cache:             cacheStatus,
+		}, nil
+	}
+
+	request := &services.SearchGoogleAdsRequest{
+		CustomerId: filters.CustomerID,
+		Query:      query,
+		PageToken:  filters.PageToken,
+		PageSize:   filters.PageSize,
+	}
+
+	// Walk pages server-side when fetch_all resumes from a page token. The row cap is
+	// checked between pages so no row is dropped and the caller can resume from NextPageToken.
+	protoResp, cacheStatus, err := s.client.SearchPages(ctx, request, loginCustomerID, filters.BypassCache, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.Results {
+			keyword := s.mapRowToKeyword(row)
+			if keyword != nil {
+				keywords = append(keywords, *keyword)
+			}
+		}
+		return filters.FetchAll && len(keywords) < s.maxFetchRows, nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("searchkeywords: %w", err)
+	}
+
+	return Result{
+		Keywords:          keywords,
+		NextPageToken:     protoResp.GetNextPageToken(),
+		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		Cache:             cacheStatus,
+	}, nil
+}
+
+func (s *Service) buildQuery(filters Filters) (string, error) {
+	// Build SELECT clause with keyword criterion fields, quality info, context and metrics
+	fields := []string{
+		"ad_group_criterion.criterion_id",
+		"ad_group_criterion.resource_name",
+		"ad_group_criterion.keyword.text",
+		"ad_group_criterion.keyword.match_type",
+		"ad_group_criterion.status",
+		"ad_group_criterion.cpc_bid_micros",
+		"ad_group_criterion.effective_cpc_bid_micros",
+		"ad_group_criterion.quality_info.quality_score",
+		"ad_group_criterion.quality_info.creative_quality_score",
+		"ad_group_criterion.quality_info.post_click_quality_score",
+		"ad_group_criterion.quality_info.search_predicted_ctr",
+		"campaign.id",
+		"campaign.name",
+		"campaign.resource_name",
+		"ad_group.id",
+		"ad_group.name",
+		"ad_group.resource_name",
+		"metrics.clicks",
+		"metrics.impressions",
+		"metrics.ctr",
+		"metrics.average_cpc",
+		"metrics.cost_micros",
+		"metrics.conversions",
+		"metrics.conversions_value",
+		"metrics.cost_per_conversion",
+		"metrics.all_conversions",
+		"metrics.all_conversions_value",
+		"metrics.all_conversions_from_interactions_rate",
+		"metrics.cost_per_all_conversions",
+		"metrics.interactions",
+		"metrics.engagement_rate",
+		"metrics.search_impression_share",
+		"metrics.search_rank_lost_impression_share",
+	}
+
+	// Narrow the SELECT to the requested fields
+	fields = filters.Fields.Columns(requiredFields, fields)
+
+	qb := gaql.NewQueryBuilder("keyword_view").Select(fields...)
+
+	// Default to LAST_7_DAYS if no date range is provided
+	hasDateRange := filters.DateRangeStart != "" || filters.DateRangeEnd != ""
+	if !hasDateRange {
+		qb.Where("segments.date DURING LAST_7_DAYS")
+	} else {
+		if err := qb.WhereDateRange(filters.DateRangeStart, filters.DateRangeEnd); err != nil {
+			return "", fmt.Errorf("searchkeywords: building query: %w", err)
+		}
+	}
+
+	// Exclude REMOVED keywords by default unless explicitly requested
+	hasRemoved := false
+	for _, status := range filters.Statuses {
+		if strings.ToUpper(strings.TrimSpace(status)) == "REMOVED" {
+			hasRemoved = true
+			break
+		}
+	}
+	if !hasRemoved {
+		qb.Where("ad_group_criterion.status != REMOVED")
+	}
+
+	// Add campaign ID filters if present
+	if len(filters.CampaignIDs) > 0 {
+		if err := qb.WhereCampaignIDs(filters.CampaignIDs); err != nil {
+			return "", fmt.Errorf("searchkeywords: building query: %w", err)
+		}
+	}
+
+	// Add campaign name filters if present
+	if len(filters.CampaignNames) > 0 {
+		qb.WhereCampaignNames(filters.CampaignNames)
+	}
+
+	// Add ad group ID filters if present
+	if len(filters.AdGroupIDs) > 0 {
+		if err := qb.WhereAdGroupIDs(filters.AdGroupIDs); err != nil {
+			return "", fmt.Errorf("searchkeywords: building query: %w", err)
+		}
+	}
+
+	// Add ad group name filters if present
+	if len(filters.AdGroupNames) > 0 {
+		qb.WhereAdGroupNames(filters.AdGroupNames)
+	}
+
+	// Add status filters if present
+	if len(filters.Statuses) > 0 {
+		if err := qb.WhereAdGroupCriterionStatus(filters.Statuses); err != nil {
+			return "", fmt.Errorf("searchkeywords: building query: %w", err)
+		}
+	}
+
+	// Add match type filters if present
+	if len(filters.MatchTypes) > 0 {
+		if err := qb.WhereKeywordMatchTypes(filters.MatchTypes); err != nil {
+			return "", fmt.Errorf("searchkeywords: building query: %w", err)
+		}
+	}
+
+	// Add metric threshold filters if present
+	thresholds := gaql.MetricThresholds{
+		MinCostMicros:  filters.MinCostMicros,
+		MinImpressions: filters.MinImpressions,
+		MinClicks:      filters.MinClicks,
+		MinConversions: filters.MinConversions,
+		MaxCPAMicros:   filters.MaxCPAMicros,
+	}
+	if err := qb.WhereMetricThresholds(thresholds); err != nil {
+		return "", fmt.Errorf("searchkeywords: building query: %w", err)
+	}
+
+	// Sort and cap server-side so "top N" requests only return N rows
+	if err := qb.OrderByMetric(filters.OrderBy, filters.Limit); err != nil {
+		return "", fmt.Errorf("searchkeywords: building query: %w", err)
+	}
+
+	return qb.Build(), nil
+}
+
+func (s *Service) mapRowToKeyword(row *services.GoogleAdsRow) *Keyword {
+	criterionResource := row.GetAdGroupCriterion()
+	if criterionResource == nil {
+		return nil
+	}
+
+	campaignResource := row.GetCampaign()
+	adGroupResource := row.GetAdGroup()
+	metricsResource := row.GetMetrics()
+	keywordResource := criterionResource.GetKeyword()
+	qualityInfoResource := criterionResource.GetQualityInfo()
+
+	var campaignID, campaignName, campaignResourceName string
+	if campaignResource != nil {
+		campaignID = fmt.Sprintf("%d", campaignResource.GetId())
+		campaignName = campaignResource.GetName()
+		campaignResourceName = campaignResource.GetResourceName()
+	}
+
+	var adGroupID, adGroupName, adGroupResourceName string
+	if adGroupResource != nil {
+		adGroupID = fmt.Sprintf("%d", adGroupResource.GetId())
+		adGroupName = adGroupResource.GetName()
+		adGroupResourceName = adGroupResource.GetResourceName()
+	}
+
+	var text, matchType string
+	if keywordResource != nil {
+		text = keywordResource.GetText()
+		matchType = strings.ToLower(keywordResource.GetMatchType().String())
+	}
+
+	var qualityInfo QualityInfo
+	if qualityInfoResource != nil {
+		qualityInfo = QualityInfo{
+			QualityScore:          qualityInfoResource.GetQualityScore(),
+			CreativeQualityScore:  strings.ToLower(qualityInfoResource.GetCreativeQualityScore().String()),
+			PostClickQualityScore: strings.ToLower(qualityInfoResource.GetPostClickQualityScore().String()),
+			SearchPredictedCTR:    strings.ToLower(qualityInfoResource.GetSearchPredictedCtr().String()),
+		}
+	}
+
+	var clicks, impressions, interactions int64
+	var ctr, averageCPC float64
+	var costMicros int64
+	var conversions, conversionsValue, costPerConversion float64
+	var allConversions, allConversionsValue, allConversionsFromInteractionsRate float64
+	var costPerAllConversions float64
+	var engagementRate, searchImpressionShare, searchRankLostImpressionShare float64
+
+	if metricsResource != nil {
+		clicks = metricsResource.GetClicks()
+		impressions = metricsResource.GetImpressions()
+		ctr = metricsResource.GetCtr()
+		averageCPC = metricsResource.GetAverageCpc()
+		costMicros = metricsResource.GetCostMicros()
+		conversions = metricsResource.GetConversions()
+		conversionsValue = metricsResource.GetConversionsValue()
+		costPerConversion = metricsResource.GetCostPerConversion()
+		allConversions = metricsResource.GetAllConversions()
+		allConversionsValue = metricsResource.GetAllConversionsValue()
+		allConversionsFromInteractionsRate = metricsResource.GetAllConversionsFromInteractionsRate()
+		costPerAllConversions = metricsResource.GetCostPerAllConversions()
+		interactions = metricsResource.GetInteractions()
+		engagementRate = metricsResource.GetEngagementRate()
+		searchImpressionShare = metricsResource.GetSearchImpressionShare()
+		searchRankLostImpressionShare = metricsResource.GetSearchRankLostImpressionShare()
+	}
+
+	conversionRate := kpi.ConversionRate(conversions, clicks)
+
+	return &Keyword{
+		CriterionID:           fmt.Sprintf("%d", criterionResource.GetCriterionId()),
+		ResourceName:          criterionResource.GetResourceName(),
+		Text:                  text,
+		MatchType:             matchType,
+		Status:                strings.ToLower(criterionResource.GetStatus().String()),
+		CPCBidMicros:          criterionResource.GetCpcBidMicros(),
+		EffectiveCPCBidMicros: criterionResource.GetEffectiveCpcBidMicros(),
+		CampaignID:            campaignID,
+		CampaignName:          campaignName,
+		CampaignResourceName:  campaignResourceName,
+		AdGroupID:             adGroupID,
+		AdGroupName:           adGroupName,
+		AdGroupResourceName:   adGroupResourceName,
+		QualityInfo:           qualityInfo,
+		Metrics: KeywordMetrics{
+			Clicks:                             clicks,
+			Impressions:                        impressions,
+			CTR:                                ctr,
+			AverageCPC:                         int64(averageCPC), // Already in micros
+			CostMicros:                         costMicros,
+			Conversions:                        conversions,
+			ConversionsValue:                   conversionsValue,
+			CostPerConversion:                  costPerConversion,
+			ConversionRate:                     conversionRate,
+			AllConversions:                     allConversions,
+			AllConversionsValue:                allConversionsValue,
+			AllConversionsFromInteractionsRate: allConversionsFromInteractionsRate,
+			CostPerAllConversions:              costPerAllConversions,
+			Interactions:                       interactions,
+			EngagementRate:                     engagementRate,
+			SearchImpressionShare:              searchImpressionShare,
+			SearchRankLostImpressionShare:      searchRankLostImpressionShare,
+			KPIs: kpi.Compute(kpi.Inputs{
+				Clicks:           clicks,
+				Impressions:      impressions,
+				Interactions:     interactions,
+				CostMicros:       costMicros,
+				Conversions:      conversions,
+				ConversionsValue: conversionsValue,
+			}),
+		},
+	}
+}
@@ -0,0 +1,46 @@
+package searchsearchterms
+
+import "google-ads-mcp/internal/infrastructure/api/projection"
+
+// requiredFields identify a search term row and are selected whatever fields are
+// requested. The keyword segments stay selected because they split the rows per
+// triggering keyword.
+var requiredFields = []string{
+	"search_term_view.search_term",
+	"search_term_view.resource_name",
+	"segments.keyword.info.text",
+	"segments.keyword.info.match_type",
+	"segments.keyword.ad_group_criterion",
+}
+
+// Fields is the allowlist of output fields the caller can select, mapped to the GAQL
+// fields that populate them. Derived metrics list every field they are computed from.
+var Fields = projection.Allowlist{
+	"status":                        {"search_term_view.status"},
+	"keyword_text":                  {"segments.keyword.info.text"},
+	"keyword_match_type":            {"segments.keyword.info.match_type"},
+	"keyword_resource_name":         {"segments.keyword.ad_group_criterion"},
+	"campaign_id":                   {"campaign.id"},
+	"campaign_name":                 {"campaign.name"},
+	"campaign_resource_name":        {"campaign.resource_name"},
+	"ad_group_id":                   {"ad_group.id"},
+	"ad_group_name":                 {"ad_group.name"},
+	"ad_group_resource_name":        {"ad_group.resource_name"},
+	"metrics.clicks":                {"metrics.clicks"},
+	"metrics.impressions":           {"metrics.impressions"},
+	"metrics.ctr":                   {"metrics.ctr"},
+	"metrics.average_cpc":           {"metrics.average_cpc"},
+	"metrics.cost":                  {"metrics.cost_micros"},
+	"metrics.conversions":           {"metrics.conversions"},
+	"metrics.conversions_value":     {"metrics.conversions_value"},
+	"metrics.cost_per_conversion":   {"metrics.cost_per_conversion"},
+	"metrics.all_conversions":       {"metrics.all_conversions"},
+	"metrics.all_conversions_value": {"metrics.all_conversions_value"},
+	"metrics.interactions":          {"metrics.interactions"},
+	"metrics.conversion_rate":       {"metrics.conversions", "metrics.clicks"},
+	"metrics.roas":                  {"metrics.conversions_value", "metrics.cost_micros"},
+	"metrics.cpa":                   {"metrics.cost_micros", "metrics.conversions"},
+	"metrics.cpm":                   {"metrics.cost_micros", "metrics.impressions"},
+	"metrics.cost_per_interaction":  {"metrics.cost_micros", "metrics.interactions"},
+	"metrics.value_per_click":       {"metrics.conversions_value", "metrics.clicks"},
+}
@@ -0,0 +1,48 @@
+package searchsearchterms
+
+import (
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+)
+
+// SearchTerm represents a normalized row of the search terms report.
+type SearchTerm struct {
+	SearchTerm           string
+	ResourceName         string
+	Status               string
+	KeywordText          string
+	KeywordMatchType     string
+	KeywordResourceName  string
+	CampaignID           string
+	CampaignName         string
+	CampaignResourceName string
+	AdGroupID            string
+	AdGroupName          string
+	AdGroupResourceName  string
+	Metrics              SearchTermMetrics
+}
+
+// SearchTermMetrics represents metrics for a search term.
+type SearchTermMetrics struct {
+	Clicks              int64    // Total clicks
+	Impressions         int64    // Total impressions
+	CTR                 float64  // Click-through rate
+	AverageCPC          int64    // Average cost per click in micros
+	CostMicros          int64    // Total cost in micros
+	Conversions         float64  // Conversions
+	ConversionsValue    float64  // Total conversion value
+	CostPerConversion   float64  // Cost per conversion in currency units
+	ConversionRate      float64  // Conversion rate (percentage)
+	AllConversions      float64  // All conversions (including estimated)
+	AllConversionsValue float64  // Total value of all conversions
+	Interactions        int64    // Total interactions (clicks + engagements)
+	KPIs                kpi.KPIs // Derived ratios (ROAS, CPA, CPM, ...)
+}
+
+// Result aggregates the search terms outcome along with pagination metadata.
+type Result struct {
+	SearchTerms       []SearchTerm
+	NextPageToken     string
+	TotalResultsCount int64
+	Cache             searchcache.Status // Whether the rows came from the search cache; empty when caching is disabled
+}
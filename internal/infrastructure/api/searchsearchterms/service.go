@@ -0,0 +1,288 @@
+package searchsearchterms
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+	"google-ads-mcp/internal/infrastructure/api/searchcache"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+)
+
+type Service struct {
+	client        *searchcache.Client
+	loginResolver logincustomer.Resolver
+	maxFetchRows  int
+}
+
+func NewService(client *searchcache.Client, loginResolver logincustomer.Resolver, maxFetchRows int) *Service {
+	return &Service{
+		client:        client,
+		loginResolver: loginResolver,
+		maxFetchRows:  maxFetchRows,
+	}
+}
+
+func (s *Service) SearchSearchTerms(ctx context.Context, filters Filters) (Result, error) {
+	query, err := s.buildQuery(filters)
+	if err != nil {
+		return Result{}, err
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, filters.CustomerID, filters.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("searchsearchterms: resolving login customer ID: %w", err)
+	}
+
+	searchTerms := make([]SearchTerm, 0)
+
+	// fetch_all from the first page streams every row in a single response. Search terms
+	// past the cap are counted but not kept, since a stream cannot be resumed.
+	if filters.FetchAll && filters.PageToken == "" {
+		streamRequest := &services.SearchGoogleAdsStreamRequest{CustomerId: filters.CustomerID, Query: query}
+		rowCount, cacheStatus, err := s.client.SearchStream(ctx, streamRequest, loginCustomerID, filters.BypassCache, func(row *services.GoogleAdsRow) error {
+			if len(searchTerms) >= s.maxFetchRows {
+				return nil
+			}
+			if searchTerm := s.mapRowToSearchTerm(row); searchTerm != nil {
+				searchTerms = append(searchTerms, *searchTerm)
+			}
+			return nil
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("searchsearchterms: %w", err)
+		}
+
+		return Result{
+			SearchTerms:       searchTerms,
+			TotalResultsCount: rowCount,
+			Cache:             cacheStatus,
+		}, nil
+	}
+
+	request := &services.SearchGoogleAdsRequest{
+		CustomerId: filters.CustomerID,
+		Query:      query,
+		PageToken:  filters.PageToken,
+		PageSize:   filters.PageSize,
+	}
+
+	// Walk pages server-side when fetch_all resumes from a page token. The row cap is
+	// checked between pages so no row is dropped and the caller can resume from NextPageToken.
+	protoResp, cacheStatus, err := s.client.SearchPages(ctx, request, loginCustomerID, filters.BypassCache, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.Results {
+			searchTerm := s.mapRowToSearchTerm(row)
+			if searchTerm != nil {
+				searchTerms = append(searchTerms, *searchTerm)
+			}
+		}
+		return filters.FetchAll && len(searchTerms) < s.maxFetchRows, nil
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("searchsearchterms: %w", err)
+	}
+
+	return Result{
+		SearchTerms:       searchTerms,
+		NextPageToken:     protoResp.GetNextPageToken(),
+		TotalResultsCount: protoResp.GetTotalResultsCount(),
+		Cache:             cacheStatus,
+	}, nil
+}
+
+func (s *Service) buildQuery(filters Filters) (string, error) {
+	// Build SELECT clause with search term fields, triggering keyword, context and metrics
+	fields := []string{
+		"search_term_view.search_term",
+		"search_term_view.resource_name",
+		"search_term_view.status",
+		"segments.keyword.info.text",
+		"segments.keyword.info.match_type",
+		"segments.keyword.ad_group_criterion",
+		"campaign.id",
+		"campaign.name",
+		"campaign.resource_name",
+		"ad_group.id",
+		"ad_group.name",
+		"ad_group.resource_name",
+		"metrics.clicks",
+		"metrics.impressions",
+		"metrics.ctr",
+		"metrics.average_cpc",
+		"metrics.cost_micros",
+		"metrics.conversions",
+		"metrics.conversions_value",
+		"metrics.cost_per_conversion",
+		"metrics.all_conversions",
+		"metrics.all_conversions_value",
+		"metrics.interactions",
+	}
+
+	// Narrow the SELECT to the requested fields
+	fields = filters.Fields.Columns(requiredFields, fields)
+
+	qb := gaql.NewQueryBuilder("search_term_view").Select(fields...)
+
+	// Default to LAST_7_DAYS if no date range is provided
+	hasDateRange := filters.DateRangeStart != "" || filters.DateRangeEnd != ""
+	if !hasDateRange {
+		qb.Where("segments.date DURING LAST_7_DAYS")
+	} else {
+		if err := qb.WhereDateRange(filters.DateRangeStart, filters.DateRangeEnd); err != nil {
+			return "", fmt.Errorf("searchsearchterms: building query: %w", err)
+		}
+	}
+
+	// Add campaign ID filters if present
+	if len(filters.CampaignIDs) > 0 {
+		if err := qb.WhereCampaignIDs(filters.CampaignIDs); err != nil {
+			return "", fmt.Errorf("searchsearchterms: building query: %w", err)
+		}
+	}
+
+	// Add campaign name filters if present
+	if len(filters.CampaignNames) > 0 {
+		qb.WhereCampaignNames(filters.CampaignNames)
+	}
+
+	// Add ad group ID filters if present
+	if len(filters.AdGroupIDs) > 0 {
+		if err := qb.WhereAdGroupIDs(filters.AdGroupIDs); err != nil {
+			return "", fmt.Errorf("searchsearchterms: building query: %w", err)
+		}
+	}
+
+	// Add ad group name filters if present
+	if len(filters.AdGroupNames) > 0 {
+		qb.WhereAdGroupNames(filters.AdGroupNames)
+	}
+
+	// Add status filters if present
+	if len(filters.Statuses) > 0 {
+		if err := qb.WhereSearchTermStatus(filters.Statuses); err != nil {
+			return "", fmt.Errorf("searchsearchterms: building query: %w", err)
+		}
+	}
+
+	// Add wasted spend filters if present
+	if filters.ZeroConversionOnly {
+		qb.Where("metrics.conversions = 0")
+	}
+
+	// Add metric threshold filters if present
+	thresholds := gaql.MetricThresholds{
+		MinCostMicros:  filters.MinCostMicros,
+		MinImpressions: filters.MinImpressions,
+		MinClicks:      filters.MinClicks,
+		MinConversions: filters.MinConversions,
+		MaxCPAMicros:   filters.MaxCPAMicros,
+	}
+	if err := qb.WhereMetricThresholds(thresholds); err != nil {
+		return "", fmt.Errorf("searchsearchterms: building query: %w", err)
+	}
+
+	// Sort and cap server-side so "top N" requests only return N rows
+	if err := qb.OrderByMetric(filters.OrderBy, filters.Limit); err != nil {
+		return "", fmt.Errorf("searchsearchterms: building query: %w", err)
+	}
+
+	return qb.Build(), nil
+}
+
+func (s *Service) mapRowToSearchTerm(row *services.GoogleAdsRow) *SearchTerm {
+	searchTermResource := row.GetSearchTermView()
+	if searchTermResource == nil {
+		return nil
+	}
+
+	campaignResource := row.GetCampaign()
+	adGroupResource := row.GetAdGroup()
+	metricsResource := row.GetMetrics()
+	keywordSegment := row.GetSegments().GetKeyword()
+
+	var campaignID, campaignName, campaignResourceName string
+	if campaignResource != nil {
+		campaignID = fmt.Sprintf("%d", campaignResource.GetId())
+		campaignName = campaignResource.GetName()
+		campaignResourceName = campaignResource.GetResourceName()
+	}
+
+	var adGroupID, adGroupName, adGroupResourceName string
+	if adGroupResource != nil {
+		adGroupID = fmt.Sprintf("%d", adGroupResource.GetId())
+		adGroupName = adGroupResource.GetName()
+		adGroupResourceName = adGroupResource.GetResourceName()
+	}
+
+	var keywordText, keywordMatchType, keywordResourceName string
+	if keywordSegment != nil {
+		keywordResourceName = keywordSegment.GetAdGroupCriterion()
+		if keywordInfo := keywordSegment.GetInfo(); keywordInfo != nil {
+			keywordText = keywordInfo.GetText()
+			keywordMatchType = strings.ToLower(keywordInfo.GetMatchType().String())
+		}
+	}
+
+	var clicks, impressions, interactions int64
+	var ctr, averageCPC float64
+	var costMicros int64
+	var conversions, conversionsValue, costPerConversion float64
+	var allConversions, allConversionsValue float64
+
+	if metricsResource != nil {
+		clicks = metricsResource.GetClicks()
+		impressions = metricsResource.GetImpressions()
+		ctr = metricsResource.GetCtr()
+		averageCPC = metricsResource.GetAverageCpc()
+		costMicros = metricsResource.GetCostMicros()
+		conversions = metricsResource.GetConversions()
+		conversionsValue = metricsResource.GetConversionsValue()
+		costPerConversion = metricsResource.GetCostPerConversion()
+		allConversions = metricsResource.GetAllConversions()
+		allConversionsValue = metricsResource.GetAllConversionsValue()
+		interactions = metricsResource.GetInteractions()
+	}
+
+	conversionRate := kpi.ConversionRate(conversions, clicks)
+
+	return &SearchTerm{
+		SearchTerm:           searchTermResource.GetSearchTerm(),
+		ResourceName:         searchTermResource.GetResourceName(),
+		Status:               strings.ToLower(searchTermResource.GetStatus().String()),
+		KeywordText:          keywordText,
+		KeywordMatchType:     keywordMatchType,
+		KeywordResourceName:  keywordResourceName,
+		CampaignID:           campaignID,
+		CampaignName:         campaignName,
+		CampaignResourceName: campaignResourceName,
+		AdGroupID:            adGroupID,
+		AdGroupName:          adGroupName,
+		AdGroupResourceName:  adGroupResourceName,
+		Metrics: SearchTermMetrics{
+			Clicks:              clicks,
+			Impressions:         impressions,
+			CTR:                 ctr,
+			AverageCPC:          int64(averageCPC), // Already in micros
+			CostMicros:          costMicros,
+			Conversions:         conversions,
+			ConversionsValue:    conversionsValue,
+			CostPerConversion:   costPerConversion,
+			ConversionRate:      conversionRate,
+			AllConversions:      allConversions,
+			AllConversionsValue: allConversionsValue,
+			Interactions:        interactions,
+			KPIs: kpi.Compute(kpi.Inputs{
+				Clicks:           clicks,
+				Impressions:      impressions,
+				Interactions:     interactions,
+				CostMicros:       costMicros,
+				Conversions:      conversions,
+				ConversionsValue: conversionsValue,
+			}),
+		},
+	}
+}
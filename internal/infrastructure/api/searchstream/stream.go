@@ -0,0 +1,142 @@
+package searchstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google-ads-mcp/internal/infrastructure/api/apierrors"
+	infrahttp "google-ads-mcp/internal/infrastructure/http"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Search runs query through googleAds:searchStream and calls fn with every row as
+// its batch is decoded, so the response is never held in memory as a whole. An
+// error from fn stops the stream. It returns the number of rows streamed and the
+// request ID.
+func Search(ctx context.Context, client *infrahttp.Client, endpoint, query string, headers map[string]string, fn func(*services.GoogleAdsRow) error) (int64, string, error) {
+	request := protoJSONRequest{message: &services.SearchGoogleAdsStreamRequest{Query: query}}
+	response, err := client.PostStream(ctx, endpoint, request, headers)
+	if err != nil {
+		return 0, "", fmt.Errorf("executing stream request: %w", err)
+	}
+
+	requestID := http.Header(response.Headers).Get("request-id")
+	if response.StatusCode >= 400 {
+		buffered, err := response.Buffer()
+		if err != nil {
+			return 0, requestID, err
+		}
+		return 0, requestID, fromErrorBody(buffered)
+	}
+	defer response.Body.Close()
+
+	var rows int64
+	err = Decode(response.Body, func(batch *services.SearchGoogleAdsStreamResponse) error {
+		if requestID == "" {
+			requestID = batch.GetRequestId()
+		}
+		for _, row := range batch.GetResults() {
+			rows++
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var streamErr *errorBatchError
+		if errors.As(err, &streamErr) {
+			return rows, requestID, apierrors.FromResponse(&infrahttp.Response{
+				StatusCode: streamErr.statusCode,
+				Headers:    response.Headers,
+				Body:       streamErr.body,
+			})
+		}
+		return rows, requestID, err
+	}
+
+	return rows, requestID, nil
+}
+
+// Decode reads a searchStream body, a JSON array of SearchGoogleAdsStreamResponse
+// batches, and calls fn with each batch as soon as it is decoded. Only one batch
+// is buffered at a time. An error reported in the middle of the stream stops it.
+func Decode(r io.Reader, fn func(*services.SearchGoogleAdsStreamResponse) error) error {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		return fmt.Errorf("reading stream: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("reading stream: expected a JSON array, got %v", token)
+	}
+
+	unmarshal := protojson.UnmarshalOptions{DiscardUnknown: true}
+	for decoder.More() {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return fmt.Errorf("reading stream: %w", err)
+		}
+
+		var envelope struct {
+			Error *struct {
+				Code int `json:"code"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(raw, &envelope); err == nil && envelope.Error != nil {
+			return &errorBatchError{statusCode: envelope.Error.Code, body: raw}
+		}
+
+		var batch services.SearchGoogleAdsStreamResponse
+		if err := unmarshal.Unmarshal(raw, &batch); err != nil {
+			return fmt.Errorf("unmarshal stream batch: %w", err)
+		}
+		if err := fn(&batch); err != nil {
+			return err
+		}
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return fmt.Errorf("reading stream: %w", err)
+	}
+	return nil
+}
+
+// errorBatchError carries an {"error": ...} element found in the stream.
+type errorBatchError struct {
+	statusCode int
+	body       json.RawMessage
+}
+
+func (e *errorBatchError) Error() string {
+	return "stream reported an error"
+}
+
+// fromErrorBody decodes an error response. searchStream wraps the error object in
+// an array, so its first element is decoded like a googleAds:search error.
+func fromErrorBody(response *infrahttp.Response) error {
+	if trimmed := bytes.TrimSpace(response.Body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var elements []json.RawMessage
+		if err := json.Unmarshal(trimmed, &elements); err == nil && len(elements) > 0 {
+			response.Body = elements[0]
+		}
+	}
+	return apierrors.FromResponse(response)
+}
+
+type protoJSONRequest struct {
+	message *services.SearchGoogleAdsStreamRequest
+}
+
+// MarshalJSON implements json.Marshaler interface to use protobuf JSON marshaling
+func (p protoJSONRequest) MarshalJSON() ([]byte, error) {
+	return protojson.MarshalOptions{EmitUnpopulated: false}.Marshal(p.message)
+}
@@ -0,0 +1,10 @@
+package setcampaignstatus
+
+// Request captures the parameters used to change the status of campaigns.
+type Request struct {
+	CustomerID      string
+	LoginCustomerID string
+	CampaignIDs     []string
+	Status          string
+	ValidateOnly    bool
+}
@@ -0,0 +1,25 @@
+package setcampaignstatus
+
+// Result represents the outcome of a campaign status mutation.
+type Result struct {
+	Status        string
+	ValidateOnly  bool
+	Operations    []OperationResult
+	RequestErrors []OperationError // Errors that apply to the whole request; no operation succeeded
+	RequestID     string
+}
+
+// OperationResult represents the outcome of a single campaign update operation.
+type OperationResult struct {
+	Index        int
+	CampaignID   string
+	ResourceName string
+	Success      bool
+	Errors       []OperationError
+}
+
+// OperationError represents an error reported by the API for one operation.
+type OperationError struct {
+	Code    string // e.g. "campaign_error.CANNOT_MODIFY_REMOVED_CAMPAIGN"
+	Message string
+}
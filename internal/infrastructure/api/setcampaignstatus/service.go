@@ -0,0 +1,150 @@
+package setcampaignstatus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google-ads-mcp/internal/infrastructure/api/apierrors"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+
+	"github.com/shenzhencenter/google-ads-pb/enums"
+	"github.com/shenzhencenter/google-ads-pb/resources"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// allowedStatuses are the statuses a campaign can be moved to with an update.
+// Removing a campaign is a different operation and is intentionally not supported.
+var allowedStatuses = map[string]enums.CampaignStatusEnum_CampaignStatus{
+	"ENABLED": enums.CampaignStatusEnum_ENABLED,
+	"PAUSED":  enums.CampaignStatusEnum_PAUSED,
+}
+
+type Service struct {
+	client        *googleads.Client
+	loginResolver logincustomer.Resolver
+}
+
+func NewService(client *googleads.Client, loginResolver logincustomer.Resolver) *Service {
+	return &Service{
+		client:        client,
+		loginResolver: loginResolver,
+	}
+}
+
+// SetCampaignStatus updates the status of the given campaigns with a single
+// campaigns:mutate call. Partial failure is enabled so that one bad campaign
+// does not block the others; failures are reported per operation.
+func (s *Service) SetCampaignStatus(ctx context.Context, request Request) (Result, error) {
+	customerID := logincustomer.NormalizeCustomerID(request.CustomerID)
+	if customerID == "" {
+		return Result{}, fmt.Errorf("setcampaignstatus: customer ID is required")
+	}
+
+	statusName := strings.ToUpper(strings.TrimSpace(request.Status))
+	status, ok := allowedStatuses[statusName]
+	if !ok {
+		return Result{}, fmt.Errorf("setcampaignstatus: invalid status %q: must be one of ENABLED, PAUSED", request.Status)
+	}
+
+	campaignIDs, err := normalizeCampaignIDs(request.CampaignIDs)
+	if err != nil {
+		return Result{}, fmt.Errorf("setcampaignstatus: %w", err)
+	}
+
+	operations := make([]*services.CampaignOperation, 0, len(campaignIDs))
+	for _, campaignID := range campaignIDs {
+		operations = append(operations, &services.CampaignOperation{
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"status"}},
+			Operation: &services.CampaignOperation_Update{
+				Update: &resources.Campaign{
+					ResourceName: fmt.Sprintf("customers/%s/campaigns/%s", customerID, campaignID),
+					Status:       status,
+				},
+			},
+		})
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, request.CustomerID, request.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("setcampaignstatus: resolving login customer ID: %w", err)
+	}
+
+	mutateRequest := &services.MutateCampaignsRequest{
+		CustomerId:     customerID,
+		Operations:     operations,
+		PartialFailure: true,
+		ValidateOnly:   request.ValidateOnly,
+	}
+
+	protoResp, requestID, err := s.client.MutateCampaigns(ctx, mutateRequest, loginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("setcampaignstatus: %w", err)
+	}
+
+	partialFailures, err := apierrors.PartialFailures(protoResp.GetPartialFailureError())
+	if err != nil {
+		return Result{}, fmt.Errorf("setcampaignstatus: decoding partial failure: %w", err)
+	}
+
+	// Errors without an operation index fail the whole request
+	requestErrors := mapOperationErrors(partialFailures[apierrors.RequestLevel])
+	results := make([]OperationResult, 0, len(campaignIDs))
+	for i, campaignID := range campaignIDs {
+		result := OperationResult{
+			Index:      i,
+			CampaignID: campaignID,
+			Errors:     mapOperationErrors(partialFailures[i]),
+			Success:    len(partialFailures[i]) == 0 && len(requestErrors) == 0,
+		}
+		if i < len(protoResp.GetResults()) {
+			result.ResourceName = protoResp.GetResults()[i].GetResourceName()
+		}
+		results = append(results, result)
+	}
+
+	return Result{
+		Status:        strings.ToLower(statusName),
+		ValidateOnly:  request.ValidateOnly,
+		Operations:    results,
+		RequestErrors: requestErrors,
+		RequestID:     requestID,
+	}, nil
+}
+
+// normalizeCampaignIDs validates campaign IDs and removes duplicates while keeping order.
+func normalizeCampaignIDs(ids []string) ([]string, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("at least one campaign ID is required")
+	}
+
+	seen := make(map[string]bool, len(ids))
+	normalized := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid campaign ID %q: must be numeric", id)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		normalized = append(normalized, id)
+	}
+
+	return normalized, nil
+}
+
+func mapOperationErrors(details []apierrors.Detail) []OperationError {
+	var errs []OperationError
+	for _, detail := range details {
+		errs = append(errs, OperationError{
+			Code:    detail.Code,
+			Message: detail.Message,
+		})
+	}
+	return errs
+}
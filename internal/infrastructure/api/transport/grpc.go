@@ -0,0 +1,210 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google-ads-mcp/internal/infrastructure/api/apierrors"
+	"google-ads-mcp/internal/infrastructure/auth"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultGRPCAddress is the Google Ads API gRPC endpoint.
+	DefaultGRPCAddress = "googleads.googleapis.com:443"
+	// GRPCAPIVersion is the API version the google-ads-pb stubs were generated for.
+	// gRPC requests always speak it, whatever GOOGLE_ADS_API_VERSION says.
+	GRPCAPIVersion = "v21"
+)
+
+// GRPC sends requests through the generated GoogleAdsServiceClient and
+// CampaignServiceClient. The API version is GRPCAPIVersion, the one the clients
+// were generated for.
+type GRPC struct {
+	client         services.GoogleAdsServiceClient
+	campaigns      services.CampaignServiceClient
+	developerToken string
+}
+
+// NewGRPC returns a transport over conn, which must authenticate every RPC, e.g. with
+// TokenCredentials. Tests can pass a connection to an in-process fake server.
+func NewGRPC(conn grpc.ClientConnInterface, developerToken string) *GRPC {
+	return &GRPC{
+		client:         services.NewGoogleAdsServiceClient(conn),
+		campaigns:      services.NewCampaignServiceClient(conn),
+		developerToken: developerToken,
+	}
+}
+
+// DialGRPC opens a TLS connection to address whose RPCs carry an OAuth access token
+// from tokenManager.
+func DialGRPC(address string, tokenManager auth.TokenProvider) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(address,
+		grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")),
+		grpc.WithPerRPCCredentials(NewTokenCredentials(tokenManager, true)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("transport: dialing %s: %w", address, err)
+	}
+	return conn, nil
+}
+
+func (t *GRPC) Search(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string) (*services.SearchGoogleAdsResponse, string, error) {
+	customerID, err := normalizeCustomerID(request.GetCustomerId())
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid customer ID: %w", err)
+	}
+	request = proto.Clone(request).(*services.SearchGoogleAdsRequest)
+	request.CustomerId = customerID
+
+	var header metadata.MD
+	response, err := t.client.Search(t.outgoingContext(ctx, loginCustomerID), request, grpc.Header(&header))
+	requestID := firstValue(header, "request-id")
+	if err != nil {
+		return nil, requestID, fromError(err, requestID)
+	}
+
+	return response, requestID, nil
+}
+
+func (t *GRPC) SearchStream(ctx context.Context, request *services.SearchGoogleAdsStreamRequest, loginCustomerID string, fn func(*services.GoogleAdsRow) error) (int64, string, error) {
+	customerID, err := normalizeCustomerID(request.GetCustomerId())
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid customer ID: %w", err)
+	}
+	request = proto.Clone(request).(*services.SearchGoogleAdsStreamRequest)
+	request.CustomerId = customerID
+
+	// Cancel the stream when fn stops it early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var header metadata.MD
+	stream, err := t.client.SearchStream(t.outgoingContext(ctx, loginCustomerID), request, grpc.Header(&header))
+	if err != nil {
+		return 0, "", fromError(err, "")
+	}
+
+	var rows int64
+	var requestID string
+	for {
+		batch, err := stream.Recv()
+		if requestID == "" {
+			requestID = firstValue(header, "request-id")
+		}
+		if errors.Is(err, io.EOF) {
+			return rows, requestID, nil
+		}
+		if err != nil {
+			return rows, requestID, fromError(err, requestID)
+		}
+
+		if requestID == "" {
+			requestID = batch.GetRequestId()
+		}
+		for _, row := range batch.GetResults() {
+			rows++
+			if err := fn(row); err != nil {
+				return rows, requestID, err
+			}
+		}
+	}
+}
+
+func (t *GRPC) Mutate(ctx context.Context, request *services.MutateGoogleAdsRequest, loginCustomerID string) (*services.MutateGoogleAdsResponse, string, error) {
+	customerID, err := normalizeCustomerID(request.GetCustomerId())
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid customer ID: %w", err)
+	}
+	request = proto.Clone(request).(*services.MutateGoogleAdsRequest)
+	request.CustomerId = customerID
+
+	var header metadata.MD
+	response, err := t.client.Mutate(t.outgoingContext(ctx, loginCustomerID), request, grpc.Header(&header))
+	requestID := firstValue(header, "request-id")
+	if err != nil {
+		return nil, requestID, fromError(err, requestID)
+	}
+
+	return response, requestID, nil
+}
+
+func (t *GRPC) MutateCampaigns(ctx context.Context, request *services.MutateCampaignsRequest, loginCustomerID string) (*services.MutateCampaignsResponse, string, error) {
+	customerID, err := normalizeCustomerID(request.GetCustomerId())
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid customer ID: %w", err)
+	}
+	request = proto.Clone(request).(*services.MutateCampaignsRequest)
+	request.CustomerId = customerID
+
+	var header metadata.MD
+	response, err := t.campaigns.MutateCampaigns(t.outgoingContext(ctx, loginCustomerID), request, grpc.Header(&header))
+	requestID := firstValue(header, "request-id")
+	if err != nil {
+		return nil, requestID, fromError(err, requestID)
+	}
+
+	return response, requestID, nil
+}
+
+// outgoingContext adds the developer token and login-customer-id metadata.
+func (t *GRPC) outgoingContext(ctx context.Context, loginCustomerID string) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, "developer-token", t.developerToken)
+	if loginCustomerID != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "login-customer-id", loginCustomerID)
+	}
+	return ctx
+}
+
+// fromError turns an RPC status into the same typed errors as a REST response.
+func fromError(err error, requestID string) error {
+	rpcStatus, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("executing request: %w", err)
+	}
+	return apierrors.FromStatus(rpcStatus.Proto(), requestID)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// TokenCredentials authenticates every RPC with an OAuth access token.
+type TokenCredentials struct {
+	tokenManager             auth.TokenProvider
+	requireTransportSecurity bool
+}
+
+// NewTokenCredentials returns per-RPC credentials backed by tokenManager. Only an
+// in-process fake should be reached without transport security.
+func NewTokenCredentials(tokenManager auth.TokenProvider, requireTransportSecurity bool) *TokenCredentials {
+	return &TokenCredentials{
+		tokenManager:             tokenManager,
+		requireTransportSecurity: requireTransportSecurity,
+	}
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+func (c *TokenCredentials) GetRequestMetadata(ctx context.Context, _ ...string) (map[string]string, error) {
+	accessToken, err := c.tokenManager.GetAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	return map[string]string{"authorization": "Bearer " + accessToken}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+func (c *TokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTransportSecurity
+}
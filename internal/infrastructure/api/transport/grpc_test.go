@@ -0,0 +1,253 @@
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google-ads-mcp/internal/infrastructure/api/apierrors"
+	"google-ads-mcp/internal/infrastructure/api/transport"
+	"google-ads-mcp/internal/infrastructure/auth"
+	"google-ads-mcp/internal/testing/fakeads"
+
+	"github.com/shenzhencenter/google-ads-pb/resources"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+)
+
+const campaignQuery = "SELECT campaign.id, campaign.name FROM campaign"
+
+// newGRPC starts a fake Google Ads API and returns a gRPC transport to it that
+// authenticates with the fake's service account.
+func newGRPC(t *testing.T) (*fakeads.Server, *transport.GRPC) {
+	t.Helper()
+
+	fake := fakeads.New()
+	t.Cleanup(fake.Close)
+
+	tokenManager, err := auth.NewTokenManagerFromServiceAccount([]byte(fake.ServiceAccountJSON()), auth.GoogleAdsScope)
+	if err != nil {
+		t.Fatalf("NewTokenManagerFromServiceAccount: %v", err)
+	}
+	conn, err := fake.DialGRPC(tokenManager)
+	if err != nil {
+		t.Fatalf("DialGRPC: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return fake, transport.NewGRPC(conn, fakeads.DefaultDeveloperToken)
+}
+
+func campaignRows(n int) []*services.GoogleAdsRow {
+	rows := make([]*services.GoogleAdsRow, n)
+	for i := range rows {
+		rows[i] = &services.GoogleAdsRow{Campaign: &resources.Campaign{Id: proto.Int64(int64(i + 1))}}
+	}
+	return rows
+}
+
+func TestGRPCSearch(t *testing.T) {
+	fake, grpcTransport := newGRPC(t)
+	fake.AddRows(campaignQuery, campaignRows(3)...)
+
+	response, requestID, err := grpcTransport.Search(context.Background(), &services.SearchGoogleAdsRequest{
+		CustomerId: "123-456-7890",
+		Query:      campaignQuery,
+	}, fakeads.DefaultCustomerID)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if got := len(response.GetResults()); got != 3 {
+		t.Errorf("Search returned %d rows, want 3", got)
+	}
+	if requestID == "" {
+		t.Error("Search returned no request ID")
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("fake received %d requests, want 1", len(requests))
+	}
+	if requests[0].CustomerID != "1234567890" {
+		t.Errorf("customer ID = %q, want 1234567890", requests[0].CustomerID)
+	}
+	if requests[0].LoginCustomerID != fakeads.DefaultCustomerID {
+		t.Errorf("login customer ID = %q, want %q", requests[0].LoginCustomerID, fakeads.DefaultCustomerID)
+	}
+}
+
+func TestGRPCSearchStream(t *testing.T) {
+	fake, grpcTransport := newGRPC(t)
+	fake.StreamBatchSize = 2
+	fake.AddRows(campaignQuery, campaignRows(5)...)
+
+	var ids []int64
+	rows, requestID, err := grpcTransport.SearchStream(context.Background(), &services.SearchGoogleAdsStreamRequest{
+		CustomerId: "1234567890",
+		Query:      campaignQuery,
+	}, "", func(row *services.GoogleAdsRow) error {
+		ids = append(ids, row.GetCampaign().GetId())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SearchStream: %v", err)
+	}
+	if rows != 5 || len(ids) != 5 || ids[4] != 5 {
+		t.Errorf("SearchStream streamed %d rows %v, want ids 1 to 5", rows, ids)
+	}
+	if requestID == "" {
+		t.Error("SearchStream returned no request ID")
+	}
+}
+
+func TestGRPCSearchStreamFailsMidStream(t *testing.T) {
+	fake, grpcTransport := newGRPC(t)
+	fake.StreamBatchSize = 2
+	fake.AddRows(campaignQuery, campaignRows(5)...)
+	failure := fakeads.QueryError("query is too complex")
+	failure.AfterBatches = 1
+	fake.Fail(campaignQuery, failure)
+
+	rows, _, err := grpcTransport.SearchStream(context.Background(), &services.SearchGoogleAdsStreamRequest{
+		CustomerId: "1234567890",
+		Query:      campaignQuery,
+	}, "", func(*services.GoogleAdsRow) error { return nil })
+
+	var queryErr *apierrors.QuerySyntaxError
+	if !errors.As(err, &queryErr) {
+		t.Fatalf("SearchStream error = %v, want a QuerySyntaxError", err)
+	}
+	if rows != 2 {
+		t.Errorf("SearchStream streamed %d rows before the error, want 2", rows)
+	}
+}
+
+func TestGRPCMutatePartialFailure(t *testing.T) {
+	fake, grpcTransport := newGRPC(t)
+	fake.FailOperation(1, fakeads.FieldError("budget amount is required"))
+
+	operation := func(id string) *services.MutateOperation {
+		return &services.MutateOperation{Operation: &services.MutateOperation_CampaignOperation{
+			CampaignOperation: &services.CampaignOperation{Operation: &services.CampaignOperation_Remove{
+				Remove: "customers/1234567890/campaigns/" + id,
+			}},
+		}}
+	}
+	response, _, err := grpcTransport.Mutate(context.Background(), &services.MutateGoogleAdsRequest{
+		CustomerId:       "1234567890",
+		MutateOperations: []*services.MutateOperation{operation("1"), operation("2")},
+		PartialFailure:   true,
+	}, "")
+	if err != nil {
+		t.Fatalf("Mutate: %v", err)
+	}
+
+	if got := response.GetMutateOperationResponses()[0].GetCampaignResult().GetResourceName(); got != "customers/1234567890/campaigns/1" {
+		t.Errorf("first result = %q, want customers/1234567890/campaigns/1", got)
+	}
+	failures, err := apierrors.PartialFailures(response.GetPartialFailureError())
+	if err != nil {
+		t.Fatalf("PartialFailures: %v", err)
+	}
+	if len(failures[1]) != 1 || len(failures[0]) != 0 {
+		t.Errorf("partial failures = %v, want one error on operation 1", failures)
+	}
+}
+
+func TestGRPCErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(*fakeads.Server)
+		check func(*testing.T, error)
+	}{
+		{
+			name:  "quota",
+			setup: func(fake *fakeads.Server) { fake.SetQuota(0, 30*time.Second) },
+			check: func(t *testing.T, err error) {
+				var quotaErr *apierrors.QuotaError
+				if !errors.As(err, &quotaErr) {
+					t.Fatalf("error = %v, want a QuotaError", err)
+				}
+				if quotaErr.RetryDelay != 30*time.Second {
+					t.Errorf("retry delay = %v, want 30s", quotaErr.RetryDelay)
+				}
+			},
+		},
+		{
+			name:  "authorization",
+			setup: func(fake *fakeads.Server) { fake.FailCustomer("1234567890", fakeads.AuthorizationError()) },
+			check: func(t *testing.T, err error) {
+				var authzErr *apierrors.AuthorizationError
+				if !errors.As(err, &authzErr) {
+					t.Fatalf("error = %v, want an AuthorizationError", err)
+				}
+				if authzErr.RequestID == "" {
+					t.Error("error has no request ID")
+				}
+			},
+		},
+		{
+			name:  "revoked token",
+			setup: func(fake *fakeads.Server) { fake.RevokeTokens() },
+			check: func(t *testing.T, err error) {
+				var authnErr *apierrors.AuthenticationError
+				if !errors.As(err, &authnErr) {
+					t.Fatalf("error = %v, want an AuthenticationError", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, grpcTransport := newGRPC(t)
+			fake.AddRows(campaignQuery, campaignRows(1)...)
+
+			// Fetch a token before the failure is set up, so a revoked one is reused
+			request := &services.SearchGoogleAdsRequest{CustomerId: "1234567890", Query: campaignQuery}
+			if _, _, err := grpcTransport.Search(context.Background(), request, ""); err != nil {
+				t.Fatalf("first Search: %v", err)
+			}
+
+			tt.setup(fake)
+			_, _, err := grpcTransport.Search(context.Background(), request, "")
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestGRPCMutateCampaigns(t *testing.T) {
+	fake, grpcTransport := newGRPC(t)
+	fake.FailOperation(0, fakeads.FieldError("campaign is removed"))
+
+	operation := func(id string) *services.CampaignOperation {
+		return &services.CampaignOperation{Operation: &services.CampaignOperation_Remove{
+			Remove: "customers/1234567890/campaigns/" + id,
+		}}
+	}
+	response, _, err := grpcTransport.MutateCampaigns(context.Background(), &services.MutateCampaignsRequest{
+		CustomerId:     "1234567890",
+		Operations:     []*services.CampaignOperation{operation("1"), operation("2")},
+		PartialFailure: true,
+	}, "")
+	if err != nil {
+		t.Fatalf("MutateCampaigns: %v", err)
+	}
+
+	if got := response.GetResults()[1].GetResourceName(); got != "customers/1234567890/campaigns/2" {
+		t.Errorf("second result = %q, want customers/1234567890/campaigns/2", got)
+	}
+	failures, err := apierrors.PartialFailures(response.GetPartialFailureError())
+	if err != nil {
+		t.Fatalf("PartialFailures: %v", err)
+	}
+	if len(failures[0]) != 1 || len(failures[1]) != 0 {
+		t.Errorf("partial failures = %v, want one error on operation 0", failures)
+	}
+
+	requests := fake.Requests()
+	if len(requests) != 1 || requests[0].Method != fakeads.MethodMutateCampaigns {
+		t.Errorf("fake received %v, want one campaigns mutate", requests)
+	}
+}
@@ -0,0 +1,199 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"google-ads-mcp/internal/infrastructure/api/apierrors"
+	"google-ads-mcp/internal/infrastructure/api/searchstream"
+	"google-ads-mcp/internal/infrastructure/auth"
+	infrahttp "google-ads-mcp/internal/infrastructure/http"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultBaseURL is the Google Ads REST interface.
+	DefaultBaseURL = "https://googleads.googleapis.com"
+	// DefaultAPIVersion is the REST API version requests are sent to.
+	DefaultAPIVersion = "v22"
+)
+
+// REST sends requests to the Google Ads REST interface with protobuf JSON bodies.
+type REST struct {
+	client         *infrahttp.Client
+	tokenManager   auth.TokenProvider
+	developerToken string
+	baseURL        string
+	apiVersion     string
+}
+
+// NewREST returns a transport that sends requests to baseURL under apiVersion, e.g.
+// https://googleads.googleapis.com/v22. Empty values fall back to the defaults.
+func NewREST(client *infrahttp.Client, tokenManager auth.TokenProvider, developerToken, baseURL, apiVersion string) *REST {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if apiVersion == "" {
+		apiVersion = DefaultAPIVersion
+	}
+
+	return &REST{
+		client:         client,
+		tokenManager:   tokenManager,
+		developerToken: developerToken,
+		baseURL:        baseURL,
+		apiVersion:     apiVersion,
+	}
+}
+
+func (t *REST) Search(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string) (*services.SearchGoogleAdsResponse, string, error) {
+	endpoint, err := t.buildEndpoint(request.GetCustomerId(), "googleAds:search")
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	headers, err := t.headers(ctx, loginCustomerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The customer ID is part of the URL path, not of the body
+	body := proto.Clone(request).(*services.SearchGoogleAdsRequest)
+	body.CustomerId = ""
+
+	response, err := t.client.Post(ctx, endpoint, protoJSONRequest{message: body}, headers)
+	if err != nil {
+		return nil, "", fmt.Errorf("executing request: %w", err)
+	}
+
+	if response.StatusCode >= 400 {
+		return nil, "", apierrors.FromResponse(response)
+	}
+
+	var protoResp services.SearchGoogleAdsResponse
+	if err = (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(response.Body, &protoResp); err != nil {
+		return nil, "", fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return &protoResp, http.Header(response.Headers).Get("request-id"), nil
+}
+
+func (t *REST) SearchStream(ctx context.Context, request *services.SearchGoogleAdsStreamRequest, loginCustomerID string, fn func(*services.GoogleAdsRow) error) (int64, string, error) {
+	endpoint, err := t.buildEndpoint(request.GetCustomerId(), "googleAds:searchStream")
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	headers, err := t.headers(ctx, loginCustomerID)
+	if err != nil {
+		return 0, "", err
+	}
+
+	return searchstream.Search(ctx, t.client, endpoint, request.GetQuery(), headers, fn)
+}
+
+func (t *REST) Mutate(ctx context.Context, request *services.MutateGoogleAdsRequest, loginCustomerID string) (*services.MutateGoogleAdsResponse, string, error) {
+	body := proto.Clone(request).(*services.MutateGoogleAdsRequest)
+	body.CustomerId = ""
+
+	var protoResp services.MutateGoogleAdsResponse
+	requestID, err := t.mutate(ctx, request.GetCustomerId(), "googleAds:mutate", body, loginCustomerID, &protoResp)
+	if err != nil {
+		return nil, "", err
+	}
+	return &protoResp, requestID, nil
+}
+
+func (t *REST) MutateCampaigns(ctx context.Context, request *services.MutateCampaignsRequest, loginCustomerID string) (*services.MutateCampaignsResponse, string, error) {
+	body := proto.Clone(request).(*services.MutateCampaignsRequest)
+	body.CustomerId = ""
+
+	var protoResp services.MutateCampaignsResponse
+	requestID, err := t.mutate(ctx, request.GetCustomerId(), "campaigns:mutate", body, loginCustomerID, &protoResp)
+	if err != nil {
+		return nil, "", err
+	}
+	return &protoResp, requestID, nil
+}
+
+// mutate posts body to method of customerID once, without retries, and decodes the
+// response into protoResp. It returns the request ID.
+func (t *REST) mutate(ctx context.Context, customerID, method string, body proto.Message, loginCustomerID string, protoResp proto.Message) (string, error) {
+	endpoint, err := t.buildEndpoint(customerID, method)
+	if err != nil {
+		return "", fmt.Errorf("invalid customer ID: %w", err)
+	}
+
+	headers, err := t.headers(ctx, loginCustomerID)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := t.client.PostOnce(ctx, endpoint, protoJSONRequest{message: body}, headers)
+	if err != nil {
+		return "", fmt.Errorf("executing request: %w", err)
+	}
+
+	if response.StatusCode >= 400 {
+		return "", apierrors.FromResponse(response)
+	}
+
+	// Partial failure details may be of a newer API version than the compiled types
+	if err = apierrors.UnmarshalResponse(response.Body, protoResp); err != nil {
+		return "", fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	return http.Header(response.Headers).Get("request-id"), nil
+}
+
+func (t *REST) headers(ctx context.Context, loginCustomerID string) (map[string]string, error) {
+	accessToken, err := t.tokenManager.GetAccessToken(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	headers := map[string]string{
+		"Content-Type":    "application/json",
+		"Authorization":   "Bearer " + accessToken,
+		"developer-token": t.developerToken,
+	}
+	if loginCustomerID != "" {
+		headers["login-customer-id"] = loginCustomerID
+	}
+
+	return headers, nil
+}
+
+func (t *REST) buildEndpoint(customerID, method string) (string, error) {
+	baseURL, err := url.Parse(t.baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	customerID, err = normalizeCustomerID(customerID)
+	if err != nil {
+		return "", err
+	}
+
+	version := strings.TrimPrefix(strings.TrimSpace(t.apiVersion), "/")
+	path := strings.TrimSuffix(baseURL.Path, "/")
+	path = fmt.Sprintf("%s/%s/customers/%s/%s", path, version, customerID, method)
+	baseURL.Path = path
+
+	return baseURL.String(), nil
+}
+
+type protoJSONRequest struct {
+	message proto.Message
+}
+
+// MarshalJSON implements json.Marshaler interface to use protobuf JSON marshaling
+func (p protoJSONRequest) MarshalJSON() ([]byte, error) {
+	return protojson.MarshalOptions{EmitUnpopulated: false}.Marshal(p.message)
+}
@@ -0,0 +1,44 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+)
+
+const (
+	// KindREST sends requests as protobuf JSON to the Google Ads REST interface.
+	KindREST = "rest"
+	// KindGRPC sends requests to the Google Ads gRPC interface.
+	KindGRPC = "grpc"
+)
+
+// Transport sends GoogleAdsService and CampaignService requests to the Google Ads
+// API. Implementations authenticate every request and set the developer token;
+// callers only pass the login-customer-id, which is left out when empty. Mutates
+// are sent once, as a retried one may be applied twice.
+type Transport interface {
+	// Search returns one page of results and the request ID.
+	Search(ctx context.Context, request *services.SearchGoogleAdsRequest, loginCustomerID string) (*services.SearchGoogleAdsResponse, string, error)
+	// SearchStream calls fn with every row as it arrives; an error from fn stops the
+	// stream. It returns the number of rows streamed and the request ID.
+	SearchStream(ctx context.Context, request *services.SearchGoogleAdsStreamRequest, loginCustomerID string, fn func(*services.GoogleAdsRow) error) (int64, string, error)
+	// Mutate applies the operations of a googleAds:mutate request and returns the
+	// response and the request ID.
+	Mutate(ctx context.Context, request *services.MutateGoogleAdsRequest, loginCustomerID string) (*services.MutateGoogleAdsResponse, string, error)
+	// MutateCampaigns applies the operations of a campaigns:mutate request and
+	// returns the response and the request ID.
+	MutateCampaigns(ctx context.Context, request *services.MutateCampaignsRequest, loginCustomerID string) (*services.MutateCampaignsResponse, string, error)
+}
+
+// normalizeCustomerID strips the "customers/" prefix and dashes from a customer ID.
+func normalizeCustomerID(customerID string) (string, error) {
+	customerID = strings.TrimPrefix(strings.TrimSpace(customerID), "customers/")
+	customerID = strings.ReplaceAll(customerID, "-", "")
+	if customerID == "" {
+		return "", fmt.Errorf("customer ID is required")
+	}
+	return customerID, nil
+}
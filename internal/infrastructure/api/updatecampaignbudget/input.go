@@ -0,0 +1,10 @@
+package updatecampaignbudget
+
+// Request captures the parameters used to change the budget of a campaign.
+type Request struct {
+	CustomerID      string
+	LoginCustomerID string
+	CampaignID      string
+	AmountMicros    int64
+	ValidateOnly    bool
+}
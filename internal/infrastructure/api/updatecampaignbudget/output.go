@@ -0,0 +1,26 @@
+package updatecampaignbudget
+
+// Result represents the outcome of a campaign budget update.
+type Result struct {
+	CampaignID         string
+	CampaignName       string
+	BudgetID           string
+	BudgetName         string
+	BudgetResourceName string
+	OldAmountMicros    int64
+	NewAmountMicros    int64
+	ChangeMicros       int64
+	ChangePercent      float64 // Relative change against the old amount, 0 when the old amount is 0
+	ValidateOnly       bool
+	Shared             bool  // True when the budget is explicitly shared or used by several campaigns
+	ReferenceCount     int64 // Number of campaigns using the budget
+	SharedCampaigns    []SharedCampaign
+	Warnings           []string
+	RequestID          string
+}
+
+// SharedCampaign is another campaign affected by a change to a shared budget.
+type SharedCampaign struct {
+	ID   string
+	Name string
+}
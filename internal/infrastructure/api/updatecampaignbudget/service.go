@@ -0,0 +1,252 @@
+package updatecampaignbudget
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google-ads-mcp/internal/infrastructure/api/gaql"
+	"google-ads-mcp/internal/infrastructure/api/googleads"
+	"google-ads-mcp/internal/infrastructure/api/logincustomer"
+
+	"github.com/shenzhencenter/google-ads-pb/resources"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+type Service struct {
+	client           *googleads.Client
+	loginResolver    logincustomer.Resolver
+	maxChangePercent float64
+	maxChangeMicros  int64
+}
+
+func NewService(client *googleads.Client, loginResolver logincustomer.Resolver, maxChangePercent float64, maxChangeMicros int64) *Service {
+	return &Service{
+		client:           client,
+		loginResolver:    loginResolver,
+		maxChangePercent: maxChangePercent,
+		maxChangeMicros:  maxChangeMicros,
+	}
+}
+
+// budgetInfo is the current state of a campaign and its budget.
+type budgetInfo struct {
+	campaignID       string
+	campaignName     string
+	budgetID         string
+	budgetName       string
+	resourceName     string
+	amountMicros     int64
+	explicitlyShared bool
+	referenceCount   int64
+}
+
+// UpdateCampaignBudget changes the amount of the budget attached to a campaign.
+// The current budget is read first so the change can be checked against the
+// configured guardrails and reported as old and new amounts.
+func (s *Service) UpdateCampaignBudget(ctx context.Context, request Request) (Result, error) {
+	customerID := logincustomer.NormalizeCustomerID(request.CustomerID)
+	if customerID == "" {
+		return Result{}, fmt.Errorf("updatecampaignbudget: invalid customer ID: customer ID is required")
+	}
+
+	campaignID := strings.TrimSpace(request.CampaignID)
+	if _, err := strconv.ParseInt(campaignID, 10, 64); err != nil {
+		return Result{}, fmt.Errorf("updatecampaignbudget: invalid campaign ID %q: must be numeric", request.CampaignID)
+	}
+
+	if request.AmountMicros <= 0 {
+		return Result{}, fmt.Errorf("updatecampaignbudget: amount_micros must be greater than zero")
+	}
+
+	loginCustomerID, err := s.loginResolver.Resolve(ctx, request.CustomerID, request.LoginCustomerID)
+	if err != nil {
+		return Result{}, fmt.Errorf("updatecampaignbudget: resolving login customer ID: %w", err)
+	}
+
+	budget, err := s.getBudget(ctx, customerID, campaignID, loginCustomerID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	changeMicros := request.AmountMicros - budget.amountMicros
+	var changePercent float64
+	if budget.amountMicros > 0 {
+		changePercent = float64(changeMicros) / float64(budget.amountMicros) * 100.0
+	}
+
+	if err := s.checkGuardrails(budget.amountMicros, changeMicros, changePercent); err != nil {
+		return Result{}, fmt.Errorf("updatecampaignbudget: %w", err)
+	}
+
+	var warnings []string
+	var sharedCampaigns []SharedCampaign
+	shared := budget.explicitlyShared || budget.referenceCount > 1
+	if shared {
+		sharedCampaigns, err = s.getCampaignsUsingBudget(ctx, customerID, budget.resourceName, campaignID, loginCustomerID)
+		if err != nil {
+			return Result{}, err
+		}
+		campaigns := "campaigns"
+		if budget.referenceCount == 1 {
+			campaigns = "campaign"
+		}
+		warnings = append(warnings, fmt.Sprintf("budget %s is shared by %d %s: the new amount applies to all of them", budget.budgetID, budget.referenceCount, campaigns))
+	}
+	if changeMicros == 0 {
+		warnings = append(warnings, "the new amount equals the current amount")
+	}
+
+	requestID, err := s.mutateBudget(ctx, customerID, budget.resourceName, request.AmountMicros, request.ValidateOnly, loginCustomerID)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		CampaignID:         budget.campaignID,
+		CampaignName:       budget.campaignName,
+		BudgetID:           budget.budgetID,
+		BudgetName:         budget.budgetName,
+		BudgetResourceName: budget.resourceName,
+		OldAmountMicros:    budget.amountMicros,
+		NewAmountMicros:    request.AmountMicros,
+		ChangeMicros:       changeMicros,
+		ChangePercent:      changePercent,
+		ValidateOnly:       request.ValidateOnly,
+		Shared:             shared,
+		ReferenceCount:     budget.referenceCount,
+		SharedCampaigns:    sharedCampaigns,
+		Warnings:           warnings,
+		RequestID:          requestID,
+	}, nil
+}
+
+// checkGuardrails rejects changes above the configured percentage or absolute ceiling.
+// The percentage is undefined when the current amount is 0, so such a budget can only
+// be changed within the absolute ceiling, and not at all when that ceiling is disabled.
+func (s *Service) checkGuardrails(oldAmountMicros, changeMicros int64, changePercent float64) error {
+	absoluteChange := changeMicros
+	if absoluteChange < 0 {
+		absoluteChange = -absoluteChange
+	}
+
+	if oldAmountMicros == 0 && s.maxChangeMicros == 0 && absoluteChange > 0 {
+		return fmt.Errorf("the current budget is 0, so the percentage ceiling cannot bound the change; set MAX_BUDGET_CHANGE_MICROS to allow it")
+	}
+
+	if s.maxChangeMicros > 0 && absoluteChange > s.maxChangeMicros {
+		return fmt.Errorf("budget change of %d micros exceeds the maximum of %d micros per call", absoluteChange, s.maxChangeMicros)
+	}
+
+	if oldAmountMicros > 0 && s.maxChangePercent > 0 && math.Abs(changePercent) > s.maxChangePercent {
+		return fmt.Errorf("budget change of %.2f%% exceeds the maximum of %.2f%% per call", math.Abs(changePercent), s.maxChangePercent)
+	}
+
+	return nil
+}
+
+func (s *Service) getBudget(ctx context.Context, customerID, campaignID string, loginCustomerID string) (budgetInfo, error) {
+	qb := gaql.NewQueryBuilder("campaign").Select(
+		"campaign.id",
+		"campaign.name",
+		"campaign_budget.id",
+		"campaign_budget.name",
+		"campaign_budget.resource_name",
+		"campaign_budget.amount_micros",
+		"campaign_budget.explicitly_shared",
+		"campaign_budget.reference_count",
+	)
+	if err := qb.WhereCampaignIDs([]string{campaignID}); err != nil {
+		return budgetInfo{}, fmt.Errorf("updatecampaignbudget: building query: %w", err)
+	}
+
+	request := &services.SearchGoogleAdsRequest{CustomerId: customerID, Query: qb.Build()}
+	protoResp, err := s.client.Search(ctx, request, loginCustomerID)
+	if err != nil {
+		return budgetInfo{}, fmt.Errorf("updatecampaignbudget: %w", err)
+	}
+
+	if len(protoResp.GetResults()) == 0 {
+		return budgetInfo{}, fmt.Errorf("updatecampaignbudget: campaign %s not found", campaignID)
+	}
+
+	row := protoResp.GetResults()[0]
+	budget := row.GetCampaignBudget()
+	if budget.GetResourceName() == "" {
+		return budgetInfo{}, fmt.Errorf("updatecampaignbudget: campaign %s has no campaign budget", campaignID)
+	}
+
+	return budgetInfo{
+		campaignID:       fmt.Sprintf("%d", row.GetCampaign().GetId()),
+		campaignName:     row.GetCampaign().GetName(),
+		budgetID:         fmt.Sprintf("%d", budget.GetId()),
+		budgetName:       budget.GetName(),
+		resourceName:     budget.GetResourceName(),
+		amountMicros:     budget.GetAmountMicros(),
+		explicitlyShared: budget.GetExplicitlyShared(),
+		referenceCount:   budget.GetReferenceCount(),
+	}, nil
+}
+
+// getCampaignsUsingBudget lists the other non-removed campaigns attached to a budget.
+func (s *Service) getCampaignsUsingBudget(ctx context.Context, customerID, budgetResourceName, excludeCampaignID string, loginCustomerID string) ([]SharedCampaign, error) {
+	query := gaql.NewQueryBuilder("campaign").
+		Select("campaign.id", "campaign.name").
+		Where(fmt.Sprintf("campaign.campaign_budget = '%s'", budgetResourceName)).
+		Where("campaign.status != REMOVED").
+		Build()
+
+	request := &services.SearchGoogleAdsRequest{CustomerId: customerID, Query: query}
+	campaigns := make([]SharedCampaign, 0)
+	_, err := s.client.SearchPages(ctx, request, loginCustomerID, func(page *services.SearchGoogleAdsResponse) (bool, error) {
+		for _, row := range page.GetResults() {
+			id := fmt.Sprintf("%d", row.GetCampaign().GetId())
+			if id == excludeCampaignID {
+				continue
+			}
+			campaigns = append(campaigns, SharedCampaign{
+				ID:   id,
+				Name: row.GetCampaign().GetName(),
+			})
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("updatecampaignbudget: %w", err)
+	}
+
+	return campaigns, nil
+}
+
+func (s *Service) mutateBudget(ctx context.Context, customerID, resourceName string, amountMicros int64, validateOnly bool, loginCustomerID string) (string, error) {
+	mutateRequest := &services.MutateGoogleAdsRequest{
+		CustomerId: customerID,
+		MutateOperations: []*services.MutateOperation{
+			{
+				Operation: &services.MutateOperation_CampaignBudgetOperation{
+					CampaignBudgetOperation: &services.CampaignBudgetOperation{
+						UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"amount_micros"}},
+						Operation: &services.CampaignBudgetOperation_Update{
+							Update: &resources.CampaignBudget{
+								ResourceName: resourceName,
+								AmountMicros: proto.Int64(amountMicros),
+							},
+						},
+					},
+				},
+			},
+		},
+		ValidateOnly: validateOnly,
+	}
+
+	_, requestID, err := s.client.Mutate(ctx, mutateRequest, loginCustomerID)
+	if err != nil {
+		return "", fmt.Errorf("updatecampaignbudget: %w", err)
+	}
+
+	return requestID, nil
+}
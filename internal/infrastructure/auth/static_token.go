@@ -0,0 +1,12 @@
+package auth
+
+import "context"
+
+// StaticToken is a TokenProvider that always returns the same token, e.g. when
+// replaying recorded traffic that never reaches Google
+type StaticToken string
+
+// GetAccessToken returns the token
+func (t StaticToken) GetAccessToken(ctx context.Context) (string, error) {
+	return string(t), nil
+}
@@ -0,0 +1,53 @@
+package fx
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Converter resolves the reporting currency of a request and its exchange rates.
+// A Converter without a table rejects every conversion.
+type Converter struct {
+	table           *Table
+	defaultCurrency string
+}
+
+func NewConverter(table *Table, defaultCurrency string) *Converter {
+	return &Converter{
+		table:           table,
+		defaultCurrency: normalizeCurrency(defaultCurrency),
+	}
+}
+
+// ReportingCurrency returns the requested currency, or the configured default
+// when the request does not set one. An empty result disables conversion.
+func (c *Converter) ReportingCurrency(requested string) (string, error) {
+	currency := normalizeCurrency(requested)
+	if currency == "" {
+		currency = c.defaultCurrency
+	}
+	if currency == "" {
+		return "", nil
+	}
+
+	if c.table == nil {
+		return "", fmt.Errorf("fx: reporting_currency %s requires an FX rates file (FX_RATES_FILE)", currency)
+	}
+	if len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("fx: invalid reporting currency %q: expected an ISO 4217 code", currency)
+	}
+
+	return currency, nil
+}
+
+// Rate returns the rate from an account currency to the reporting currency on date.
+// Amounts already in the reporting currency get a rate of 1 without a rate date.
+func (c *Converter) Rate(from, to, date string) (Rate, error) {
+	if c.table == nil {
+		return Rate{}, fmt.Errorf("fx: no FX rates file configured")
+	}
+	if from, to := normalizeCurrency(from), normalizeCurrency(to); from != "" && from == to {
+		return Rate{From: from, To: to, Value: 1}, nil
+	}
+	return c.table.Rate(from, to, date)
+}
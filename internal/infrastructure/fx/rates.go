@@ -0,0 +1,205 @@
+package fx
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+// Table holds exchange rate snapshots loaded from a local file, sorted by date.
+type Table struct {
+	snapshots []snapshot
+}
+
+// snapshot is the set of rates published for one date, relative to a base currency.
+type snapshot struct {
+	Date  string             `json:"date"`
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"` // Units of each currency for one unit of Base
+}
+
+// Rate converts amounts from one currency to another. Date is the date of the
+// snapshot the rate was taken from.
+type Rate struct {
+	From  string
+	To    string
+	Value float64
+	Date  string
+}
+
+// ConvertMicros converts an amount in micros, rounded to the nearest micro.
+func (r Rate) ConvertMicros(micros int64) int64 {
+	return int64(math.Round(float64(micros) * r.Value))
+}
+
+// Convert converts an amount in currency units.
+func (r Rate) Convert(amount float64) float64 {
+	return amount * r.Value
+}
+
+// LoadFile reads a rates file. ".json" files hold one snapshot or a list of
+// snapshots ({"date": "2025-01-31", "base": "USD", "rates": {"EUR": 0.92}});
+// ".csv" files have a "date,base,currency,rate" header and one rate per line.
+func LoadFile(path string) (*Table, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("fx: opening rates file: %w", err)
+	}
+	defer file.Close()
+
+	var snapshots []snapshot
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		snapshots, err = readJSON(file)
+	case ".csv":
+		snapshots, err = readCSV(file)
+	default:
+		return nil, fmt.Errorf("fx: unsupported rates file %q: expected .json or .csv", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fx: reading %s: %w", path, err)
+	}
+
+	return newTable(snapshots)
+}
+
+// Rate returns the rate from one currency to another taken from the latest
+// snapshot dated on or before date that quotes both. An empty date uses the
+// latest snapshot.
+func (t *Table) Rate(from, to, date string) (Rate, error) {
+	from, to = normalizeCurrency(from), normalizeCurrency(to)
+	if from == "" || to == "" {
+		return Rate{}, fmt.Errorf("fx: currency code is required")
+	}
+
+	for i := len(t.snapshots) - 1; i >= 0; i-- {
+		s := t.snapshots[i]
+		if date != "" && s.Date > date {
+			continue
+		}
+
+		fromRate, okFrom := s.Rates[from]
+		toRate, okTo := s.Rates[to]
+		if !okFrom || !okTo {
+			continue
+		}
+
+		return Rate{From: from, To: to, Value: toRate / fromRate, Date: s.Date}, nil
+	}
+
+	if date != "" {
+		return Rate{}, fmt.Errorf("fx: no %s to %s rate on or before %s", from, to, date)
+	}
+	return Rate{}, fmt.Errorf("fx: no %s to %s rate", from, to)
+}
+
+func newTable(snapshots []snapshot) (*Table, error) {
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("fx: rates file has no rates")
+	}
+
+	byDate := make(map[string]*snapshot)
+	for _, s := range snapshots {
+		if _, err := time.Parse(dateLayout, s.Date); err != nil {
+			return nil, fmt.Errorf("fx: invalid rate date %q: expected YYYY-MM-DD", s.Date)
+		}
+		base := normalizeCurrency(s.Base)
+		if base == "" {
+			return nil, fmt.Errorf("fx: base currency is required for %s", s.Date)
+		}
+
+		key := s.Date + "/" + base
+		merged, ok := byDate[key]
+		if !ok {
+			merged = &snapshot{Date: s.Date, Base: base, Rates: map[string]float64{base: 1}}
+			byDate[key] = merged
+		}
+		for currency, rate := range s.Rates {
+			if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+				return nil, fmt.Errorf("fx: invalid %s rate %v for %s", currency, rate, s.Date)
+			}
+			merged.Rates[normalizeCurrency(currency)] = rate
+		}
+	}
+
+	table := &Table{snapshots: make([]snapshot, 0, len(byDate))}
+	for _, s := range byDate {
+		table.snapshots = append(table.snapshots, *s)
+	}
+	sort.Slice(table.snapshots, func(i, j int) bool {
+		if table.snapshots[i].Date != table.snapshots[j].Date {
+			return table.snapshots[i].Date < table.snapshots[j].Date
+		}
+		return table.snapshots[i].Base < table.snapshots[j].Base
+	})
+
+	return table, nil
+}
+
+func readJSON(r io.Reader) ([]snapshot, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []snapshot
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		err = json.Unmarshal(data, &snapshots)
+	} else {
+		var single snapshot
+		err = json.Unmarshal(data, &single)
+		snapshots = []snapshot{single}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing JSON: %w", err)
+	}
+
+	return snapshots, nil
+}
+
+func readCSV(r io.Reader) ([]snapshot, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := strings.Join(records[0], ",")
+	if !strings.EqualFold(strings.ReplaceAll(header, " ", ""), "date,base,currency,rate") {
+		return nil, fmt.Errorf("unexpected CSV header %q: expected date,base,currency,rate", header)
+	}
+
+	snapshots := make([]snapshot, 0, len(records)-1)
+	for i, record := range records[1:] {
+		if len(record) != 4 {
+			return nil, fmt.Errorf("line %d: expected 4 columns, got %d", i+2, len(record))
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid rate %q", i+2, record[3])
+		}
+		snapshots = append(snapshots, snapshot{
+			Date:  strings.TrimSpace(record[0]),
+			Base:  record[1],
+			Rates: map[string]float64{record[2]: rate},
+		})
+	}
+
+	return snapshots, nil
+}
+
+func normalizeCurrency(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
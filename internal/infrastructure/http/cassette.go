@@ -0,0 +1,243 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Cassette modes
+const (
+	// CassetteRecord sends requests and saves every request/response pair to the cassette file
+	CassetteRecord = "record"
+	// CassetteReplay answers requests from the cassette file without reaching the network
+	CassetteReplay = "replay"
+)
+
+// scrubbedValue replaces the credentials and manager account of recorded requests
+const scrubbedValue = "REDACTED"
+
+// scrubbedHeaders are never written to a cassette. Replays do not match on headers,
+// so the login-customer-id naming the manager account is dropped along with the
+// credentials. Customer IDs in URLs, GAQL, resource names and responses are kept,
+// since replays match requests by URL and body.
+var scrubbedHeaders = []string{"Authorization", "Developer-Token", "Login-Customer-Id"}
+
+// Interaction is a recorded request and the response it received
+type Interaction struct {
+	Request    RecordedRequest  `json:"request"`
+	Response   RecordedResponse `json:"response"`
+	RecordedAt time.Time        `json:"recorded_at"`
+}
+
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Cassette records API traffic to a JSON file or replays it. Replayed requests are
+// matched by method, URL and body; identical requests get their responses in the
+// order they were recorded, and the last one once those run out.
+type Cassette struct {
+	mode string
+	path string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	replayed     []bool
+}
+
+// NewCassette opens the cassette at path. Recording starts an empty cassette and
+// overwrites the file; replaying loads it.
+func NewCassette(mode, path string) (*Cassette, error) {
+	cassette := &Cassette{mode: mode, path: path}
+
+	switch mode {
+	case CassetteRecord:
+		if err := cassette.save(); err != nil {
+			return nil, err
+		}
+	case CassetteReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &cassette.interactions); err != nil {
+			return nil, fmt.Errorf("failed to parse cassette %s: %w", path, err)
+		}
+		cassette.replayed = make([]bool, len(cassette.interactions))
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+
+	return cassette, nil
+}
+
+// Interactions returns the recorded or loaded interactions
+func (c *Cassette) Interactions() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Interaction(nil), c.interactions...)
+}
+
+// transport wraps next so requests sent through it are recorded or replayed
+func (c *Cassette) transport(next http.RoundTripper) http.RoundTripper {
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.cassette.mode == CassetteReplay {
+		return t.cassette.replay(req, body)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// The body is read in full so it can be saved; streamed responses arrive at once
+	responseBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	if err := t.cassette.record(req, body, resp, responseBody); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (c *Cassette) record(req *http.Request, body []byte, resp *http.Response, responseBody []byte) error {
+	headers := req.Header.Clone()
+	for _, name := range scrubbedHeaders {
+		if headers.Get(name) != "" {
+			headers.Set(name, scrubbedValue)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: headers,
+			Body:    string(body),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       string(responseBody),
+		},
+		RecordedAt: time.Now().UTC(),
+	})
+
+	return c.save()
+}
+
+func (c *Cassette) replay(req *http.Request, body []byte) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, interaction := range c.interactions {
+		if interaction.Request.Method != req.Method || interaction.Request.URL != req.URL.String() || interaction.Request.Body != string(body) {
+			continue
+		}
+		last = i
+		if !c.replayed[i] {
+			break
+		}
+	}
+	if last < 0 {
+		return nil, fmt.Errorf("cassette %s has no response for %s %s", c.path, req.Method, req.URL)
+	}
+	c.replayed[last] = true
+
+	recorded := c.interactions[last].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Headers.Clone(),
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// save rewrites the cassette file, so it stays complete if the server stops mid-session
+func (c *Cassette) save() error {
+	interactions := c.interactions
+	if interactions == nil {
+		interactions = []Interaction{}
+	}
+
+	data, err := json.MarshalIndent(interactions, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	if dir := filepath.Dir(c.path); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create cassette directory: %w", err)
+		}
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// readRequestBody reads the body and puts it back so the request can still be sent
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
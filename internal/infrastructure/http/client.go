@@ -18,6 +18,8 @@ type Config struct {
 	MaxRetryDelay  time.Duration
 	UserAgent      string
 	DefaultHeaders map[string]string
+	// Cassette records or replays every request sent by the client; nil sends requests as usual
+	Cassette *Cassette
 }
 
 // DefaultConfig returns a default configuration
@@ -33,8 +35,9 @@ func DefaultConfig() *Config {
 }
 
 type Client struct {
-	client *http.Client
-	config *Config
+	client       *http.Client
+	streamClient *http.Client
+	config       *Config
 }
 
 func NewClient(config *Config) *Client {
@@ -42,37 +45,65 @@ func NewClient(config *Config) *Client {
 		config = DefaultConfig()
 	}
 
+	var roundTripper http.RoundTripper = http.DefaultTransport
+
+	// Streamed bodies can take longer than Timeout to read, so only the wait for the
+	// response headers is bounded; the rest is bounded by the request context
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = config.Timeout
+	var streamRoundTripper http.RoundTripper = transport
+
+	if config.Cassette != nil {
+		roundTripper = config.Cassette.transport(roundTripper)
+		streamRoundTripper = config.Cassette.transport(streamRoundTripper)
+	}
+
 	client := &http.Client{
-		Timeout: config.Timeout,
+		Transport: roundTripper,
+		Timeout:   config.Timeout,
+	}
+	streamClient := &http.Client{
+		Transport: streamRoundTripper,
 	}
 
 	return &Client{
-		client: client,
-		config: config,
+		client:       client,
+		streamClient: streamClient,
+		config:       config,
 	}
 }
 
 func (c *Client) Get(ctx context.Context, url string, headers map[string]string) (*Response, error) {
-	return c.doRequest(ctx, http.MethodGet, url, nil, headers)
+	return c.doRequest(ctx, http.MethodGet, url, nil, headers, c.config.MaxRetries)
 }
 
 func (c *Client) Post(ctx context.Context, url string, body interface{}, headers map[string]string) (*Response, error) {
-	return c.doRequest(ctx, http.MethodPost, url, body, headers)
+	return c.doRequest(ctx, http.MethodPost, url, body, headers, c.config.MaxRetries)
+}
+
+// PostOnce sends a POST request without retrying it, for requests that are not
+// idempotent: a 5xx, 429 or 408 response or a failed connection does not tell
+// whether the server already applied them.
+func (c *Client) PostOnce(ctx context.Context, url string, body interface{}, headers map[string]string) (*Response, error) {
+	return c.doRequest(ctx, http.MethodPost, url, body, headers, 0)
 }
 
 func (c *Client) Put(ctx context.Context, url string, body interface{}, headers map[string]string) (*Response, error) {
-	return c.doRequest(ctx, http.MethodPut, url, body, headers)
+	return c.doRequest(ctx, http.MethodPut, url, body, headers, c.config.MaxRetries)
 }
 
 func (c *Client) Delete(ctx context.Context, url string, headers map[string]string) (*Response, error) {
-	return c.doRequest(ctx, http.MethodDelete, url, nil, headers)
+	return c.doRequest(ctx, http.MethodDelete, url, nil, headers, c.config.MaxRetries)
 }
 
 func (c *Client) Patch(ctx context.Context, url string, body interface{}, headers map[string]string) (*Response, error) {
-	return c.doRequest(ctx, http.MethodPatch, url, body, headers)
+	return c.doRequest(ctx, http.MethodPatch, url, body, headers, c.config.MaxRetries)
 }
 
-func (c *Client) doRequest(ctx context.Context, method, url string, body interface{}, headers map[string]string) (*Response, error) {
+// PostStream sends a POST request and returns the response without reading its body,
+// so large responses can be decoded as they arrive. The caller must close the body.
+// Requests are retried like the other methods until a response is accepted.
+func (c *Client) PostStream(ctx context.Context, url string, body interface{}, headers map[string]string) (*StreamResponse, error) {
 	var lastErr error
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
@@ -85,6 +116,56 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body interfa
 			bodyReader = bytes.NewReader(bodyBytes)
 		}
 
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bodyReader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		c.setHeaders(req, headers)
+
+		resp, err := c.streamClient.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("request failed: %w", err)
+			if attempt < c.config.MaxRetries {
+				c.waitBeforeRetry(attempt)
+
+				continue
+			}
+
+			return nil, lastErr
+		}
+
+		if c.shouldRetry(resp.StatusCode) && attempt < c.config.MaxRetries {
+			resp.Body.Close()
+			lastErr = fmt.Errorf("received status %d, retrying", resp.StatusCode)
+			c.waitBeforeRetry(attempt)
+
+			continue
+		}
+
+		return &StreamResponse{
+			StatusCode: resp.StatusCode,
+			Headers:    resp.Header,
+			Body:       resp.Body,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
+}
+
+func (c *Client) doRequest(ctx context.Context, method, url string, body interface{}, headers map[string]string, maxRetries int) (*Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyBytes, err := json.Marshal(body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			}
+			bodyReader = bytes.NewReader(bodyBytes)
+		}
+
 		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create request: %w", err)
@@ -95,7 +176,7 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body interfa
 		resp, err := c.client.Do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("request failed: %w", err)
-			if attempt < c.config.MaxRetries {
+			if attempt < maxRetries {
 				c.waitBeforeRetry(attempt)
 
 				continue
@@ -108,7 +189,7 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body interfa
 		if err != nil {
 			resp.Body.Close()
 			lastErr = fmt.Errorf("failed to read response body: %w", err)
-			if attempt < c.config.MaxRetries {
+			if attempt < maxRetries {
 				c.waitBeforeRetry(attempt)
 
 				continue
@@ -117,7 +198,7 @@ func (c *Client) doRequest(ctx context.Context, method, url string, body interfa
 		}
 		resp.Body.Close()
 
-		if c.shouldRetry(resp.StatusCode) && attempt < c.config.MaxRetries {
+		if c.shouldRetry(resp.StatusCode) && attempt < maxRetries {
 			lastErr = fmt.Errorf("received status %d, retrying", resp.StatusCode)
 			c.waitBeforeRetry(attempt)
 
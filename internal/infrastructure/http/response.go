@@ -1,7 +1,35 @@
 package http
 
+import (
+	"fmt"
+	"io"
+)
+
 type Response struct {
 	StatusCode int
 	Headers    map[string][]string
 	Body       []byte
 }
+
+// StreamResponse is a response whose body is read as it arrives.
+type StreamResponse struct {
+	StatusCode int
+	Headers    map[string][]string
+	Body       io.ReadCloser
+}
+
+// Buffer reads and closes the body, e.g. to decode an error response.
+func (r *StreamResponse) Buffer() (*Response, error) {
+	defer r.Body.Close()
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return &Response{
+		StatusCode: r.StatusCode,
+		Headers:    r.Headers,
+		Body:       body,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package fakeads
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	pberrors "github.com/shenzhencenter/google-ads-pb/errors"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Failure is an error the fake answers with. It is encoded like a Google Ads API
+// error, a google.rpc.Status carrying a GoogleAdsFailure, so clients decode it into
+// the same typed errors as a real one.
+type Failure struct {
+	StatusCode int // HTTP status, e.g. 400
+	ErrorCode  *pberrors.ErrorCode
+	Message    string
+	// RetryDelay is reported in the quota error details.
+	RetryDelay time.Duration
+	// AfterBatches is the number of searchStream batches sent before the error;
+	// 0 fails the stream before the first one.
+	AfterBatches int
+}
+
+// QueryError is a rejected GAQL query.
+func QueryError(message string) *Failure {
+	return &Failure{
+		StatusCode: http.StatusBadRequest,
+		ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_QueryError{QueryError: pberrors.QueryErrorEnum_QUERY_ERROR}},
+		Message:    message,
+	}
+}
+
+// QuotaError is an exhausted quota that can be retried after retryDelay.
+func QuotaError(retryDelay time.Duration) *Failure {
+	return &Failure{
+		StatusCode: http.StatusTooManyRequests,
+		ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_QuotaError{QuotaError: pberrors.QuotaErrorEnum_RESOURCE_EXHAUSTED}},
+		Message:    "Too many requests. Retry in " + retryDelay.String() + ".",
+		RetryDelay: retryDelay,
+	}
+}
+
+// AuthorizationError is a customer the caller may not access.
+func AuthorizationError() *Failure {
+	return &Failure{
+		StatusCode: http.StatusForbidden,
+		ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_AuthorizationError{AuthorizationError: pberrors.AuthorizationErrorEnum_USER_PERMISSION_DENIED}},
+		Message:    "User doesn't have permission to access customer.",
+	}
+}
+
+// FieldError is an invalid field value, e.g. for a failed mutate operation.
+func FieldError(message string) *Failure {
+	return &Failure{
+		StatusCode: http.StatusBadRequest,
+		ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_FieldError{FieldError: pberrors.FieldErrorEnum_REQUIRED}},
+		Message:    message,
+	}
+}
+
+// InvalidPageToken is a page token the fake did not issue.
+func InvalidPageToken(token string) *Failure {
+	return &Failure{
+		StatusCode: http.StatusBadRequest,
+		ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_RequestError{RequestError: pberrors.RequestErrorEnum_INVALID_PAGE_TOKEN}},
+		Message:    "Page token is invalid: " + token,
+	}
+}
+
+// PageSizeNotSupported is the error the API returns for a search that sets page_size.
+func PageSizeNotSupported() *Failure {
+	return &Failure{
+		StatusCode: http.StatusBadRequest,
+		ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_RequestError{RequestError: pberrors.RequestErrorEnum_PAGE_SIZE_NOT_SUPPORTED}},
+		Message:    "Setting the page size is not supported. Search Responses will have fixed page size of '10000' rows.",
+	}
+}
+
+// InvalidArgument is a request the fake cannot decode.
+func InvalidArgument(message string) *Failure {
+	return &Failure{StatusCode: http.StatusBadRequest, Message: message}
+}
+
+// NotFound is a method the fake does not serve.
+func NotFound(method string) *Failure {
+	return &Failure{StatusCode: http.StatusNotFound, Message: "method not found: " + method}
+}
+
+// googleAdsError converts the failure into the error of a GoogleAdsFailure.
+func (f *Failure) googleAdsError(location *pberrors.ErrorLocation) *pberrors.GoogleAdsError {
+	adsErr := &pberrors.GoogleAdsError{
+		ErrorCode: f.ErrorCode,
+		Message:   f.Message,
+		Location:  location,
+	}
+	if f.RetryDelay > 0 {
+		adsErr.Details = &pberrors.ErrorDetails{
+			QuotaErrorDetails: &pberrors.QuotaErrorDetails{RetryDelay: durationpb.New(f.RetryDelay)},
+		}
+	}
+	return adsErr
+}
+
+// failureJSON encodes the failure as the {"error": ...} body of a REST response.
+func failureJSON(requestID string, failure *Failure, location *pberrors.ErrorLocation) ([]byte, error) {
+	rpcStatus := &status.Status{
+		Code:    int32(failure.StatusCode),
+		Message: failure.Message,
+	}
+	if failure.ErrorCode != nil {
+		detail, err := anypb.New(&pberrors.GoogleAdsFailure{
+			Errors:    []*pberrors.GoogleAdsError{failure.googleAdsError(location)},
+			RequestId: requestID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rpcStatus.Details = append(rpcStatus.Details, detail)
+	}
+
+	body, err := protojson.Marshal(rpcStatus)
+	if err != nil {
+		return nil, err
+	}
+	var envelope map[string]any
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, err
+	}
+	envelope["status"] = statusName(failure.StatusCode)
+
+	return json.Marshal(map[string]any{"error": envelope})
+}
+
+func writeFailure(w http.ResponseWriter, requestID string, failure *Failure) {
+	writeFailureAt(w, requestID, failure, nil)
+}
+
+func writeFailureAt(w http.ResponseWriter, requestID string, failure *Failure, location *pberrors.ErrorLocation) {
+	body, err := failureJSON(requestID, failure, location)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(failure.StatusCode)
+	w.Write(body)
+}
+
+// operationLocation points an error at the operation at index of a mutate request.
+// googleAds:mutate names its operations "mutate_operations", the other services
+// "operations".
+func operationLocation(method string, index int) *pberrors.ErrorLocation {
+	fieldName := "operations"
+	if method == MethodMutate {
+		fieldName = "mutate_operations"
+	}
+	return &pberrors.ErrorLocation{
+		FieldPathElements: []*pberrors.ErrorLocation_FieldPathElement{
+			{FieldName: fieldName, Index: proto.Int32(int32(index))},
+		},
+	}
+}
+
+// statusName is the RPC status the API reports along with an HTTP status.
+func statusName(statusCode int) string {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return "INVALID_ARGUMENT"
+	case http.StatusUnauthorized:
+		return "UNAUTHENTICATED"
+	case http.StatusForbidden:
+		return "PERMISSION_DENIED"
+	case http.StatusNotFound:
+		return "NOT_FOUND"
+	case http.StatusTooManyRequests:
+		return "RESOURCE_EXHAUSTED"
+	case http.StatusServiceUnavailable:
+		return "UNAVAILABLE"
+	}
+	return "INTERNAL"
+}
@@ -0,0 +1,272 @@
+package fakeads
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var fromPattern = regexp.MustCompile(`(?i)\bFROM\s+(\w+)`)
+
+// fixtureKey identifies canned rows or a failure. An empty customer ID matches
+// every customer and an empty query matches every request of the customer.
+type fixtureKey struct {
+	customerID string
+	query      string
+}
+
+// NormalizeQuery collapses whitespace so a fixture matches however the query is
+// formatted. Requests record their query in this form.
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// AddRows returns rows for query on every customer. Rows added for the same query
+// are appended.
+func (s *Server) AddRows(query string, rows ...*services.GoogleAdsRow) {
+	s.addRows(fixtureKey{query: NormalizeQuery(query)}, rows)
+}
+
+// AddCustomerRows returns rows for query on one customer only, ahead of rows
+// added with AddRows.
+func (s *Server) AddCustomerRows(customerID, query string, rows ...*services.GoogleAdsRow) {
+	s.addRows(fixtureKey{customerID: normalizeCustomerID(customerID), query: NormalizeQuery(query)}, rows)
+}
+
+// AddResourceRows returns rows for any query FROM resource that has no fixture of
+// its own, so a test does not have to spell out the exact GAQL a tool builds.
+func (s *Server) AddResourceRows(resource string, rows ...*services.GoogleAdsRow) {
+	s.addRows(fixtureKey{query: resourceKey(resource)}, rows)
+}
+
+// Fail answers searches of query with failure.
+func (s *Server) Fail(query string, failure *Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[fixtureKey{query: NormalizeQuery(query)}] = failure
+}
+
+// FailResource answers searches FROM resource with failure, unless a failure is set
+// for their exact query.
+func (s *Server) FailResource(resource string, failure *Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[fixtureKey{query: resourceKey(resource)}] = failure
+}
+
+// FailCustomer answers every request for customerID with failure.
+func (s *Server) FailCustomer(customerID string, failure *Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[fixtureKey{customerID: normalizeCustomerID(customerID)}] = failure
+}
+
+func (s *Server) addRows(key fixtureKey, rows []*services.GoogleAdsRow) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fixtures[key] = append(s.fixtures[key], rows...)
+}
+
+// lookup returns the rows and the failure, if any, for a search, most specific
+// fixture first. The rows are returned with a failure so a stream can fail after
+// sending some of them.
+func (s *Server) lookup(customerID, query string) ([]*services.GoogleAdsRow, *Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if failure := s.failures[fixtureKey{customerID: customerID}]; failure != nil {
+		return nil, failure
+	}
+
+	keys := []fixtureKey{{customerID, query}, {"", query}}
+	if match := fromPattern.FindStringSubmatch(query); match != nil {
+		keys = append(keys, fixtureKey{customerID, resourceKey(match[1])}, fixtureKey{"", resourceKey(match[1])})
+	}
+
+	var failure *Failure
+	for _, key := range keys {
+		if failure = s.failures[key]; failure != nil {
+			break
+		}
+	}
+	for _, key := range keys {
+		if rows, ok := s.fixtures[key]; ok {
+			return rows, failure
+		}
+	}
+	return nil, failure
+}
+
+// customerFailure returns the failure set for every request of customerID, if any.
+func (s *Server) customerFailure(customerID string) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failures[fixtureKey{customerID: customerID}]
+}
+
+// search serves one page of the matching rows.
+func (s *Server) search(w http.ResponseWriter, requestID, customerID, loginCustomerID string, request *services.SearchGoogleAdsRequest) {
+	response, failure := s.searchPage(customerID, loginCustomerID, request)
+	if failure != nil {
+		writeFailure(w, requestID, failure)
+		return
+	}
+	writeMessage(w, response)
+}
+
+// searchPage returns one page of the matching rows. Page tokens are row offsets.
+func (s *Server) searchPage(customerID, loginCustomerID string, request *services.SearchGoogleAdsRequest) (*services.SearchGoogleAdsResponse, *Failure) {
+	customerID = normalizeCustomerID(customerID)
+	query := NormalizeQuery(request.GetQuery())
+	s.record(Request{
+		Method:          MethodSearch,
+		CustomerID:      customerID,
+		LoginCustomerID: loginCustomerID,
+		Query:           query,
+		PageToken:       request.GetPageToken(),
+	})
+
+	rows, failure := s.lookup(customerID, query)
+	if failure != nil {
+		return nil, failure
+	}
+
+	// The API has rejected page_size since v17; pages are always 10,000 rows
+	if request.GetPageSize() != 0 {
+		return nil, PageSizeNotSupported()
+	}
+
+	offset := 0
+	if token := request.GetPageToken(); token != "" {
+		var err error
+		offset, err = parsePageToken(token)
+		if err != nil || offset > len(rows) {
+			return nil, InvalidPageToken(token)
+		}
+	}
+
+	size := s.PageSize
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	end := min(offset+size, len(rows))
+
+	response := &services.SearchGoogleAdsResponse{
+		Results:           rows[offset:end],
+		TotalResultsCount: int64(len(rows)),
+	}
+	if end < len(rows) {
+		response.NextPageToken = fmt.Sprintf("page-%d", end)
+	}
+	return response, nil
+}
+
+// searchStream serves every matching row as a JSON array of batches. A failure
+// with AfterBatches set is reported as an error element after that many batches,
+// the way the API reports an error in the middle of a stream.
+func (s *Server) searchStream(w http.ResponseWriter, requestID, customerID, loginCustomerID string, request *services.SearchGoogleAdsStreamRequest) {
+	batches, failure := s.streamBatches(customerID, loginCustomerID, request)
+	if failure != nil && failure.AfterBatches <= 0 {
+		body, err := failureJSON(requestID, failure, nil)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(failure.StatusCode)
+		fmt.Fprintf(w, "[%s]", body)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, "[")
+	for i, batch := range batches {
+		body, err := protojson.Marshal(&services.SearchGoogleAdsStreamResponse{Results: batch, RequestId: requestID})
+		if err != nil {
+			return
+		}
+		if i > 0 {
+			fmt.Fprint(w, ",")
+		}
+		w.Write(body)
+	}
+	if failure != nil {
+		body, err := failureJSON(requestID, failure, nil)
+		if err != nil {
+			return
+		}
+		fmt.Fprintf(w, ",%s", body)
+	}
+	fmt.Fprint(w, "]")
+}
+
+// streamBatches splits the matching rows into batches of StreamBatchSize rows. With
+// a failure, the batches are the ones to send before it.
+func (s *Server) streamBatches(customerID, loginCustomerID string, request *services.SearchGoogleAdsStreamRequest) ([][]*services.GoogleAdsRow, *Failure) {
+	customerID = normalizeCustomerID(customerID)
+	query := NormalizeQuery(request.GetQuery())
+	s.record(Request{
+		Method:          MethodSearchStream,
+		CustomerID:      customerID,
+		LoginCustomerID: loginCustomerID,
+		Query:           query,
+	})
+
+	rows, failure := s.lookup(customerID, query)
+	if failure != nil && failure.AfterBatches <= 0 {
+		return nil, failure
+	}
+
+	size := s.StreamBatchSize
+	if size <= 0 {
+		size = max(len(rows), 1)
+	}
+	batches := [][]*services.GoogleAdsRow{nil}
+	if len(rows) > 0 {
+		batches = batches[:0]
+		for start := 0; start < len(rows); start += size {
+			batches = append(batches, rows[start:min(start+size, len(rows))])
+		}
+	}
+
+	if failure != nil && failure.AfterBatches < len(batches) {
+		batches = batches[:failure.AfterBatches]
+	}
+	return batches, failure
+}
+
+func parsePageToken(token string) (int, error) {
+	offset, ok := strings.CutPrefix(token, "page-")
+	if !ok {
+		return 0, fmt.Errorf("unknown page token %q", token)
+	}
+	return strconv.Atoi(offset)
+}
+
+func resourceKey(resource string) string {
+	return "FROM " + strings.ToLower(resource)
+}
+
+func normalizeCustomerID(customerID string) string {
+	customerID = strings.TrimPrefix(strings.TrimSpace(customerID), "customers/")
+	return strings.ReplaceAll(customerID, "-", "")
+}
+
+// isCustomerID reports whether a customer ID in a URL path is the 10 digits the API
+// accepts; dashed IDs are rejected there, as the API does.
+func isCustomerID(customerID string) bool {
+	if len(customerID) != 10 {
+		return false
+	}
+	for _, r := range customerID {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
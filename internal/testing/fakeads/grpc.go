@@ -0,0 +1,155 @@
+package fakeads
+
+import (
+	"context"
+	"net"
+
+	"google-ads-mcp/internal/infrastructure/api/transport"
+	"google-ads-mcp/internal/infrastructure/auth"
+
+	pberrors "github.com/shenzhencenter/google-ads-pb/errors"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// bufferSize is the in-memory buffer of the gRPC connection.
+const bufferSize = 1 << 20
+
+// grpcService serves GoogleAdsService over gRPC from the same fixtures, failures
+// and tokens as the REST API.
+type grpcService struct {
+	services.UnimplementedGoogleAdsServiceServer
+	server *Server
+}
+
+// grpcCampaignService serves CampaignService over gRPC like campaigns:mutate.
+type grpcCampaignService struct {
+	services.UnimplementedCampaignServiceServer
+	service *grpcService
+}
+
+// DialGRPC serves GoogleAdsService and CampaignService over an in-memory gRPC connection and returns a
+// client connection to it whose RPCs carry tokens from tokenProvider, e.g. a token
+// manager built from ServiceAccountJSON. The fake stops serving on Close.
+func (s *Server) DialGRPC(tokenProvider auth.TokenProvider) (*grpc.ClientConn, error) {
+	listener := bufconn.Listen(bufferSize)
+	s.grpcServer = grpc.NewServer()
+	service := &grpcService{server: s}
+	services.RegisterGoogleAdsServiceServer(s.grpcServer, service)
+	services.RegisterCampaignServiceServer(s.grpcServer, &grpcCampaignService{service: service})
+	go s.grpcServer.Serve(listener)
+
+	return grpc.NewClient("passthrough:///fakeads",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(transport.NewTokenCredentials(tokenProvider, false)),
+	)
+}
+
+func (g *grpcService) Search(ctx context.Context, request *services.SearchGoogleAdsRequest) (*services.SearchGoogleAdsResponse, error) {
+	requestID, loginCustomerID, err := g.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, failure := g.server.searchPage(request.GetCustomerId(), loginCustomerID, request)
+	if failure != nil {
+		return nil, grpcError(requestID, failure, nil)
+	}
+	return response, nil
+}
+
+func (g *grpcService) SearchStream(request *services.SearchGoogleAdsStreamRequest, stream grpc.ServerStreamingServer[services.SearchGoogleAdsStreamResponse]) error {
+	requestID, loginCustomerID, err := g.begin(stream.Context())
+	if err != nil {
+		return err
+	}
+
+	batches, failure := g.server.streamBatches(request.GetCustomerId(), loginCustomerID, request)
+	for _, batch := range batches {
+		if err := stream.Send(&services.SearchGoogleAdsStreamResponse{Results: batch, RequestId: requestID}); err != nil {
+			return err
+		}
+	}
+	if failure != nil {
+		return grpcError(requestID, failure, nil)
+	}
+	return nil
+}
+
+func (g *grpcService) Mutate(ctx context.Context, request *services.MutateGoogleAdsRequest) (*services.MutateGoogleAdsResponse, error) {
+	requestID, loginCustomerID, err := g.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, failure, location := g.server.mutateResponse(MethodMutate, requestID, request.GetCustomerId(), loginCustomerID, request)
+	if failure != nil {
+		return nil, grpcError(requestID, failure, location)
+	}
+	return response, nil
+}
+
+func (c *grpcCampaignService) MutateCampaigns(ctx context.Context, request *services.MutateCampaignsRequest) (*services.MutateCampaignsResponse, error) {
+	requestID, loginCustomerID, err := c.service.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response, failure, location := c.service.server.mutateCampaignsResponse(requestID, request.GetCustomerId(), loginCustomerID, request)
+	if failure != nil {
+		return nil, grpcError(requestID, failure, location)
+	}
+	return response, nil
+}
+
+// begin sends the request ID header and authenticates the call like a REST request.
+// It returns the request ID and the login customer ID.
+func (g *grpcService) begin(ctx context.Context) (string, string, error) {
+	requestID := g.server.nextRequestID()
+	if err := grpc.SetHeader(ctx, metadata.Pairs("request-id", requestID)); err != nil {
+		return "", "", err
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	if failure := g.server.authenticate(firstValue(md, "authorization"), firstValue(md, "developer-token")); failure != nil {
+		return "", "", grpcError(requestID, failure, nil)
+	}
+	return requestID, firstValue(md, "login-customer-id"), nil
+}
+
+// grpcError encodes the failure as the RPC status the API returns, carrying the
+// GoogleAdsFailure in its details.
+func grpcError(requestID string, failure *Failure, location *pberrors.ErrorLocation) error {
+	rpcStatus := &status.Status{
+		Code:    code.Code_value[statusName(failure.StatusCode)],
+		Message: failure.Message,
+	}
+	if failure.ErrorCode != nil {
+		detail, err := anypb.New(&pberrors.GoogleAdsFailure{
+			Errors:    []*pberrors.GoogleAdsError{failure.googleAdsError(location)},
+			RequestId: requestID,
+		})
+		if err != nil {
+			return err
+		}
+		rpcStatus.Details = append(rpcStatus.Details, detail)
+	}
+	return grpcstatus.ErrorProto(rpcStatus)
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
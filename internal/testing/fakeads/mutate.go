@@ -0,0 +1,194 @@
+package fakeads
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+
+	pberrors "github.com/shenzhencenter/google-ads-pb/errors"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// FailOperation fails the mutate operation at index of every later mutate request.
+// With partial_failure set the other operations still succeed and the error is
+// reported in partial_failure_error; otherwise the whole request fails.
+func (s *Server) FailOperation(index int, failure *Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operationFails[index] = failure
+}
+
+// mutate answers every operation with the resource name it updates, removes or
+// creates. Created resources get generated IDs. validate_only requests return no
+// results, as the API does.
+func (s *Server) mutate(w http.ResponseWriter, requestID, customerID, loginCustomerID string, request *services.MutateGoogleAdsRequest) {
+	response, failure, location := s.mutateResponse(MethodMutate, requestID, customerID, loginCustomerID, request)
+	if failure != nil {
+		writeFailureAt(w, requestID, failure, location)
+		return
+	}
+	writeMessage(w, response)
+}
+
+// mutateCampaigns answers campaigns:mutate like a googleAds:mutate of the same
+// campaign operations.
+func (s *Server) mutateCampaigns(w http.ResponseWriter, requestID, customerID, loginCustomerID string, request *services.MutateCampaignsRequest) {
+	response, failure, location := s.mutateCampaignsResponse(requestID, customerID, loginCustomerID, request)
+	if failure != nil {
+		writeFailureAt(w, requestID, failure, location)
+		return
+	}
+	writeMessage(w, response)
+}
+
+func (s *Server) mutateCampaignsResponse(requestID, customerID, loginCustomerID string, request *services.MutateCampaignsRequest) (*services.MutateCampaignsResponse, *Failure, *pberrors.ErrorLocation) {
+	mutateRequest := &services.MutateGoogleAdsRequest{
+		CustomerId:     request.GetCustomerId(),
+		PartialFailure: request.GetPartialFailure(),
+		ValidateOnly:   request.GetValidateOnly(),
+	}
+	for _, operation := range request.GetOperations() {
+		mutateRequest.MutateOperations = append(mutateRequest.MutateOperations, &services.MutateOperation{
+			Operation: &services.MutateOperation_CampaignOperation{CampaignOperation: operation},
+		})
+	}
+
+	mutateResponse, failure, location := s.mutateResponse(MethodMutateCampaigns, requestID, customerID, loginCustomerID, mutateRequest)
+	if failure != nil {
+		return nil, failure, location
+	}
+
+	response := &services.MutateCampaignsResponse{PartialFailureError: mutateResponse.GetPartialFailureError()}
+	for _, result := range mutateResponse.GetMutateOperationResponses() {
+		response.Results = append(response.Results, &services.MutateCampaignResult{
+			ResourceName: result.GetCampaignResult().GetResourceName(),
+		})
+	}
+	return response, nil, nil
+}
+
+// mutateResponse applies request, or returns the failure of the whole request and
+// the operation it points at, if any.
+func (s *Server) mutateResponse(method, requestID, customerID, loginCustomerID string, request *services.MutateGoogleAdsRequest) (*services.MutateGoogleAdsResponse, *Failure, *pberrors.ErrorLocation) {
+	customerID = normalizeCustomerID(customerID)
+	s.record(Request{
+		Method:          method,
+		CustomerID:      customerID,
+		LoginCustomerID: loginCustomerID,
+		Mutate:          request,
+	})
+
+	if failure := s.customerFailure(customerID); failure != nil {
+		return nil, failure, nil
+	}
+
+	s.mu.Lock()
+	operationFails := maps.Clone(s.operationFails)
+	s.mu.Unlock()
+
+	response := &services.MutateGoogleAdsResponse{}
+	var partialFailure pberrors.GoogleAdsFailure
+	for i, operation := range request.GetMutateOperations() {
+		if failure := operationFails[i]; failure != nil {
+			if !request.GetPartialFailure() {
+				return nil, failure, operationLocation(method, i)
+			}
+			partialFailure.Errors = append(partialFailure.Errors, failure.googleAdsError(operationLocation(method, i)))
+			response.MutateOperationResponses = append(response.MutateOperationResponses, &services.MutateOperationResponse{})
+			continue
+		}
+
+		result, err := s.operationResult(customerID, operation)
+		if err != nil {
+			return nil, InvalidArgument(err.Error()), operationLocation(method, i)
+		}
+		response.MutateOperationResponses = append(response.MutateOperationResponses, result)
+	}
+
+	if request.GetValidateOnly() {
+		response.MutateOperationResponses = nil
+	}
+
+	if len(partialFailure.Errors) > 0 {
+		partialFailure.RequestId = requestID
+		detail, err := anypb.New(&partialFailure)
+		if err != nil {
+			return nil, &Failure{StatusCode: http.StatusInternalServerError, Message: err.Error()}, nil
+		}
+		response.PartialFailureError = &status.Status{
+			Code:    int32(code.Code_INVALID_ARGUMENT),
+			Message: fmt.Sprintf("Multiple errors in 'details'. First error: %s", partialFailure.Errors[0].GetMessage()),
+			Details: []*anypb.Any{detail},
+		}
+	}
+
+	return response, nil, nil
+}
+
+// operationResult builds the response of one operation. A MutateOperation sets one
+// "<resource>_operation" field; its response sets the matching "<resource>_result"
+// to the resource name of the created, updated or removed resource.
+func (s *Server) operationResult(customerID string, operation *services.MutateOperation) (*services.MutateOperationResponse, error) {
+	message := operation.ProtoReflect()
+	field := message.WhichOneof(message.Descriptor().Oneofs().ByName("operation"))
+	if field == nil {
+		return nil, fmt.Errorf("mutate operation is empty")
+	}
+	resource := strings.TrimSuffix(string(field.Name()), "_operation")
+
+	resourceOperation := message.Get(field).Message()
+	action := resourceOperation.WhichOneof(resourceOperation.Descriptor().Oneofs().ByName("operation"))
+	if action == nil {
+		return nil, fmt.Errorf("%s has no create, update or remove", field.Name())
+	}
+
+	var resourceName string
+	switch action.Name() {
+	case "remove":
+		resourceName = resourceOperation.Get(action).String()
+	default:
+		resourceName = resourceOperation.Get(action).Message().Get(action.Message().Fields().ByName("resource_name")).String()
+	}
+	if resourceName == "" {
+		resourceName = s.createdResourceName(customerID, resource)
+	}
+
+	response := &services.MutateOperationResponse{}
+	responseMessage := response.ProtoReflect()
+	resultField := responseMessage.Descriptor().Fields().ByName(protoreflect.Name(resource + "_result"))
+	if resultField == nil {
+		return nil, fmt.Errorf("%s is not supported", field.Name())
+	}
+	result := responseMessage.NewField(resultField).Message()
+	result.Set(resultField.Message().Fields().ByName("resource_name"), protoreflect.ValueOfString(resourceName))
+	responseMessage.Set(resultField, protoreflect.ValueOfMessage(result))
+
+	return response, nil
+}
+
+// createdResourceName generates the resource name of a created resource, e.g.
+// customers/123/campaignCriteria/1001.
+func (s *Server) createdResourceName(customerID, resource string) string {
+	s.mu.Lock()
+	s.createdCount++
+	id := 1000 + s.createdCount
+	s.mu.Unlock()
+
+	parts := strings.Split(resource, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+	}
+	collection := strings.Join(parts, "")
+	if trimmed, ok := strings.CutSuffix(collection, "Criterion"); ok {
+		collection = trimmed + "Criteria"
+	} else {
+		collection += "s"
+	}
+
+	return fmt.Sprintf("customers/%s/%s/%d", customerID, collection, id)
+}
@@ -0,0 +1,99 @@
+package fakeads
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const jwtBearerGrant = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+func generateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 2048)
+}
+
+// ServiceAccountJSON returns a service account key whose token_uri points at the
+// fake, so the real token manager signs assertions the fake exchanges for tokens.
+func (s *Server) ServiceAccountJSON() string {
+	der, err := x509.MarshalPKCS8PrivateKey(s.key)
+	if err != nil {
+		panic("fakeads: encoding service account key: " + err.Error())
+	}
+
+	data, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "fake-project",
+		"private_key_id": "fake-key",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "fake@fake-project.iam.gserviceaccount.com",
+		"client_id":      "1",
+		"token_uri":      s.URL() + "/token",
+	})
+	if err != nil {
+		panic("fakeads: encoding service account: " + err.Error())
+	}
+	return string(data)
+}
+
+// RevokeTokens invalidates every access token issued so far. Clients that cached
+// one get authentication errors until they fetch a new token.
+func (s *Server) RevokeTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token := range s.tokens {
+		s.tokens[token] = false
+	}
+}
+
+// handleToken exchanges a JWT bearer assertion signed with the service account key
+// for a fake access token.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request", "error_description": err.Error()})
+		return
+	}
+	if grantType := r.PostForm.Get("grant_type"); grantType != jwtBearerGrant {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type", "error_description": grantType})
+		return
+	}
+	if err := verifyAssertion(r.PostForm.Get("assertion"), &s.key.PublicKey); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	token := fmt.Sprintf("fake-access-token-%d", len(s.tokens)+1)
+	s.tokens[token] = true
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	})
+}
+
+// verifyAssertion checks the RS256 signature of a JWT.
+func verifyAssertion(assertion string, key *rsa.PublicKey) error {
+	parts := strings.Split(assertion, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed assertion")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return fmt.Errorf("malformed signature: %w", err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,276 @@
+package fakeads
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"google-ads-mcp/internal/app/configs"
+
+	pberrors "github.com/shenzhencenter/google-ads-pb/errors"
+	"github.com/shenzhencenter/google-ads-pb/services"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultCustomerID is the manager account the fake configures as the root customer.
+	DefaultCustomerID = "1234567890"
+	// DefaultDeveloperToken is the developer token the fake expects on every request.
+	DefaultDeveloperToken = "fake-developer-token"
+
+	// defaultPageSize matches the fixed page size of googleAds:search.
+	defaultPageSize = 10000
+)
+
+// Methods recorded in Request.Method.
+const (
+	MethodSearch          = "search"
+	MethodSearchStream    = "searchStream"
+	MethodMutate          = "mutate"
+	MethodMutateCampaigns = "mutateCampaigns"
+)
+
+// Server is an in-process Google Ads REST API. It serves googleAds:search,
+// googleAds:searchStream, googleAds:mutate and campaigns:mutate from canned rows
+// keyed by GAQL,
+// issues OAuth tokens for a generated service account and rejects requests
+// without a valid token or developer token, like the real API.
+type Server struct {
+	server     *httptest.Server
+	grpcServer *grpc.Server
+	key        *rsa.PrivateKey
+
+	// PageSize splits search results into pages of this many rows instead of the
+	// API's fixed 10,000, so tests can page through a few fixture rows.
+	PageSize int
+	// StreamBatchSize splits searchStream results into batches of this many rows;
+	// 0 sends every row in one batch.
+	StreamBatchSize int
+
+	mu             sync.Mutex
+	tokens         map[string]bool
+	fixtures       map[fixtureKey][]*services.GoogleAdsRow
+	failures       map[fixtureKey]*Failure
+	operationFails map[int]*Failure
+	quota          int
+	quotaDelay     time.Duration
+	requests       []Request
+	requestCount   int
+	createdCount   int
+}
+
+// Request is a Google Ads API call received by the fake.
+type Request struct {
+	Method          string
+	CustomerID      string
+	LoginCustomerID string
+	Query           string // normalized GAQL of search requests
+	PageToken       string
+	// Mutate holds the operations of a mutate; campaigns:mutate operations are
+	// wrapped in the MutateOperations of a googleAds:mutate request.
+	Mutate *services.MutateGoogleAdsRequest
+}
+
+// New starts a fake Google Ads API. Close it when the test ends.
+func New() *Server {
+	key, err := generateKey()
+	if err != nil {
+		panic("fakeads: generating service account key: " + err.Error())
+	}
+
+	s := &Server{
+		key:            key,
+		tokens:         make(map[string]bool),
+		fixtures:       make(map[fixtureKey][]*services.GoogleAdsRow),
+		failures:       make(map[fixtureKey]*Failure),
+		operationFails: make(map[int]*Failure),
+		quota:          -1,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", s.handleToken)
+	mux.HandleFunc("POST /{version}/customers/{customerID}/{method}", s.handleAPI)
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+// URL is the base URL to configure as GOOGLE_ADS_BASE_URL.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+func (s *Server) Close() {
+	s.server.Close()
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
+
+// Configs returns a configuration that points every tool at the fake, with the
+// service account, developer token and limits the server defaults to. The search
+// cache is disabled so every tool call reaches the fake; set CacheConfig to test it.
+func (s *Server) Configs() configs.Configs {
+	return configs.Configs{
+		ServerConfig: configs.ServerConfig{
+			BindAddress: "127.0.0.1:0",
+			Path:        "/mcp",
+		},
+		GoogleAdsConfig: configs.GoogleAdsConfig{
+			CustomerID:         DefaultCustomerID,
+			DeveloperToken:     DefaultDeveloperToken,
+			ServiceAccountJSON: s.ServiceAccountJSON(),
+		},
+		SearchConfig: configs.SearchConfig{MaxFetchAllRows: 10000},
+		BudgetConfig: configs.BudgetConfig{MaxChangePercent: 50},
+		FanOutConfig: configs.FanOutConfig{MaxConcurrency: 8, AccountTimeout: 60 * time.Second},
+		TransportConfig: configs.TransportConfig{
+			Kind:    "rest",
+			BaseURL: s.URL(),
+		},
+	}
+}
+
+// Requests returns the API calls that passed authentication and the quota, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// SetQuota lets the next requests API calls through and answers every later one
+// with a RESOURCE_EXHAUSTED quota error carrying retryDelay. A negative value
+// removes the limit.
+func (s *Server) SetQuota(requests int, retryDelay time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.quota = requests
+	s.quotaDelay = retryDelay
+}
+
+// handleAPI serves /{version}/customers/{customerID}/{method}. The version is not
+// checked, so the fake answers whatever version the client is configured for.
+func (s *Server) handleAPI(w http.ResponseWriter, r *http.Request) {
+	customerID := r.PathValue("customerID")
+	method := r.PathValue("method")
+
+	requestID := s.nextRequestID()
+	w.Header().Set("request-id", requestID)
+
+	if failure := s.authenticate(r.Header.Get("Authorization"), r.Header.Get("developer-token")); failure != nil {
+		writeFailure(w, requestID, failure)
+		return
+	}
+	if !isCustomerID(customerID) {
+		writeFailure(w, requestID, InvalidArgument(fmt.Sprintf("Invalid customer ID '%s'.", customerID)))
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeFailure(w, requestID, InvalidArgument("reading request body: "+err.Error()))
+		return
+	}
+
+	loginCustomerID := r.Header.Get("login-customer-id")
+	switch method {
+	case "googleAds:search":
+		var request services.SearchGoogleAdsRequest
+		if err := protojson.Unmarshal(body, &request); err != nil {
+			writeFailure(w, requestID, InvalidArgument(err.Error()))
+			return
+		}
+		s.search(w, requestID, customerID, loginCustomerID, &request)
+	case "googleAds:searchStream":
+		var request services.SearchGoogleAdsStreamRequest
+		if err := protojson.Unmarshal(body, &request); err != nil {
+			writeFailure(w, requestID, InvalidArgument(err.Error()))
+			return
+		}
+		s.searchStream(w, requestID, customerID, loginCustomerID, &request)
+	case "googleAds:mutate":
+		var request services.MutateGoogleAdsRequest
+		if err := protojson.Unmarshal(body, &request); err != nil {
+			writeFailure(w, requestID, InvalidArgument(err.Error()))
+			return
+		}
+		s.mutate(w, requestID, customerID, loginCustomerID, &request)
+	case "campaigns:mutate":
+		var request services.MutateCampaignsRequest
+		if err := protojson.Unmarshal(body, &request); err != nil {
+			writeFailure(w, requestID, InvalidArgument(err.Error()))
+			return
+		}
+		s.mutateCampaigns(w, requestID, customerID, loginCustomerID, &request)
+	default:
+		writeFailure(w, requestID, NotFound(method))
+	}
+}
+
+// authenticate checks the OAuth token of the authorization header, the developer
+// token and the quota.
+func (s *Server) authenticate(authorization, developerToken string) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || !s.tokens[token] {
+		return &Failure{
+			StatusCode: http.StatusUnauthorized,
+			ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_AuthenticationError{AuthenticationError: pberrors.AuthenticationErrorEnum_OAUTH_TOKEN_INVALID}},
+			Message:    "Oauth token is invalid.",
+		}
+	}
+	if developerToken != DefaultDeveloperToken {
+		return &Failure{
+			StatusCode: http.StatusUnauthorized,
+			ErrorCode:  &pberrors.ErrorCode{ErrorCode: &pberrors.ErrorCode_AuthenticationError{AuthenticationError: pberrors.AuthenticationErrorEnum_DEVELOPER_TOKEN_INVALID}},
+			Message:    "The developer token is not valid.",
+		}
+	}
+
+	if s.quota == 0 {
+		return QuotaError(s.quotaDelay)
+	}
+	if s.quota > 0 {
+		s.quota--
+	}
+	return nil
+}
+
+func (s *Server) record(request Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, request)
+}
+
+func (s *Server) nextRequestID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requestCount++
+	return fmt.Sprintf("fake-request-%d", s.requestCount)
+}
+
+func writeMessage(w http.ResponseWriter, message proto.Message) {
+	body, err := protojson.Marshal(message)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(body)
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,11 @@
+package accountsummary
+
+// ToolInput defines the parameters accepted by the MCP tool.
+type ToolInput struct {
+	CustomerIDs       []string `json:"customer_ids" validate:"required,min=1"`
+	LoginCustomerID   string   `json:"login_customer_id,omitempty"`
+	DateRangeStart    string   `json:"date_range_start,omitempty"`
+	DateRangeEnd      string   `json:"date_range_end,omitempty"`
+	ReportingCurrency string   `json:"reporting_currency,omitempty"`
+	MoneyFormat       string   `json:"money_format,omitempty" validate:"omitempty,oneof=micros decimal both"`
+}
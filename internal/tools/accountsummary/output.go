@@ -0,0 +1,97 @@
+package accountsummary
+
+// ToolOutput captures the structured response returned to the MCP client.
+type ToolOutput struct {
+	DateRangeStart    string                `json:"date_range_start,omitempty"`
+	DateRangeEnd      string                `json:"date_range_end,omitempty"`
+	Accounts          []AccountOutput       `json:"accounts"`
+	Rollup            RollupOutput          `json:"rollup"`
+	FailedAccounts    []FailedAccountOutput `json:"failed_accounts,omitempty"`
+	ReportingCurrency string                `json:"reporting_currency,omitempty"`
+}
+
+// AccountOutput holds the account-level totals of a single customer.
+type AccountOutput struct {
+	CustomerID      string           `json:"customer_id"`
+	Name            string           `json:"name"`
+	CurrencyCode    string           `json:"currency_code"`
+	TimeZone        string           `json:"time_zone"`
+	DateRangeStart  string           `json:"date_range_start,omitempty"`
+	DateRangeEnd    string           `json:"date_range_end,omitempty"`
+	Metrics         AccountMetrics   `json:"metrics"`
+	Converted       *ConvertedOutput `json:"converted,omitempty"`
+	ConversionError string           `json:"conversion_error,omitempty"`
+}
+
+// ConvertedOutput holds the money metrics of an account in the reporting currency.
+// RateDate is empty when the account already reports in that currency.
+type ConvertedOutput struct {
+	CurrencyCode            string  `json:"currency_code"`
+	Rate                    float64 `json:"rate"`
+	RateDate                string  `json:"rate_date,omitempty"`
+	CostMicros              *int64  `json:"cost_micros,omitempty"`
+	Cost                    string  `json:"cost,omitempty"`
+	ConversionsValue        float64 `json:"conversions_value"`
+	AverageCPC              *int64  `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount        string  `json:"average_cpc,omitempty"`
+	CostPerConversion       *int64  `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount string  `json:"cost_per_conversion,omitempty"`
+}
+
+// ConvertedRollupOutput sums the converted money metrics of the accounts that could be converted.
+type ConvertedRollupOutput struct {
+	CurrencyCode            string   `json:"currency_code"`
+	CostMicros              *int64   `json:"cost_micros,omitempty"`
+	Cost                    string   `json:"cost,omitempty"`
+	ConversionsValue        float64  `json:"conversions_value"`
+	AverageCPC              *int64   `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount        string   `json:"average_cpc,omitempty"`
+	CostPerConversion       *int64   `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount string   `json:"cost_per_conversion,omitempty"`
+	RateDates               []string `json:"rate_dates,omitempty"`
+	UnconvertedAccounts     []string `json:"unconverted_accounts,omitempty"`
+}
+
+// RollupOutput sums the metrics of every account that succeeded.
+// Metrics amounts are added in each account's own currency, so they are only
+// returned, labelled with CurrencyCode, when every account shares one; Converted
+// holds the totals in the reporting currency.
+type RollupOutput struct {
+	AccountCount   int                    `json:"account_count"`
+	SucceededCount int                    `json:"succeeded_count"`
+	FailedCount    int                    `json:"failed_count"`
+	CurrencyCode   string                 `json:"currency_code,omitempty"`
+	Metrics        AccountMetrics         `json:"metrics"`
+	Converted      *ConvertedRollupOutput `json:"converted,omitempty"`
+}
+
+// FailedAccountOutput reports an account that could not be queried.
+type FailedAccountOutput struct {
+	CustomerID string `json:"customer_id"`
+	Error      string `json:"error"`
+}
+
+// AccountMetrics represents account-level performance metrics. Money amounts are
+// returned in micros, as decimal amounts in the account currency, or both, per money_format.
+type AccountMetrics struct {
+	Clicks                   int64   `json:"clicks"`
+	Impressions              int64   `json:"impressions"`
+	CTR                      float64 `json:"ctr"`
+	AverageCPC               *int64  `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount         string  `json:"average_cpc,omitempty"`
+	CostMicros               *int64  `json:"cost_micros,omitempty"`
+	Cost                     string  `json:"cost,omitempty"`
+	Conversions              float64 `json:"conversions"`
+	ConversionsValue         float64 `json:"conversions_value,omitempty"`
+	CostPerConversion        *int64  `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount  string  `json:"cost_per_conversion,omitempty"`
+	Interactions             int64   `json:"interactions"`
+	ROAS                     float64 `json:"roas,omitempty"`
+	CPAMicros                *int64  `json:"cpa_micros,omitempty"`
+	CPA                      string  `json:"cpa,omitempty"`
+	CPMMicros                *int64  `json:"cpm_micros,omitempty"`
+	CPM                      string  `json:"cpm,omitempty"`
+	CostPerInteractionMicros *int64  `json:"cost_per_interaction_micros,omitempty"`
+	CostPerInteraction       string  `json:"cost_per_interaction,omitempty"`
+	ValuePerClick            float64 `json:"value_per_click,omitempty"`
+}
@@ -0,0 +1,199 @@
+package accountsummary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/accountsummary"
+	"google-ads-mcp/internal/infrastructure/api/currency"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var validate = validator.New()
+
+type Tool struct {
+	service *accountsummary.Service
+}
+
+func NewAccountSummaryTool(service *accountsummary.Service) *Tool {
+	return &Tool{
+		service: service,
+	}
+}
+
+func (t *Tool) AccountSummary(ctx context.Context, req *mcp.CallToolRequest, input ToolInput) (*mcp.CallToolResult, ToolOutput, error) {
+	if req.Params.Arguments == nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("accountsummary: arguments payload is required")
+	}
+
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("accountsummary: validation error: %w", err)
+	}
+
+	moneyFormat, err := currency.ParseMoneyFormat(input.MoneyFormat)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("accountsummary: %w", err)
+	}
+
+	result, err := t.service.Summarize(ctx, mapInputToFilters(input))
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, err
+	}
+
+	output := mapResult(result)
+	formatMoney(&output, moneyFormat)
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("accountsummary: marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, output, nil
+}
+
+func mapInputToFilters(input ToolInput) accountsummary.Filters {
+	return accountsummary.Filters{
+		CustomerIDs:       input.CustomerIDs,
+		LoginCustomerID:   input.LoginCustomerID,
+		DateRangeStart:    input.DateRangeStart,
+		DateRangeEnd:      input.DateRangeEnd,
+		ReportingCurrency: input.ReportingCurrency,
+	}
+}
+
+func mapResult(result accountsummary.Result) ToolOutput {
+	output := ToolOutput{
+		DateRangeStart: result.DateRangeStart,
+		DateRangeEnd:   result.DateRangeEnd,
+		Accounts:       make([]AccountOutput, 0, len(result.Accounts)),
+		Rollup: RollupOutput{
+			AccountCount:   result.Rollup.AccountCount,
+			SucceededCount: result.Rollup.SucceededCount,
+			FailedCount:    result.Rollup.FailedCount,
+			Metrics:        mapMetrics(result.Rollup.Metrics),
+			Converted:      mapConvertedRollup(result.Rollup.Converted),
+		},
+		ReportingCurrency: result.ReportingCurrency,
+	}
+
+	for _, account := range result.Accounts {
+		if account.Err != nil {
+			output.FailedAccounts = append(output.FailedAccounts, FailedAccountOutput{
+				CustomerID: account.CustomerID,
+				Error:      account.Err.Error(),
+			})
+			continue
+		}
+
+		accountOutput := AccountOutput{
+			CustomerID:     account.Value.CustomerID,
+			Name:           account.Value.Name,
+			CurrencyCode:   account.Value.CurrencyCode,
+			TimeZone:       account.Value.TimeZone,
+			DateRangeStart: account.Value.DateRangeStart,
+			DateRangeEnd:   account.Value.DateRangeEnd,
+			Metrics:        mapMetrics(account.Value.Metrics),
+			Converted:      mapConverted(account.Value.Converted),
+		}
+		if account.Value.ConversionErr != nil {
+			accountOutput.ConversionError = account.Value.ConversionErr.Error()
+		}
+		output.Accounts = append(output.Accounts, accountOutput)
+	}
+
+	return output
+}
+
+// formatMoney formats the converted amounts in the reporting currency and every
+// account in its own currency. The native rollup is labelled with the currency
+// when all accounts share one; otherwise its money amounts are omitted.
+func formatMoney(output *ToolOutput, format currency.MoneyFormat) {
+	if output.ReportingCurrency != "" {
+		for _, account := range output.Accounts {
+			currency.Apply(account.Converted, format, output.ReportingCurrency)
+		}
+		currency.Apply(output.Rollup.Converted, format, output.ReportingCurrency)
+	}
+
+	codes := make(map[string]struct{})
+	for i := range output.Accounts {
+		currency.Apply(&output.Accounts[i], format, output.Accounts[i].CurrencyCode)
+		if output.Accounts[i].CurrencyCode != "" {
+			codes[output.Accounts[i].CurrencyCode] = struct{}{}
+		}
+	}
+	switch {
+	case len(codes) == 1:
+		for code := range codes {
+			output.Rollup.CurrencyCode = code
+			currency.Apply(&output.Rollup.Metrics, format, code)
+		}
+	case len(codes) > 1:
+		omitMoney(&output.Rollup.Metrics)
+	}
+}
+
+// omitMoney drops the money amounts and ratios of metrics added across currencies.
+func omitMoney(metrics *AccountMetrics) {
+	currency.Omit(metrics)
+	metrics.ConversionsValue = 0
+	metrics.ROAS = 0
+	metrics.ValuePerClick = 0
+}
+
+func mapConverted(converted *accountsummary.Converted) *ConvertedOutput {
+	if converted == nil {
+		return nil
+	}
+
+	return &ConvertedOutput{
+		CurrencyCode:      converted.Rate.To,
+		Rate:              converted.Rate.Value,
+		RateDate:          converted.Rate.Date,
+		CostMicros:        currency.Micros(converted.CostMicros),
+		ConversionsValue:  converted.ConversionsValue,
+		AverageCPC:        currency.Micros(converted.AverageCPC),
+		CostPerConversion: currency.RoundMicros(converted.CostPerConversion),
+	}
+}
+
+func mapConvertedRollup(converted *accountsummary.ConvertedRollup) *ConvertedRollupOutput {
+	if converted == nil {
+		return nil
+	}
+
+	return &ConvertedRollupOutput{
+		CurrencyCode:        converted.CurrencyCode,
+		CostMicros:          currency.Micros(converted.CostMicros),
+		ConversionsValue:    converted.ConversionsValue,
+		AverageCPC:          currency.Micros(converted.AverageCPC),
+		CostPerConversion:   currency.RoundMicros(converted.CostPerConversion),
+		RateDates:           converted.RateDates,
+		UnconvertedAccounts: converted.UnconvertedAccounts,
+	}
+}
+
+func mapMetrics(metrics accountsummary.Metrics) AccountMetrics {
+	return AccountMetrics{
+		Clicks:                   metrics.Clicks,
+		Impressions:              metrics.Impressions,
+		CTR:                      metrics.CTR,
+		AverageCPC:               currency.Micros(metrics.AverageCPC),
+		CostMicros:               currency.Micros(metrics.CostMicros),
+		Conversions:              metrics.Conversions,
+		ConversionsValue:         metrics.ConversionsValue,
+		CostPerConversion:        currency.RoundMicros(metrics.CostPerConversion),
+		Interactions:             metrics.Interactions,
+		ROAS:                     metrics.KPIs.ROAS,
+		CPAMicros:                currency.Micros(metrics.KPIs.CPAMicros),
+		CPMMicros:                currency.Micros(metrics.KPIs.CPMMicros),
+		CostPerInteractionMicros: currency.Micros(metrics.KPIs.CostPerInteractionMicros),
+		ValuePerClick:            metrics.KPIs.ValuePerClick,
+	}
+}
@@ -0,0 +1,18 @@
+package addnegativekeywords
+
+// ToolInput defines the parameters accepted by the MCP tool.
+type ToolInput struct {
+	CustomerID      string                 `json:"customer_id" validate:"required"`
+	LoginCustomerID string                 `json:"login_customer_id,omitempty"`
+	CampaignID      string                 `json:"campaign_id,omitempty"`
+	AdGroupID       string                 `json:"ad_group_id,omitempty"`
+	SharedSetID     string                 `json:"shared_set_id,omitempty"`
+	Keywords        []NegativeKeywordInput `json:"keywords" validate:"required,min=1,dive"`
+	ValidateOnly    bool                   `json:"validate_only,omitempty"`
+}
+
+// NegativeKeywordInput is a negative keyword to add. MatchType is EXACT, PHRASE or BROAD (default EXACT).
+type NegativeKeywordInput struct {
+	Text      string `json:"text" validate:"required"`
+	MatchType string `json:"match_type,omitempty"`
+}
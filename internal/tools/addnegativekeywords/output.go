@@ -0,0 +1,45 @@
+package addnegativekeywords
+
+// ToolOutput captures the structured response returned to the MCP client.
+type ToolOutput struct {
+	Targets       []TargetOutput          `json:"targets"`
+	ValidateOnly  bool                    `json:"validate_only"`
+	Results       []OperationResultOutput `json:"results"`
+	RequestErrors []OperationErrorOutput  `json:"request_errors,omitempty"`
+	Skipped       []SkippedKeywordOutput  `json:"skipped,omitempty"`
+	AddedCount    int                     `json:"added_count"`
+	FailedCount   int                     `json:"failed_count"`
+	SkippedCount  int                     `json:"skipped_count"`
+	RequestID     string                  `json:"request_id,omitempty"`
+}
+
+// TargetOutput is a campaign, ad group or shared set the keywords were added to.
+type TargetOutput struct {
+	Level        string `json:"level"`
+	ResourceName string `json:"resource_name"`
+}
+
+// OperationResultOutput reports the outcome of the creation of a single negative keyword.
+type OperationResultOutput struct {
+	Index        int                    `json:"index"`
+	Level        string                 `json:"level"`
+	Text         string                 `json:"text"`
+	MatchType    string                 `json:"match_type"`
+	ResourceName string                 `json:"resource_name,omitempty"`
+	Success      bool                   `json:"success"`
+	Errors       []OperationErrorOutput `json:"errors,omitempty"`
+}
+
+// OperationErrorOutput is an API error attached to one operation.
+type OperationErrorOutput struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// SkippedKeywordOutput is a keyword that was not sent because it already exists or repeats.
+type SkippedKeywordOutput struct {
+	Level     string `json:"level,omitempty"`
+	Text      string `json:"text"`
+	MatchType string `json:"match_type"`
+	Reason    string `json:"reason"`
+}
@@ -0,0 +1,145 @@
+package addnegativekeywords
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/addnegativekeywords"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var validate = validator.New()
+
+type Tool struct {
+	service *addnegativekeywords.Service
+}
+
+func NewAddNegativeKeywordsTool(service *addnegativekeywords.Service) *Tool {
+	return &Tool{
+		service: service,
+	}
+}
+
+func (t *Tool) AddNegativeKeywords(ctx context.Context, req *mcp.CallToolRequest, input ToolInput) (*mcp.CallToolResult, ToolOutput, error) {
+	if req.Params.Arguments == nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("addnegativekeywords: arguments payload is required")
+	}
+
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("addnegativekeywords: validation error: %w", err)
+	}
+
+	result, err := t.service.AddNegativeKeywords(ctx, mapInputToRequest(input))
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, err
+	}
+
+	output := ToolOutput{
+		Targets:       mapTargets(result.Targets),
+		ValidateOnly:  result.ValidateOnly,
+		Results:       mapOperationResults(result.Operations),
+		RequestErrors: mapOperationErrors(result.RequestErrors),
+		Skipped:       mapSkippedKeywords(result.Skipped),
+		SkippedCount:  len(result.Skipped),
+		RequestID:     result.RequestID,
+	}
+	for _, operation := range output.Results {
+		if operation.Success {
+			output.AddedCount++
+		} else {
+			output.FailedCount++
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("addnegativekeywords: marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, output, nil
+}
+
+func mapInputToRequest(input ToolInput) addnegativekeywords.Request {
+	keywords := make([]addnegativekeywords.Keyword, 0, len(input.Keywords))
+	for _, keyword := range input.Keywords {
+		keywords = append(keywords, addnegativekeywords.Keyword{
+			Text:      keyword.Text,
+			MatchType: keyword.MatchType,
+		})
+	}
+
+	return addnegativekeywords.Request{
+		CustomerID:      input.CustomerID,
+		LoginCustomerID: input.LoginCustomerID,
+		CampaignID:      input.CampaignID,
+		AdGroupID:       input.AdGroupID,
+		SharedSetID:     input.SharedSetID,
+		Keywords:        keywords,
+		ValidateOnly:    input.ValidateOnly,
+	}
+}
+
+func mapTargets(targets []addnegativekeywords.Target) []TargetOutput {
+	normalized := make([]TargetOutput, 0, len(targets))
+	for _, target := range targets {
+		normalized = append(normalized, TargetOutput{
+			Level:        target.Level,
+			ResourceName: target.ResourceName,
+		})
+	}
+
+	return normalized
+}
+
+func mapOperationResults(operations []addnegativekeywords.OperationResult) []OperationResultOutput {
+	normalized := make([]OperationResultOutput, 0, len(operations))
+	for _, operation := range operations {
+		normalized = append(normalized, OperationResultOutput{
+			Index:        operation.Index,
+			Level:        operation.Level,
+			Text:         operation.Text,
+			MatchType:    operation.MatchType,
+			ResourceName: operation.ResourceName,
+			Success:      operation.Success,
+			Errors:       mapOperationErrors(operation.Errors),
+		})
+	}
+
+	return normalized
+}
+
+func mapOperationErrors(errs []addnegativekeywords.OperationError) []OperationErrorOutput {
+	var normalized []OperationErrorOutput
+	for _, opErr := range errs {
+		normalized = append(normalized, OperationErrorOutput{
+			Code:    opErr.Code,
+			Message: opErr.Message,
+		})
+	}
+
+	return normalized
+}
+
+func mapSkippedKeywords(skipped []addnegativekeywords.SkippedKeyword) []SkippedKeywordOutput {
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	normalized := make([]SkippedKeywordOutput, 0, len(skipped))
+	for _, keyword := range skipped {
+		normalized = append(normalized, SkippedKeywordOutput{
+			Level:     keyword.Level,
+			Text:      keyword.Text,
+			MatchType: keyword.MatchType,
+			Reason:    keyword.Reason,
+		})
+	}
+
+	return normalized
+}
@@ -2,6 +2,12 @@ package listadaccounts
 
 // ToolInput defines the parameters accepted by the MCP tool.
 type ToolInput struct {
-	AccountIDs   []string `json:"account_ids,omitempty"`
-	AccountNames []string `json:"account_names,omitempty"`
+	AccountIDs      []string `json:"account_ids,omitempty"`
+	AccountNames    []string `json:"account_names,omitempty"`
+	PageToken       string   `json:"page_token,omitempty"`
+	PageSize        int32    `json:"page_size,omitempty" validate:"omitempty,min=1,max=10000"`
+	FetchAll        bool     `json:"fetch_all,omitempty"`
+	IncludeManagers bool     `json:"include_managers,omitempty"`
+	MaxDepth        int      `json:"max_depth,omitempty" validate:"omitempty,min=1,max=10"`
+	LoginCustomerID string   `json:"login_customer_id,omitempty"`
 }
@@ -1,18 +1,25 @@
 package listadaccounts
 
+import "google-ads-mcp/internal/infrastructure/api/responsebudget"
+
 // ToolOutput captures the structured response returned to the MCP client.
 type ToolOutput struct {
 	Accounts      []AccountOutput `json:"accounts"`
 	NextPageToken string          `json:"next_page_token,omitempty"`
 	TotalCount    int64           `json:"total_count"`
+	responsebudget.Truncation
 }
 
 // AccountOutput mirrors the normalized account representation returned to clients.
 type AccountOutput struct {
-	CustomerID   string `json:"customer_id"`
-	CustomerName string `json:"customer_name"`
-	CurrencyCode string `json:"currency_code"`
-	TimeZone     string `json:"time_zone"`
-	Status       string `json:"status"`
-	ResourceName string `json:"resource_name"`
+	CustomerID   string   `json:"customer_id"`
+	CustomerName string   `json:"customer_name"`
+	CurrencyCode string   `json:"currency_code"`
+	TimeZone     string   `json:"time_zone"`
+	Status       string   `json:"status"`
+	ResourceName string   `json:"resource_name"`
+	Manager      bool     `json:"manager"`
+	Level        int64    `json:"level"`
+	ParentID     string   `json:"parent_id,omitempty"`
+	Path         []string `json:"path,omitempty"`
 }
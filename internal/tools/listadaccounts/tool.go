@@ -2,12 +2,12 @@ package listadaccounts
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"slices"
 	"strings"
 
 	"google-ads-mcp/internal/infrastructure/api/listadaccounts"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -17,11 +17,13 @@ var validate = validator.New()
 
 type Tool struct {
 	service *listadaccounts.Service
+	budget  responsebudget.Budget
 }
 
-func NewListAdAccountsTool(service *listadaccounts.Service) *Tool {
+func NewListAdAccountsTool(service *listadaccounts.Service, budget responsebudget.Budget) *Tool {
 	return &Tool{
 		service: service,
+		budget:  budget,
 	}
 }
 
@@ -30,6 +32,11 @@ func (t *Tool) ListAdAccounts(ctx context.Context, req *mcp.CallToolRequest, inp
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("listadaccounts: arguments payload is required")
 	}
 
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("listadaccounts: validation error: %w", err)
+	}
+
 	filters := mapInputToFilters(input)
 
 	result, err := t.service.ListAccounts(ctx, filters)
@@ -43,7 +50,7 @@ func (t *Tool) ListAdAccounts(ctx context.Context, req *mcp.CallToolRequest, inp
 		TotalCount:    result.TotalResultsCount,
 	}
 
-	data, err := json.Marshal(output)
+	data, err := responsebudget.Marshal(t.budget, &output, &output.Accounts, &output.Truncation, nil)
 	if err != nil {
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("listadaccounts: marshal response: %v", err)
 	}
@@ -55,8 +62,14 @@ func (t *Tool) ListAdAccounts(ctx context.Context, req *mcp.CallToolRequest, inp
 
 func mapInputToFilters(input ToolInput) listadaccounts.Filters {
 	return listadaccounts.Filters{
-		AccountIDs:   input.AccountIDs,
-		AccountNames: input.AccountNames,
+		AccountIDs:      input.AccountIDs,
+		AccountNames:    input.AccountNames,
+		PageToken:       input.PageToken,
+		PageSize:        input.PageSize,
+		FetchAll:        input.FetchAll,
+		IncludeManagers: input.IncludeManagers,
+		MaxDepth:        input.MaxDepth,
+		LoginCustomerID: input.LoginCustomerID,
 	}
 }
 
@@ -70,6 +83,10 @@ func mapAccounts(accounts []listadaccounts.Account) []AccountOutput {
 			TimeZone:     acc.TimeZone,
 			Status:       strings.ToLower(acc.Status),
 			ResourceName: acc.ResourceName,
+			Manager:      acc.Manager,
+			Level:        acc.Level,
+			ParentID:     acc.ParentID,
+			Path:         acc.Path,
 		})
 	}
 
@@ -0,0 +1,12 @@
+package rungaqlquery
+
+// ToolInput defines the parameters accepted by the MCP tool.
+type ToolInput struct {
+	CustomerID      string `json:"customer_id" validate:"required"`
+	LoginCustomerID string `json:"login_customer_id,omitempty"`
+	Query           string `json:"query" validate:"required"`
+	PageToken       string `json:"page_token,omitempty"`
+	PageSize        int32  `json:"page_size,omitempty" validate:"omitempty,min=1,max=10000"`
+	FetchAll        bool   `json:"fetch_all,omitempty"`
+	BypassCache     bool   `json:"bypass_cache,omitempty"`
+}
@@ -0,0 +1,14 @@
+package rungaqlquery
+
+import "google-ads-mcp/internal/infrastructure/api/responsebudget"
+
+// ToolOutput captures the structured response returned to the MCP client.
+type ToolOutput struct {
+	Resource      string           `json:"resource"`
+	Fields        []string         `json:"fields"`
+	Rows          []map[string]any `json:"rows"`
+	NextPageToken string           `json:"next_page_token,omitempty"`
+	TotalCount    int64            `json:"total_count"`
+	Cache         string           `json:"cache,omitempty"`
+	responsebudget.Truncation
+}
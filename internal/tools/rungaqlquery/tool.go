@@ -0,0 +1,83 @@
+package rungaqlquery
+
+import (
+	"context"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+	"google-ads-mcp/internal/infrastructure/api/rungaqlquery"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var validate = validator.New()
+
+type Tool struct {
+	service *rungaqlquery.Service
+	budget  responsebudget.Budget
+}
+
+func NewRunGAQLQueryTool(service *rungaqlquery.Service, budget responsebudget.Budget) *Tool {
+	return &Tool{
+		service: service,
+		budget:  budget,
+	}
+}
+
+func (t *Tool) RunGAQLQuery(ctx context.Context, req *mcp.CallToolRequest, input ToolInput) (*mcp.CallToolResult, ToolOutput, error) {
+	if req.Params.Arguments == nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("rungaqlquery: arguments payload is required")
+	}
+
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("rungaqlquery: validation error: %w", err)
+	}
+
+	filters := mapInputToFilters(input)
+
+	result, err := t.service.RunQuery(ctx, filters)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, err
+	}
+
+	output := ToolOutput{
+		Resource:      result.Resource,
+		Fields:        result.Fields,
+		Rows:          mapRows(result.Rows),
+		NextPageToken: result.NextPageToken,
+		TotalCount:    result.TotalResultsCount,
+		Cache:         string(result.Cache),
+	}
+
+	data, err := responsebudget.Marshal(t.budget, &output, &output.Rows, &output.Truncation, nil)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("rungaqlquery: marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, output, nil
+}
+
+func mapInputToFilters(input ToolInput) rungaqlquery.Filters {
+	return rungaqlquery.Filters{
+		CustomerID:      input.CustomerID,
+		LoginCustomerID: input.LoginCustomerID,
+		Query:           input.Query,
+		PageToken:       input.PageToken,
+		PageSize:        input.PageSize,
+		FetchAll:        input.FetchAll,
+		BypassCache:     input.BypassCache,
+	}
+}
+
+func mapRows(rows []rungaqlquery.Row) []map[string]any {
+	normalized := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		normalized = append(normalized, row)
+	}
+
+	return normalized
+}
@@ -2,13 +2,30 @@ package searchadgroups
 
 // ToolInput defines the parameters accepted by the MCP tool.
 type ToolInput struct {
-	CustomerID      string   `json:"customer_id" validate:"required"`
-	AdGroupIDs      []string `json:"ad_group_ids,omitempty"`
-	AdGroupNames    []string `json:"ad_group_names,omitempty"`
-	Statuses        []string `json:"statuses,omitempty"`
-	CampaignIDs     []string `json:"campaign_ids,omitempty"`
-	CampaignNames   []string `json:"campaign_names,omitempty"`
-	DateRangeStart  string   `json:"date_range_start,omitempty"`
-	DateRangeEnd    string   `json:"date_range_end,omitempty"`
+	CustomerID            string   `json:"customer_id" validate:"required"`
+	LoginCustomerID       string   `json:"login_customer_id,omitempty"`
+	AdGroupIDs            []string `json:"ad_group_ids,omitempty"`
+	AdGroupNames          []string `json:"ad_group_names,omitempty"`
+	Statuses              []string `json:"statuses,omitempty"`
+	CampaignIDs           []string `json:"campaign_ids,omitempty"`
+	CampaignNames         []string `json:"campaign_names,omitempty"`
+	MinCostMicros         int64    `json:"min_cost_micros,omitempty" validate:"omitempty,min=0"`
+	MinImpressions        int64    `json:"min_impressions,omitempty" validate:"omitempty,min=0"`
+	MinClicks             int64    `json:"min_clicks,omitempty" validate:"omitempty,min=0"`
+	MinConversions        float64  `json:"min_conversions,omitempty" validate:"omitempty,min=0"`
+	MaxCPAMicros          int64    `json:"max_cpa_micros,omitempty" validate:"omitempty,min=0"`
+	OrderBy               string   `json:"order_by,omitempty"`
+	Limit                 int      `json:"limit,omitempty" validate:"omitempty,min=1,max=10000"`
+	DateRangeStart        string   `json:"date_range_start,omitempty"`
+	DateRangeEnd          string   `json:"date_range_end,omitempty"`
+	SegmentBy             string   `json:"segment_by,omitempty"`
+	CompareTo             string   `json:"compare_to,omitempty"`
+	CompareDateRangeStart string   `json:"compare_date_range_start,omitempty"`
+	CompareDateRangeEnd   string   `json:"compare_date_range_end,omitempty"`
+	PageToken             string   `json:"page_token,omitempty"`
+	PageSize              int32    `json:"page_size,omitempty" validate:"omitempty,min=1,max=10000"`
+	FetchAll              bool     `json:"fetch_all,omitempty"`
+	BypassCache           bool     `json:"bypass_cache,omitempty"`
+	MoneyFormat           string   `json:"money_format,omitempty" validate:"omitempty,oneof=micros decimal both"`
+	Fields                []string `json:"fields,omitempty"`
 }
-
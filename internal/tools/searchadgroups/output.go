@@ -1,31 +1,88 @@
 package searchadgroups
 
+import (
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+)
+
 // ToolOutput captures the structured response returned to the MCP client.
 type ToolOutput struct {
-	AdGroups      []AdGroupOutput `json:"ad_groups"`
-	NextPageToken string          `json:"next_page_token,omitempty"`
-	TotalCount    int64           `json:"total_count"`
+	AdGroups         []AdGroupOutput         `json:"ad_groups"`
+	NextPageToken    string                  `json:"next_page_token,omitempty"`
+	TotalCount       int64                   `json:"total_count"`
+	HasMore          bool                    `json:"has_more,omitempty"`
+	Cache            string                  `json:"cache,omitempty"`
+	ComparisonPeriod *ComparisonPeriodOutput `json:"comparison_period,omitempty"`
+	responsebudget.Truncation
+
+	fields projection.Selection
+}
+
+// MarshalJSON serializes only the ad group fields selected by the fields input.
+func (o ToolOutput) MarshalJSON() ([]byte, error) {
+	type toolOutput ToolOutput
+	return o.fields.Marshal(toolOutput(o), "ad_groups")
+}
+
+// ComparisonPeriodOutput describes the date ranges compared when compare_to is set.
+type ComparisonPeriodOutput struct {
+	CurrentStart  string `json:"current_start"`
+	CurrentEnd    string `json:"current_end"`
+	PreviousStart string `json:"previous_start"`
+	PreviousEnd   string `json:"previous_end"`
 }
 
 // AdGroupOutput mirrors the normalized ad group representation returned to clients.
 type AdGroupOutput struct {
-	ID                   string          `json:"id"`
-	ResourceName         string          `json:"resource_name"`
-	Name                 string          `json:"name"`
-	Status               string          `json:"status"`
-	Type                 string          `json:"type"`
-	CampaignID           string          `json:"campaign_id"`
-	CampaignName         string          `json:"campaign_name"`
-	CampaignResourceName string          `json:"campaign_resource_name"`
-	Metrics              AdGroupMetrics  `json:"metrics"`
+	ID                   string                   `json:"id"`
+	ResourceName         string                   `json:"resource_name"`
+	Name                 string                   `json:"name"`
+	Status               string                   `json:"status"`
+	Type                 string                   `json:"type"`
+	CampaignID           string                   `json:"campaign_id"`
+	CampaignName         string                   `json:"campaign_name"`
+	CampaignResourceName string                   `json:"campaign_resource_name"`
+	Metrics              AdGroupMetrics           `json:"metrics,omitzero"`
+	Segments             []AdGroupSegmentOutput   `json:"segments,omitempty"`
+	Comparison           *AdGroupComparisonOutput `json:"comparison,omitempty"`
+	Incomplete           bool                     `json:"incomplete,omitempty"`
 }
 
-// AdGroupMetrics represents metrics for an ad group.
-type AdGroupMetrics struct {
-	Clicks      int64   `json:"clicks"`
-	Impressions int64   `json:"impressions"`
-	CTR         float64 `json:"ctr"`
-	AverageCPC  int64   `json:"average_cpc_micros"` // in micros
-	CostMicros  int64   `json:"cost_micros"`
+// AdGroupComparisonOutput compares the metrics of an ad group against the comparison period.
+// PercentChange is keyed by metric name and is null when the previous value is zero.
+type AdGroupComparisonOutput struct {
+	Presence        string              `json:"presence"`
+	PreviousMetrics AdGroupMetrics      `json:"previous_metrics"`
+	Delta           AdGroupMetrics      `json:"delta"`
+	PercentChange   map[string]*float64 `json:"percent_change"`
+}
+
+// AdGroupSegmentOutput holds the metrics of an ad group for a single segment value.
+type AdGroupSegmentOutput struct {
+	Value   string         `json:"value"`
+	Metrics AdGroupMetrics `json:"metrics"`
 }
 
+// AdGroupMetrics represents metrics for an ad group. Money amounts are returned in
+// micros, as decimal amounts in the account currency, or both, per money_format.
+type AdGroupMetrics struct {
+	Clicks                   int64   `json:"clicks"`
+	Impressions              int64   `json:"impressions"`
+	CTR                      float64 `json:"ctr"`
+	AverageCPC               *int64  `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount         string  `json:"average_cpc,omitempty"`
+	CostMicros               *int64  `json:"cost_micros,omitempty"`
+	Cost                     string  `json:"cost,omitempty"`
+	Conversions              float64 `json:"conversions"`
+	ConversionsValue         float64 `json:"conversions_value"`
+	ConversionRate           float64 `json:"conversion_rate"`
+	Interactions             int64   `json:"interactions"`
+	ROAS                     float64 `json:"roas"`
+	CPAMicros                *int64  `json:"cpa_micros,omitempty"`
+	CPA                      string  `json:"cpa,omitempty"`
+	CPMMicros                *int64  `json:"cpm_micros,omitempty"`
+	CPM                      string  `json:"cpm,omitempty"`
+	CostPerInteractionMicros *int64  `json:"cost_per_interaction_micros,omitempty"`
+	CostPerInteraction       string  `json:"cost_per_interaction,omitempty"`
+	ValuePerClick            float64 `json:"value_per_click"`
+}
@@ -2,9 +2,13 @@ package searchadgroups
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"google-ads-mcp/internal/infrastructure/api/comparison"
+	"google-ads-mcp/internal/infrastructure/api/currency"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
 	"google-ads-mcp/internal/infrastructure/api/searchadgroups"
 
 	"github.com/go-playground/validator/v10"
@@ -14,12 +18,16 @@ import (
 var validate = validator.New()
 
 type Tool struct {
-	service *searchadgroups.Service
+	service        *searchadgroups.Service
+	currencyLookup currency.Lookup
+	budget         responsebudget.Budget
 }
 
-func NewSearchAdGroupsTool(service *searchadgroups.Service) *Tool {
+func NewSearchAdGroupsTool(service *searchadgroups.Service, currencyLookup currency.Lookup, budget responsebudget.Budget) *Tool {
 	return &Tool{
-		service: service,
+		service:        service,
+		currencyLookup: currencyLookup,
+		budget:         budget,
 	}
 }
 
@@ -33,7 +41,17 @@ func (t *Tool) SearchAdGroups(ctx context.Context, req *mcp.CallToolRequest, inp
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchadgroups: validation error: %w", err)
 	}
 
-	filters := mapInputToFilters(input)
+	moneyFormat, err := currency.ParseMoneyFormat(input.MoneyFormat)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchadgroups: %w", err)
+	}
+
+	fields, err := searchadgroups.Fields.Select(input.Fields)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchadgroups: %w", err)
+	}
+
+	filters := mapInputToFilters(input, fields)
 
 	result, err := t.service.SearchAdGroups(ctx, filters)
 	if err != nil {
@@ -41,12 +59,24 @@ func (t *Tool) SearchAdGroups(ctx context.Context, req *mcp.CallToolRequest, inp
 	}
 
 	output := ToolOutput{
-		AdGroups:      mapAdGroups(result.AdGroups),
-		NextPageToken: result.NextPageToken,
-		TotalCount:    result.TotalResultsCount,
+		AdGroups:         mapAdGroups(result.AdGroups),
+		NextPageToken:    result.NextPageToken,
+		TotalCount:       result.TotalResultsCount,
+		HasMore:          result.HasMore,
+		Cache:            string(result.Cache),
+		ComparisonPeriod: mapComparisonPeriod(result.ComparisonPeriod),
+		fields:           fields,
+	}
+
+	if moneyFormat.IncludesDecimal() {
+		code, err := t.currencyLookup.CurrencyCode(ctx, input.CustomerID, input.LoginCustomerID)
+		if err != nil {
+			return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchadgroups: %w", err)
+		}
+		currency.Apply(output.AdGroups, moneyFormat, code)
 	}
 
-	data, err := json.Marshal(output)
+	data, err := responsebudget.Marshal(t.budget, &output, &output.AdGroups, &output.Truncation, func() *responsebudget.Totals { return adGroupTotals(result.AdGroups) })
 	if err != nil {
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchadgroups: marshal response: %v", err)
 	}
@@ -56,16 +86,33 @@ func (t *Tool) SearchAdGroups(ctx context.Context, req *mcp.CallToolRequest, inp
 	}, output, nil
 }
 
-func mapInputToFilters(input ToolInput) searchadgroups.Filters {
+func mapInputToFilters(input ToolInput, fields projection.Selection) searchadgroups.Filters {
 	return searchadgroups.Filters{
-		CustomerID:     input.CustomerID,
-		AdGroupIDs:     input.AdGroupIDs,
-		AdGroupNames:   input.AdGroupNames,
-		Statuses:       input.Statuses,
-		CampaignIDs:    input.CampaignIDs,
-		CampaignNames:  input.CampaignNames,
-		DateRangeStart: input.DateRangeStart,
-		DateRangeEnd:   input.DateRangeEnd,
+		CustomerID:            input.CustomerID,
+		LoginCustomerID:       input.LoginCustomerID,
+		AdGroupIDs:            input.AdGroupIDs,
+		AdGroupNames:          input.AdGroupNames,
+		Statuses:              input.Statuses,
+		CampaignIDs:           input.CampaignIDs,
+		CampaignNames:         input.CampaignNames,
+		MinCostMicros:         input.MinCostMicros,
+		MinImpressions:        input.MinImpressions,
+		MinClicks:             input.MinClicks,
+		MinConversions:        input.MinConversions,
+		MaxCPAMicros:          input.MaxCPAMicros,
+		OrderBy:               input.OrderBy,
+		Limit:                 input.Limit,
+		DateRangeStart:        input.DateRangeStart,
+		DateRangeEnd:          input.DateRangeEnd,
+		SegmentBy:             input.SegmentBy,
+		CompareTo:             input.CompareTo,
+		CompareDateRangeStart: input.CompareDateRangeStart,
+		CompareDateRangeEnd:   input.CompareDateRangeEnd,
+		PageToken:             input.PageToken,
+		PageSize:              input.PageSize,
+		FetchAll:              input.FetchAll,
+		BypassCache:           input.BypassCache,
+		Fields:                fields,
 	}
 }
 
@@ -81,16 +128,99 @@ func mapAdGroups(adGroups []searchadgroups.AdGroup) []AdGroupOutput {
 			CampaignID:           ag.CampaignID,
 			CampaignName:         ag.CampaignName,
 			CampaignResourceName: ag.CampaignResourceName,
-			Metrics: AdGroupMetrics{
-				Clicks:      ag.Metrics.Clicks,
-				Impressions: ag.Metrics.Impressions,
-				CTR:         ag.Metrics.CTR,
-				AverageCPC:  ag.Metrics.AverageCPC,
-				CostMicros:  ag.Metrics.CostMicros,
-			},
+			Metrics:              mapAdGroupMetrics(ag.Metrics),
+			Segments:             mapAdGroupSegments(ag.Segments),
+			Comparison:           mapAdGroupComparison(ag.Metrics, ag.Comparison),
+			Incomplete:           ag.Incomplete,
+		})
+	}
+
+	return normalized
+}
+
+func mapAdGroupComparison(current searchadgroups.AdGroupMetrics, c *searchadgroups.AdGroupComparison) *AdGroupComparisonOutput {
+	if c == nil {
+		return nil
+	}
+
+	currentMetrics := mapAdGroupMetrics(current)
+	previousMetrics := mapAdGroupMetrics(c.PreviousMetrics)
+	return &AdGroupComparisonOutput{
+		Presence:        string(c.Presence),
+		PreviousMetrics: previousMetrics,
+		Delta:           comparison.Delta(currentMetrics, previousMetrics),
+		PercentChange:   comparison.PercentChange(currentMetrics, previousMetrics),
+	}
+}
+
+func mapComparisonPeriod(period *comparison.Period) *ComparisonPeriodOutput {
+	if period == nil {
+		return nil
+	}
+
+	return &ComparisonPeriodOutput{
+		CurrentStart:  period.CurrentStart,
+		CurrentEnd:    period.CurrentEnd,
+		PreviousStart: period.PreviousStart,
+		PreviousEnd:   period.PreviousEnd,
+	}
+}
+
+func mapAdGroupSegments(segments []searchadgroups.AdGroupSegment) []AdGroupSegmentOutput {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	normalized := make([]AdGroupSegmentOutput, 0, len(segments))
+	for _, segment := range segments {
+		normalized = append(normalized, AdGroupSegmentOutput{
+			Value:   segment.Value,
+			Metrics: mapAdGroupMetrics(segment.Metrics),
 		})
 	}
 
 	return normalized
 }
 
+func mapAdGroupMetrics(metrics searchadgroups.AdGroupMetrics) AdGroupMetrics {
+	return AdGroupMetrics{
+		Clicks:                   metrics.Clicks,
+		Impressions:              metrics.Impressions,
+		CTR:                      metrics.CTR,
+		AverageCPC:               currency.Micros(metrics.AverageCPC),
+		CostMicros:               currency.Micros(metrics.CostMicros),
+		Conversions:              metrics.Conversions,
+		ConversionsValue:         metrics.ConversionsValue,
+		ConversionRate:           metrics.ConversionRate,
+		Interactions:             metrics.Interactions,
+		ROAS:                     metrics.KPIs.ROAS,
+		CPAMicros:                currency.Micros(metrics.KPIs.CPAMicros),
+		CPMMicros:                currency.Micros(metrics.KPIs.CPMMicros),
+		CostPerInteractionMicros: currency.Micros(metrics.KPIs.CostPerInteractionMicros),
+		ValuePerClick:            metrics.KPIs.ValuePerClick,
+	}
+}
+
+// adGroupTotals rolls up the metrics of every ad group for the truncation summary,
+// across segments when the query is segmented.
+func adGroupTotals(adGroups []searchadgroups.AdGroup) *responsebudget.Totals {
+	inputs := make([]kpi.Inputs, 0, len(adGroups))
+	for _, adGroup := range adGroups {
+		inputs = append(inputs, adGroupInputs(adGroup.Metrics))
+		for _, segment := range adGroup.Segments {
+			inputs = append(inputs, adGroupInputs(segment.Metrics))
+		}
+	}
+	return responsebudget.Sum(len(adGroups), inputs)
+}
+
+func adGroupInputs(metrics searchadgroups.AdGroupMetrics) kpi.Inputs {
+	return kpi.Inputs{
+		Clicks:           metrics.Clicks,
+		Impressions:      metrics.Impressions,
+		Interactions:     metrics.Interactions,
+		CostMicros:       metrics.CostMicros,
+		Conversions:      metrics.Conversions,
+		ConversionsValue: metrics.ConversionsValue,
+	}
+}
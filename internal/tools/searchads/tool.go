@@ -2,9 +2,13 @@ package searchads
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"google-ads-mcp/internal/infrastructure/api/comparison"
+	"google-ads-mcp/internal/infrastructure/api/currency"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
 	"google-ads-mcp/internal/infrastructure/api/searchads"
 
 	"github.com/go-playground/validator/v10"
@@ -14,12 +18,16 @@ import (
 var validate = validator.New()
 
 type Tool struct {
-	service *searchads.Service
+	service        *searchads.Service
+	currencyLookup currency.Lookup
+	budget         responsebudget.Budget
 }
 
-func NewSearchAdsTool(service *searchads.Service) *Tool {
+func NewSearchAdsTool(service *searchads.Service, currencyLookup currency.Lookup, budget responsebudget.Budget) *Tool {
 	return &Tool{
-		service: service,
+		service:        service,
+		currencyLookup: currencyLookup,
+		budget:         budget,
 	}
 }
 
@@ -33,7 +41,17 @@ func (t *Tool) SearchAds(ctx context.Context, req *mcp.CallToolRequest, input To
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchads: validation error: %w", err)
 	}
 
-	filters := mapInputToFilters(input)
+	moneyFormat, err := currency.ParseMoneyFormat(input.MoneyFormat)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchads: %w", err)
+	}
+
+	fields, err := searchads.Fields.Select(input.Fields)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchads: %w", err)
+	}
+
+	filters := mapInputToFilters(input, fields)
 
 	result, err := t.service.SearchAds(ctx, filters)
 	if err != nil {
@@ -41,12 +59,24 @@ func (t *Tool) SearchAds(ctx context.Context, req *mcp.CallToolRequest, input To
 	}
 
 	output := ToolOutput{
-		Ads:           mapAds(result.Ads),
-		NextPageToken: result.NextPageToken,
-		TotalCount:    result.TotalResultsCount,
+		Ads:              mapAds(result.Ads),
+		NextPageToken:    result.NextPageToken,
+		TotalCount:       result.TotalResultsCount,
+		HasMore:          result.HasMore,
+		Cache:            string(result.Cache),
+		ComparisonPeriod: mapComparisonPeriod(result.ComparisonPeriod),
+		fields:           fields,
 	}
 
-	data, err := json.Marshal(output)
+	if moneyFormat.IncludesDecimal() {
+		code, err := t.currencyLookup.CurrencyCode(ctx, input.CustomerID, input.LoginCustomerID)
+		if err != nil {
+			return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchads: %w", err)
+		}
+		currency.Apply(output.Ads, moneyFormat, code)
+	}
+
+	data, err := responsebudget.Marshal(t.budget, &output, &output.Ads, &output.Truncation, func() *responsebudget.Totals { return adTotals(result.Ads) })
 	if err != nil {
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchads: marshal response: %v", err)
 	}
@@ -56,17 +86,34 @@ func (t *Tool) SearchAds(ctx context.Context, req *mcp.CallToolRequest, input To
 	}, output, nil
 }
 
-func mapInputToFilters(input ToolInput) searchads.Filters {
+func mapInputToFilters(input ToolInput, fields projection.Selection) searchads.Filters {
 	return searchads.Filters{
-		CustomerID:      input.CustomerID,
-		CampaignIDs:    input.CampaignIDs,
-		CampaignNames:  input.CampaignNames,
-		AdGroupIDs:     input.AdGroupIDs,
-		AdGroupNames:   input.AdGroupNames,
-		Statuses:       input.Statuses,
-		AdTypes:        input.AdTypes,
-		DateRangeStart: input.DateRangeStart,
-		DateRangeEnd:   input.DateRangeEnd,
+		CustomerID:            input.CustomerID,
+		LoginCustomerID:       input.LoginCustomerID,
+		CampaignIDs:           input.CampaignIDs,
+		CampaignNames:         input.CampaignNames,
+		AdGroupIDs:            input.AdGroupIDs,
+		AdGroupNames:          input.AdGroupNames,
+		Statuses:              input.Statuses,
+		AdTypes:               input.AdTypes,
+		MinCostMicros:         input.MinCostMicros,
+		MinImpressions:        input.MinImpressions,
+		MinClicks:             input.MinClicks,
+		MinConversions:        input.MinConversions,
+		MaxCPAMicros:          input.MaxCPAMicros,
+		OrderBy:               input.OrderBy,
+		Limit:                 input.Limit,
+		DateRangeStart:        input.DateRangeStart,
+		DateRangeEnd:          input.DateRangeEnd,
+		SegmentBy:             input.SegmentBy,
+		CompareTo:             input.CompareTo,
+		CompareDateRangeStart: input.CompareDateRangeStart,
+		CompareDateRangeEnd:   input.CompareDateRangeEnd,
+		PageToken:             input.PageToken,
+		PageSize:              input.PageSize,
+		FetchAll:              input.FetchAll,
+		BypassCache:           input.BypassCache,
+		Fields:                fields,
 	}
 }
 
@@ -74,41 +121,23 @@ func mapAds(ads []searchads.Ad) []AdOutput {
 	normalized := make([]AdOutput, 0, len(ads))
 	for _, ad := range ads {
 		output := AdOutput{
-			ID:                  ad.ID,
-			ResourceName:        ad.ResourceName,
-			Name:                ad.Name,
-			Type:                ad.Type,
-			Status:              ad.Status,
-			FinalURLs:           ad.FinalURLs,
-			ApprovalStatus:      ad.ApprovalStatus,
-			CampaignID:          ad.CampaignID,
-			CampaignName:        ad.CampaignName,
+			ID:                   ad.ID,
+			ResourceName:         ad.ResourceName,
+			Name:                 ad.Name,
+			Type:                 ad.Type,
+			Status:               ad.Status,
+			FinalURLs:            ad.FinalURLs,
+			ApprovalStatus:       ad.ApprovalStatus,
+			CampaignID:           ad.CampaignID,
+			CampaignName:         ad.CampaignName,
 			CampaignResourceName: ad.CampaignResourceName,
-			AdGroupID:           ad.AdGroupID,
-			AdGroupName:         ad.AdGroupName,
-			AdGroupResourceName: ad.AdGroupResourceName,
-			Metrics: AdMetrics{
-				Clicks:                             ad.Metrics.Clicks,
-				Impressions:                        ad.Metrics.Impressions,
-				CTR:                                ad.Metrics.CTR,
-				AverageCPC:                         ad.Metrics.AverageCPC,
-				CostMicros:                         ad.Metrics.CostMicros,
-				Conversions:                        ad.Metrics.Conversions,
-				ConversionsValue:                   ad.Metrics.ConversionsValue,
-				CostPerConversion:                  ad.Metrics.CostPerConversion,
-				ConversionRate:                     ad.Metrics.ConversionRate,
-				AllConversions:                     ad.Metrics.AllConversions,
-				AllConversionsValue:                ad.Metrics.AllConversionsValue,
-				AllConversionsFromInteractionsRate: ad.Metrics.AllConversionsFromInteractionsRate,
-				CostPerAllConversions:              ad.Metrics.CostPerAllConversions,
-				Interactions:                       ad.Metrics.Interactions,
-				EngagementRate:                     ad.Metrics.EngagementRate,
-				SearchImpressionShare:              ad.Metrics.SearchImpressionShare,
-				SearchRankLostImpressionShare:      ad.Metrics.SearchRankLostImpressionShare,
-				VideoViews:                         ad.Metrics.VideoViews,
-				VideoViewRate:                      ad.Metrics.VideoViewRate,
-				AverageCPV:                        ad.Metrics.AverageCPV,
-			},
+			AdGroupID:            ad.AdGroupID,
+			AdGroupName:          ad.AdGroupName,
+			AdGroupResourceName:  ad.AdGroupResourceName,
+			Metrics:              mapAdMetrics(ad.Metrics),
+			Segments:             mapAdSegments(ad.Segments),
+			Comparison:           mapAdComparison(ad.Metrics, ad.Comparison),
+			Incomplete:           ad.Incomplete,
 		}
 
 		if ad.ExpandedTextAd != nil {
@@ -118,8 +147,8 @@ func mapAds(ads []searchads.Ad) []AdOutput {
 				HeadlinePart3: ad.ExpandedTextAd.HeadlinePart3,
 				Description:   ad.ExpandedTextAd.Description,
 				Description2:  ad.ExpandedTextAd.Description2,
-				Path1:          ad.ExpandedTextAd.Path1,
-				Path2:          ad.ExpandedTextAd.Path2,
+				Path1:         ad.ExpandedTextAd.Path1,
+				Path2:         ad.ExpandedTextAd.Path2,
 			}
 		}
 
@@ -127,19 +156,19 @@ func mapAds(ads []searchads.Ad) []AdOutput {
 			output.ResponsiveSearchAd = &ResponsiveSearchAd{
 				Headlines:    ad.ResponsiveSearchAd.Headlines,
 				Descriptions: ad.ResponsiveSearchAd.Descriptions,
-				Path1:         ad.ResponsiveSearchAd.Path1,
-				Path2:         ad.ResponsiveSearchAd.Path2,
+				Path1:        ad.ResponsiveSearchAd.Path1,
+				Path2:        ad.ResponsiveSearchAd.Path2,
 			}
 		}
 
 		if ad.CallOnlyAd != nil {
 			output.CallOnlyAd = &CallOnlyAd{
-				Headline1:            ad.CallOnlyAd.Headline1,
-				Headline2:            ad.CallOnlyAd.Headline2,
-				Description1:         ad.CallOnlyAd.Description1,
-				Description2:         ad.CallOnlyAd.Description2,
-				PhoneNumber:          ad.CallOnlyAd.PhoneNumber,
-				CallTracked:          ad.CallOnlyAd.CallTracked,
+				Headline1:             ad.CallOnlyAd.Headline1,
+				Headline2:             ad.CallOnlyAd.Headline2,
+				Description1:          ad.CallOnlyAd.Description1,
+				Description2:          ad.CallOnlyAd.Description2,
+				PhoneNumber:           ad.CallOnlyAd.PhoneNumber,
+				CallTracked:           ad.CallOnlyAd.CallTracked,
 				DisableCallConversion: ad.CallOnlyAd.DisableCallConversion,
 			}
 		}
@@ -150,3 +179,104 @@ func mapAds(ads []searchads.Ad) []AdOutput {
 	return normalized
 }
 
+func mapAdComparison(current searchads.AdMetrics, c *searchads.AdComparison) *AdComparisonOutput {
+	if c == nil {
+		return nil
+	}
+
+	currentMetrics := mapAdMetrics(current)
+	previousMetrics := mapAdMetrics(c.PreviousMetrics)
+	return &AdComparisonOutput{
+		Presence:        string(c.Presence),
+		PreviousMetrics: previousMetrics,
+		Delta:           comparison.Delta(currentMetrics, previousMetrics),
+		PercentChange:   comparison.PercentChange(currentMetrics, previousMetrics),
+	}
+}
+
+func mapComparisonPeriod(period *comparison.Period) *ComparisonPeriodOutput {
+	if period == nil {
+		return nil
+	}
+
+	return &ComparisonPeriodOutput{
+		CurrentStart:  period.CurrentStart,
+		CurrentEnd:    period.CurrentEnd,
+		PreviousStart: period.PreviousStart,
+		PreviousEnd:   period.PreviousEnd,
+	}
+}
+
+func mapAdSegments(segments []searchads.AdSegment) []AdSegmentOutput {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	normalized := make([]AdSegmentOutput, 0, len(segments))
+	for _, segment := range segments {
+		normalized = append(normalized, AdSegmentOutput{
+			Value:   segment.Value,
+			Metrics: mapAdMetrics(segment.Metrics),
+		})
+	}
+
+	return normalized
+}
+
+func mapAdMetrics(metrics searchads.AdMetrics) AdMetrics {
+	output := AdMetrics{
+		Clicks:                             metrics.Clicks,
+		Impressions:                        metrics.Impressions,
+		CTR:                                metrics.CTR,
+		AverageCPC:                         currency.Micros(metrics.AverageCPC),
+		CostMicros:                         currency.Micros(metrics.CostMicros),
+		Conversions:                        metrics.Conversions,
+		ConversionsValue:                   metrics.ConversionsValue,
+		CostPerConversion:                  currency.RoundMicros(metrics.CostPerConversion),
+		ConversionRate:                     metrics.ConversionRate,
+		AllConversions:                     metrics.AllConversions,
+		AllConversionsValue:                metrics.AllConversionsValue,
+		AllConversionsFromInteractionsRate: metrics.AllConversionsFromInteractionsRate,
+		CostPerAllConversions:              currency.RoundMicros(metrics.CostPerAllConversions),
+		Interactions:                       metrics.Interactions,
+		EngagementRate:                     metrics.EngagementRate,
+		SearchImpressionShare:              metrics.SearchImpressionShare,
+		SearchRankLostImpressionShare:      metrics.SearchRankLostImpressionShare,
+		VideoViews:                         metrics.VideoViews,
+		VideoViewRate:                      metrics.VideoViewRate,
+		ROAS:                               metrics.KPIs.ROAS,
+		CPAMicros:                          currency.Micros(metrics.KPIs.CPAMicros),
+		CPMMicros:                          currency.Micros(metrics.KPIs.CPMMicros),
+		CostPerInteractionMicros:           currency.Micros(metrics.KPIs.CostPerInteractionMicros),
+		ValuePerClick:                      metrics.KPIs.ValuePerClick,
+	}
+	if metrics.AverageCPV != 0 {
+		output.AverageCPV = currency.Micros(metrics.AverageCPV)
+	}
+
+	return output
+}
+
+// adTotals rolls up the metrics of every ad for the truncation summary,
+// across segments when the query is segmented.
+func adTotals(ads []searchads.Ad) *responsebudget.Totals {
+	inputs := make([]kpi.Inputs, 0, len(ads))
+	for _, ad := range ads {
+		inputs = append(inputs, adInputs(ad.Metrics))
+		for _, segment := range ad.Segments {
+			inputs = append(inputs, adInputs(segment.Metrics))
+		}
+	}
+	return responsebudget.Sum(len(ads), inputs)
+}
+
+func adInputs(metrics searchads.AdMetrics) kpi.Inputs {
+	return kpi.Inputs{
+		Clicks:           metrics.Clicks,
+		Impressions:      metrics.Impressions,
+		Interactions:     metrics.Interactions,
+		CostMicros:       metrics.CostMicros,
+		Conversions:      metrics.Conversions,
+		ConversionsValue: metrics.ConversionsValue,
+	}
+}
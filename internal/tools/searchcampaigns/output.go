@@ -1,42 +1,170 @@
 package searchcampaigns
 
+import (
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+)
+
 // ToolOutput captures the structured response returned to the MCP client.
 type ToolOutput struct {
-	Campaigns     []CampaignOutput `json:"campaigns"`
-	NextPageToken string           `json:"next_page_token,omitempty"`
-	TotalCount    int64            `json:"total_count"`
+	Campaigns         []CampaignOutput         `json:"campaigns"`
+	NextPageToken     string                   `json:"next_page_token,omitempty"`
+	TotalCount        int64                    `json:"total_count"`
+	HasMore           bool                     `json:"has_more,omitempty"`
+	Cache             string                   `json:"cache,omitempty"`
+	ComparisonPeriod  *ComparisonPeriodOutput  `json:"comparison_period,omitempty"`
+	Accounts          []AccountCampaignsOutput `json:"accounts,omitempty"`
+	Rollup            *RollupOutput            `json:"rollup,omitempty"`
+	FailedAccounts    []FailedAccountOutput    `json:"failed_accounts,omitempty"`
+	ReportingCurrency string                   `json:"reporting_currency,omitempty"`
+	responsebudget.Truncation
+
+	fields projection.Selection
+}
+
+// MarshalJSON serializes only the campaign fields selected by the fields input.
+func (o ToolOutput) MarshalJSON() ([]byte, error) {
+	type toolOutput ToolOutput
+	return o.fields.Marshal(toolOutput(o), "campaigns", "accounts.campaigns", "rollup")
+}
+
+// AccountCampaignsOutput holds the campaigns of one account when customer_ids is used.
+type AccountCampaignsOutput struct {
+	CustomerID      string           `json:"customer_id"`
+	Campaigns       []CampaignOutput `json:"campaigns"`
+	NextPageToken   string           `json:"next_page_token,omitempty"`
+	TotalCount      int64            `json:"total_count"`
+	HasMore         bool             `json:"has_more,omitempty"`
+	CurrencyCode    string           `json:"currency_code,omitempty"`
+	FXRate          *FXRateOutput    `json:"fx_rate,omitempty"`
+	ConversionError string           `json:"conversion_error,omitempty"`
+}
+
+// FXRateOutput is the exchange rate used to convert an account to the reporting currency.
+// RateDate is empty when the account already reports in that currency.
+type FXRateOutput struct {
+	From     string  `json:"from"`
+	To       string  `json:"to"`
+	Rate     float64 `json:"rate"`
+	RateDate string  `json:"rate_date,omitempty"`
+}
+
+// ConvertedAmountsOutput holds money metrics converted to the reporting currency.
+type ConvertedAmountsOutput struct {
+	CostMicros              *int64  `json:"cost_micros,omitempty"`
+	Cost                    string  `json:"cost,omitempty"`
+	ConversionsValue        float64 `json:"conversions_value"`
+	AverageCPC              *int64  `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount        string  `json:"average_cpc,omitempty"`
+	CostPerConversion       *int64  `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount string  `json:"cost_per_conversion,omitempty"`
+}
+
+// ConvertedRollupOutput sums the converted money metrics of the accounts that could be converted.
+type ConvertedRollupOutput struct {
+	CurrencyCode            string   `json:"currency_code"`
+	CostMicros              *int64   `json:"cost_micros,omitempty"`
+	Cost                    string   `json:"cost,omitempty"`
+	ConversionsValue        float64  `json:"conversions_value"`
+	AverageCPC              *int64   `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount        string   `json:"average_cpc,omitempty"`
+	CostPerConversion       *int64   `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount string   `json:"cost_per_conversion,omitempty"`
+	RateDates               []string `json:"rate_dates,omitempty"`
+	UnconvertedAccounts     []string `json:"unconverted_accounts,omitempty"`
+}
+
+// RollupOutput sums the campaign metrics of every account that succeeded.
+// Metrics amounts are added in each account's own currency, so they are only
+// returned, labelled with CurrencyCode, when every account shares one; Converted
+// holds the totals in the reporting currency.
+type RollupOutput struct {
+	AccountCount   int                    `json:"account_count"`
+	SucceededCount int                    `json:"succeeded_count"`
+	FailedCount    int                    `json:"failed_count"`
+	CurrencyCode   string                 `json:"currency_code,omitempty"`
+	CampaignCount  int64                  `json:"campaign_count"`
+	Metrics        CampaignMetrics        `json:"metrics"`
+	Converted      *ConvertedRollupOutput `json:"converted,omitempty"`
+}
+
+// FailedAccountOutput reports an account that could not be queried.
+type FailedAccountOutput struct {
+	CustomerID string `json:"customer_id"`
+	Error      string `json:"error"`
+}
+
+// ComparisonPeriodOutput describes the date ranges compared when compare_to is set.
+type ComparisonPeriodOutput struct {
+	CurrentStart  string `json:"current_start"`
+	CurrentEnd    string `json:"current_end"`
+	PreviousStart string `json:"previous_start"`
+	PreviousEnd   string `json:"previous_end"`
 }
 
 // CampaignOutput mirrors the normalized campaign representation returned to clients.
 type CampaignOutput struct {
-	ID                     string          `json:"id"`
-	ResourceName           string          `json:"resource_name"`
-	Name                   string          `json:"name"`
-	Status                 string          `json:"status"`
-	AdvertisingChannelType string          `json:"advertising_channel_type"`
-	BiddingStrategyType    string          `json:"bidding_strategy_type"`
-	BudgetAmountMicros     int64           `json:"budget_amount_micros"`
-	OptimizationScore      float64         `json:"optimization_score"`
-	Metrics                CampaignMetrics `json:"metrics"`
-}
-
-// CampaignMetrics represents metrics for a campaign.
+	ID                     string                    `json:"id"`
+	ResourceName           string                    `json:"resource_name"`
+	Name                   string                    `json:"name"`
+	Status                 string                    `json:"status"`
+	AdvertisingChannelType string                    `json:"advertising_channel_type"`
+	BiddingStrategyType    string                    `json:"bidding_strategy_type"`
+	BudgetAmountMicros     *int64                    `json:"budget_amount_micros,omitempty"`
+	BudgetAmount           string                    `json:"budget_amount,omitempty"`
+	OptimizationScore      float64                   `json:"optimization_score"`
+	Metrics                CampaignMetrics           `json:"metrics,omitzero"`
+	Segments               []CampaignSegmentOutput   `json:"segments,omitempty"`
+	Comparison             *CampaignComparisonOutput `json:"comparison,omitempty"`
+	Converted              *ConvertedAmountsOutput   `json:"converted,omitempty"`
+	Incomplete             bool                      `json:"incomplete,omitempty"`
+}
+
+// CampaignComparisonOutput compares the metrics of a campaign against the comparison period.
+// PercentChange is keyed by metric name and is null when the previous value is zero.
+type CampaignComparisonOutput struct {
+	Presence        string              `json:"presence"`
+	PreviousMetrics CampaignMetrics     `json:"previous_metrics"`
+	Delta           CampaignMetrics     `json:"delta"`
+	PercentChange   map[string]*float64 `json:"percent_change"`
+}
+
+// CampaignSegmentOutput holds the metrics of a campaign for a single segment value.
+type CampaignSegmentOutput struct {
+	Value   string          `json:"value"`
+	Metrics CampaignMetrics `json:"metrics"`
+}
+
+// CampaignMetrics represents metrics for a campaign. Money amounts are returned in
+// micros, as decimal amounts in the account currency, or both, per money_format.
 type CampaignMetrics struct {
 	Clicks                             int64   `json:"clicks"`
 	Impressions                        int64   `json:"impressions"`
 	CTR                                float64 `json:"ctr"`
-	AverageCPC                         int64   `json:"average_cpc_micros"` // in micros
-	CostMicros                         int64   `json:"cost_micros"`
+	AverageCPC                         *int64  `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount                   string  `json:"average_cpc,omitempty"`
+	CostMicros                         *int64  `json:"cost_micros,omitempty"`
+	Cost                               string  `json:"cost,omitempty"`
 	Conversions                        float64 `json:"conversions"`
-	ConversionsValue                   float64 `json:"conversions_value"`
-	CostPerConversion                  float64 `json:"cost_per_conversion"`
+	ConversionsValue                   float64 `json:"conversions_value,omitempty"`
+	CostPerConversion                  *int64  `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount            string  `json:"cost_per_conversion,omitempty"`
 	ConversionRate                     float64 `json:"conversion_rate"`
 	AllConversions                     float64 `json:"all_conversions"`
-	AllConversionsValue                float64 `json:"all_conversions_value"`
+	AllConversionsValue                float64 `json:"all_conversions_value,omitempty"`
 	AllConversionsFromInteractionsRate float64 `json:"all_conversions_from_interactions_rate"`
-	CostPerAllConversions              float64 `json:"cost_per_all_conversions"`
+	CostPerAllConversions              *int64  `json:"cost_per_all_conversions_micros,omitempty"`
+	CostPerAllConversionsAmount        string  `json:"cost_per_all_conversions,omitempty"`
 	Interactions                       int64   `json:"interactions"`
 	EngagementRate                     float64 `json:"engagement_rate"`
 	SearchImpressionShare              float64 `json:"search_impression_share"`
 	SearchRankLostImpressionShare      float64 `json:"search_rank_lost_impression_share"`
+	ROAS                               float64 `json:"roas,omitempty"`
+	CPAMicros                          *int64  `json:"cpa_micros,omitempty"`
+	CPA                                string  `json:"cpa,omitempty"`
+	CPMMicros                          *int64  `json:"cpm_micros,omitempty"`
+	CPM                                string  `json:"cpm,omitempty"`
+	CostPerInteractionMicros           *int64  `json:"cost_per_interaction_micros,omitempty"`
+	CostPerInteraction                 string  `json:"cost_per_interaction,omitempty"`
+	ValuePerClick                      float64 `json:"value_per_click,omitempty"`
 }
@@ -2,10 +2,15 @@ package searchcampaigns
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
+	"google-ads-mcp/internal/infrastructure/api/comparison"
+	"google-ads-mcp/internal/infrastructure/api/currency"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
 	"google-ads-mcp/internal/infrastructure/api/searchcampaigns"
+	"google-ads-mcp/internal/infrastructure/fx"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -15,11 +20,13 @@ var validate = validator.New()
 
 type Tool struct {
 	service *searchcampaigns.Service
+	budget  responsebudget.Budget
 }
 
-func NewSearchCampaignsTool(service *searchcampaigns.Service) *Tool {
+func NewSearchCampaignsTool(service *searchcampaigns.Service, budget responsebudget.Budget) *Tool {
 	return &Tool{
 		service: service,
+		budget:  budget,
 	}
 }
 
@@ -33,20 +40,61 @@ func (t *Tool) SearchCampaigns(ctx context.Context, req *mcp.CallToolRequest, in
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchcampaigns: validation error: %w", err)
 	}
 
-	filters := mapInputToFilters(input)
+	if input.CustomerID != "" && len(input.CustomerIDs) > 0 {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchcampaigns: customer_id and customer_ids cannot be combined")
+	}
 
-	result, err := t.service.SearchCampaigns(ctx, filters)
+	moneyFormat, err := currency.ParseMoneyFormat(input.MoneyFormat)
 	if err != nil {
-		return &mcp.CallToolResult{}, ToolOutput{}, err
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchcampaigns: %w", err)
 	}
 
-	output := ToolOutput{
-		Campaigns:     mapCampaigns(result.Campaigns),
-		NextPageToken: result.NextPageToken,
-		TotalCount:    result.TotalResultsCount,
+	fields, err := searchcampaigns.Fields.Select(input.Fields)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchcampaigns: %w", err)
 	}
 
-	data, err := json.Marshal(output)
+	filters := mapInputToFilters(input, fields)
+
+	var output ToolOutput
+	var totals func() *responsebudget.Totals
+	if len(filters.CustomerIDs) > 0 {
+		result, err := t.service.SearchCampaignsAcrossAccounts(ctx, filters)
+		if err != nil {
+			return &mcp.CallToolResult{}, ToolOutput{}, err
+		}
+		output = mapMultiAccountResult(result)
+		formatMultiAccountMoney(&output, moneyFormat)
+	} else {
+		result, err := t.service.SearchCampaigns(ctx, filters)
+		if err != nil {
+			return &mcp.CallToolResult{}, ToolOutput{}, err
+		}
+		output = ToolOutput{
+			Campaigns:        mapCampaigns(result.Campaigns),
+			NextPageToken:    result.NextPageToken,
+			TotalCount:       result.TotalResultsCount,
+			HasMore:          result.HasMore,
+			Cache:            string(result.Cache),
+			ComparisonPeriod: mapComparisonPeriod(result.ComparisonPeriod),
+		}
+		// The campaigns query selects customer.currency_code, so no lookup is needed.
+		currency.Apply(output.Campaigns, moneyFormat, result.CurrencyCode)
+		totals = func() *responsebudget.Totals { return campaignTotals(result.Campaigns) }
+	}
+	output.fields = fields
+
+	// Multi-account results keep their first campaigns in account order; their rollup
+	// already holds the totals
+	var data []byte
+	if len(filters.CustomerIDs) > 0 {
+		accounts := output.Accounts
+		data, err = responsebudget.MarshalFunc(t.budget, &output, countCampaigns(accounts), func(n int) {
+			output.Accounts = keepCampaigns(accounts, n)
+		}, &output.Truncation, nil)
+	} else {
+		data, err = responsebudget.Marshal(t.budget, &output, &output.Campaigns, &output.Truncation, totals)
+	}
 	if err != nil {
 		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchcampaigns: marshal response: %v", err)
 	}
@@ -56,14 +104,188 @@ func (t *Tool) SearchCampaigns(ctx context.Context, req *mcp.CallToolRequest, in
 	}, output, nil
 }
 
-func mapInputToFilters(input ToolInput) searchcampaigns.Filters {
+func countCampaigns(accounts []AccountCampaignsOutput) int {
+	count := 0
+	for _, account := range accounts {
+		count += len(account.Campaigns)
+	}
+	return count
+}
+
+// keepCampaigns returns the accounts holding the first n campaigns across accounts,
+// dropping the accounts past the last campaign kept.
+func keepCampaigns(accounts []AccountCampaignsOutput, n int) []AccountCampaignsOutput {
+	kept := make([]AccountCampaignsOutput, 0, len(accounts))
+	for _, account := range accounts {
+		if n == 0 && len(account.Campaigns) > 0 {
+			break
+		}
+		take := min(n, len(account.Campaigns))
+		account.Campaigns = account.Campaigns[:take]
+		kept = append(kept, account)
+		n -= take
+	}
+	return kept
+}
+
+func mapInputToFilters(input ToolInput, fields projection.Selection) searchcampaigns.Filters {
 	return searchcampaigns.Filters{
-		CustomerID:     input.CustomerID,
-		CampaignIDs:    input.CampaignIDs,
-		CampaignNames:  input.CampaignNames,
-		Statuses:       input.Statuses,
-		DateRangeStart: input.DateRangeStart,
-		DateRangeEnd:   input.DateRangeEnd,
+		CustomerID:            input.CustomerID,
+		CustomerIDs:           input.CustomerIDs,
+		LoginCustomerID:       input.LoginCustomerID,
+		CampaignIDs:           input.CampaignIDs,
+		CampaignNames:         input.CampaignNames,
+		Statuses:              input.Statuses,
+		MinCostMicros:         input.MinCostMicros,
+		MinImpressions:        input.MinImpressions,
+		MinClicks:             input.MinClicks,
+		MinConversions:        input.MinConversions,
+		MaxCPAMicros:          input.MaxCPAMicros,
+		OrderBy:               input.OrderBy,
+		Limit:                 input.Limit,
+		DateRangeStart:        input.DateRangeStart,
+		DateRangeEnd:          input.DateRangeEnd,
+		SegmentBy:             input.SegmentBy,
+		CompareTo:             input.CompareTo,
+		CompareDateRangeStart: input.CompareDateRangeStart,
+		CompareDateRangeEnd:   input.CompareDateRangeEnd,
+		PageToken:             input.PageToken,
+		PageSize:              input.PageSize,
+		FetchAll:              input.FetchAll,
+		BypassCache:           input.BypassCache,
+		ReportingCurrency:     input.ReportingCurrency,
+		Fields:                fields,
+	}
+}
+
+// mapMultiAccountResult returns the campaigns per account; the top-level campaigns
+// list stays empty and the total count is the number of campaigns across accounts.
+func mapMultiAccountResult(result searchcampaigns.MultiAccountResult) ToolOutput {
+	output := ToolOutput{
+		Campaigns:  make([]CampaignOutput, 0),
+		TotalCount: result.Rollup.CampaignCount,
+		Cache:      string(result.Cache),
+		Accounts:   make([]AccountCampaignsOutput, 0, len(result.Accounts)),
+		Rollup: &RollupOutput{
+			AccountCount:   result.Rollup.AccountCount,
+			SucceededCount: result.Rollup.SucceededCount,
+			FailedCount:    result.Rollup.FailedCount,
+			CampaignCount:  result.Rollup.CampaignCount,
+			Metrics:        mapCampaignMetrics(result.Rollup.Metrics),
+			Converted:      mapConvertedRollup(result.Rollup.Converted),
+		},
+		ReportingCurrency: result.ReportingCurrency,
+	}
+
+	for _, account := range result.Accounts {
+		if account.Err != nil {
+			output.FailedAccounts = append(output.FailedAccounts, FailedAccountOutput{
+				CustomerID: account.CustomerID,
+				Error:      account.Err.Error(),
+			})
+			continue
+		}
+
+		accountOutput := AccountCampaignsOutput{
+			CustomerID:    account.CustomerID,
+			Campaigns:     mapCampaigns(account.Value.Campaigns),
+			NextPageToken: account.Value.NextPageToken,
+			TotalCount:    account.Value.TotalResultsCount,
+			HasMore:       account.Value.HasMore,
+			CurrencyCode:  account.Value.CurrencyCode,
+			FXRate:        mapFXRate(account.Value.Rate),
+		}
+		if account.Value.ConversionErr != nil {
+			accountOutput.ConversionError = account.Value.ConversionErr.Error()
+		}
+		output.Accounts = append(output.Accounts, accountOutput)
+		if output.ComparisonPeriod == nil {
+			output.ComparisonPeriod = mapComparisonPeriod(account.Value.ComparisonPeriod)
+		}
+	}
+
+	return output
+}
+
+// formatMultiAccountMoney formats the converted amounts in the reporting currency
+// and every account in its own currency. The native rollup is labelled with the
+// currency when all accounts share one; otherwise its money amounts are omitted.
+func formatMultiAccountMoney(output *ToolOutput, format currency.MoneyFormat) {
+	if output.ReportingCurrency != "" {
+		for _, account := range output.Accounts {
+			for _, campaign := range account.Campaigns {
+				currency.Apply(campaign.Converted, format, output.ReportingCurrency)
+			}
+		}
+		currency.Apply(output.Rollup.Converted, format, output.ReportingCurrency)
+	}
+
+	codes := make(map[string]struct{})
+	for i := range output.Accounts {
+		currency.Apply(&output.Accounts[i], format, output.Accounts[i].CurrencyCode)
+		if output.Accounts[i].CurrencyCode != "" {
+			codes[output.Accounts[i].CurrencyCode] = struct{}{}
+		}
+	}
+	switch {
+	case len(codes) == 1:
+		for code := range codes {
+			output.Rollup.CurrencyCode = code
+			currency.Apply(&output.Rollup.Metrics, format, code)
+		}
+	case len(codes) > 1:
+		omitMoney(&output.Rollup.Metrics)
+	}
+}
+
+// omitMoney drops the money amounts and ratios of metrics added across currencies.
+func omitMoney(metrics *CampaignMetrics) {
+	currency.Omit(metrics)
+	metrics.ConversionsValue = 0
+	metrics.AllConversionsValue = 0
+	metrics.ROAS = 0
+	metrics.ValuePerClick = 0
+}
+
+func mapFXRate(rate *fx.Rate) *FXRateOutput {
+	if rate == nil {
+		return nil
+	}
+
+	return &FXRateOutput{
+		From:     rate.From,
+		To:       rate.To,
+		Rate:     rate.Value,
+		RateDate: rate.Date,
+	}
+}
+
+func mapConvertedAmounts(converted *searchcampaigns.ConvertedAmounts) *ConvertedAmountsOutput {
+	if converted == nil {
+		return nil
+	}
+
+	return &ConvertedAmountsOutput{
+		CostMicros:        currency.Micros(converted.CostMicros),
+		ConversionsValue:  converted.ConversionsValue,
+		AverageCPC:        currency.Micros(converted.AverageCPC),
+		CostPerConversion: currency.RoundMicros(converted.CostPerConversion),
+	}
+}
+
+func mapConvertedRollup(converted *searchcampaigns.ConvertedRollup) *ConvertedRollupOutput {
+	if converted == nil {
+		return nil
+	}
+
+	return &ConvertedRollupOutput{
+		CurrencyCode:        converted.CurrencyCode,
+		CostMicros:          currency.Micros(converted.CostMicros),
+		ConversionsValue:    converted.ConversionsValue,
+		AverageCPC:          currency.Micros(converted.AverageCPC),
+		CostPerConversion:   currency.RoundMicros(converted.CostPerConversion),
+		RateDates:           converted.RateDates,
+		UnconvertedAccounts: converted.UnconvertedAccounts,
 	}
 }
 
@@ -77,29 +299,110 @@ func mapCampaigns(campaigns []searchcampaigns.Campaign) []CampaignOutput {
 			Status:                 camp.Status,
 			AdvertisingChannelType: camp.AdvertisingChannelType,
 			BiddingStrategyType:    camp.BiddingStrategyType,
-			BudgetAmountMicros:     camp.BudgetAmountMicros,
+			BudgetAmountMicros:     currency.Micros(camp.BudgetAmountMicros),
 			OptimizationScore:      camp.OptimizationScore,
-			Metrics: CampaignMetrics{
-				Clicks:                             camp.Metrics.Clicks,
-				Impressions:                        camp.Metrics.Impressions,
-				CTR:                                camp.Metrics.CTR,
-				AverageCPC:                         camp.Metrics.AverageCPC,
-				CostMicros:                         camp.Metrics.CostMicros,
-				Conversions:                        camp.Metrics.Conversions,
-				ConversionsValue:                   camp.Metrics.ConversionsValue,
-				CostPerConversion:                  camp.Metrics.CostPerConversion,
-				ConversionRate:                     camp.Metrics.ConversionRate,
-				AllConversions:                     camp.Metrics.AllConversions,
-				AllConversionsValue:                camp.Metrics.AllConversionsValue,
-				AllConversionsFromInteractionsRate: camp.Metrics.AllConversionsFromInteractionsRate,
-				CostPerAllConversions:              camp.Metrics.CostPerAllConversions,
-				Interactions:                       camp.Metrics.Interactions,
-				EngagementRate:                     camp.Metrics.EngagementRate,
-				SearchImpressionShare:              camp.Metrics.SearchImpressionShare,
-				SearchRankLostImpressionShare:      camp.Metrics.SearchRankLostImpressionShare,
-			},
+			Metrics:                mapCampaignMetrics(camp.Metrics),
+			Segments:               mapCampaignSegments(camp.Segments),
+			Comparison:             mapCampaignComparison(camp.Metrics, camp.Comparison),
+			Converted:              mapConvertedAmounts(camp.Converted),
+			Incomplete:             camp.Incomplete,
 		})
 	}
 
 	return normalized
 }
+
+func mapCampaignComparison(current searchcampaigns.CampaignMetrics, c *searchcampaigns.CampaignComparison) *CampaignComparisonOutput {
+	if c == nil {
+		return nil
+	}
+
+	currentMetrics := mapCampaignMetrics(current)
+	previousMetrics := mapCampaignMetrics(c.PreviousMetrics)
+	return &CampaignComparisonOutput{
+		Presence:        string(c.Presence),
+		PreviousMetrics: previousMetrics,
+		Delta:           comparison.Delta(currentMetrics, previousMetrics),
+		PercentChange:   comparison.PercentChange(currentMetrics, previousMetrics),
+	}
+}
+
+func mapComparisonPeriod(period *comparison.Period) *ComparisonPeriodOutput {
+	if period == nil {
+		return nil
+	}
+
+	return &ComparisonPeriodOutput{
+		CurrentStart:  period.CurrentStart,
+		CurrentEnd:    period.CurrentEnd,
+		PreviousStart: period.PreviousStart,
+		PreviousEnd:   period.PreviousEnd,
+	}
+}
+
+func mapCampaignSegments(segments []searchcampaigns.CampaignSegment) []CampaignSegmentOutput {
+	if len(segments) == 0 {
+		return nil
+	}
+
+	normalized := make([]CampaignSegmentOutput, 0, len(segments))
+	for _, segment := range segments {
+		normalized = append(normalized, CampaignSegmentOutput{
+			Value:   segment.Value,
+			Metrics: mapCampaignMetrics(segment.Metrics),
+		})
+	}
+
+	return normalized
+}
+
+func mapCampaignMetrics(metrics searchcampaigns.CampaignMetrics) CampaignMetrics {
+	return CampaignMetrics{
+		Clicks:                             metrics.Clicks,
+		Impressions:                        metrics.Impressions,
+		CTR:                                metrics.CTR,
+		AverageCPC:                         currency.Micros(metrics.AverageCPC),
+		CostMicros:                         currency.Micros(metrics.CostMicros),
+		Conversions:                        metrics.Conversions,
+		ConversionsValue:                   metrics.ConversionsValue,
+		CostPerConversion:                  currency.RoundMicros(metrics.CostPerConversion),
+		ConversionRate:                     metrics.ConversionRate,
+		AllConversions:                     metrics.AllConversions,
+		AllConversionsValue:                metrics.AllConversionsValue,
+		AllConversionsFromInteractionsRate: metrics.AllConversionsFromInteractionsRate,
+		CostPerAllConversions:              currency.RoundMicros(metrics.CostPerAllConversions),
+		Interactions:                       metrics.Interactions,
+		EngagementRate:                     metrics.EngagementRate,
+		SearchImpressionShare:              metrics.SearchImpressionShare,
+		SearchRankLostImpressionShare:      metrics.SearchRankLostImpressionShare,
+		ROAS:                               metrics.KPIs.ROAS,
+		CPAMicros:                          currency.Micros(metrics.KPIs.CPAMicros),
+		CPMMicros:                          currency.Micros(metrics.KPIs.CPMMicros),
+		CostPerInteractionMicros:           currency.Micros(metrics.KPIs.CostPerInteractionMicros),
+		ValuePerClick:                      metrics.KPIs.ValuePerClick,
+	}
+}
+
+// campaignTotals rolls up the metrics of every campaign for the truncation summary,
+// across segments when the query is segmented.
+func campaignTotals(campaigns []searchcampaigns.Campaign) *responsebudget.Totals {
+	inputs := make([]kpi.Inputs, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		inputs = append(inputs, campaignInputs(campaign.Metrics))
+		for _, segment := range campaign.Segments {
+			inputs = append(inputs, campaignInputs(segment.Metrics))
+		}
+	}
+	return responsebudget.Sum(len(campaigns), inputs)
+}
+
+func campaignInputs(metrics searchcampaigns.CampaignMetrics) kpi.Inputs {
+	return kpi.Inputs{
+		Clicks:           metrics.Clicks,
+		Impressions:      metrics.Impressions,
+		Interactions:     metrics.Interactions,
+		CostMicros:       metrics.CostMicros,
+		Conversions:      metrics.Conversions,
+		ConversionsValue: metrics.ConversionsValue,
+	}
+}
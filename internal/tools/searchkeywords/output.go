@@ -0,0 +1,86 @@
+package searchkeywords
+
+import (
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+)
+
+// ToolOutput captures the structured response returned to the MCP client.
+type ToolOutput struct {
+	Keywords      []KeywordOutput `json:"keywords"`
+	NextPageToken string          `json:"next_page_token,omitempty"`
+	TotalCount    int64           `json:"total_count"`
+	Cache         string          `json:"cache,omitempty"`
+	responsebudget.Truncation
+
+	fields projection.Selection
+}
+
+// MarshalJSON serializes only the keyword fields selected by the fields input.
+func (o ToolOutput) MarshalJSON() ([]byte, error) {
+	type toolOutput ToolOutput
+	return o.fields.Marshal(toolOutput(o), "keywords")
+}
+
+// KeywordOutput mirrors the normalized keyword representation returned to clients.
+type KeywordOutput struct {
+	CriterionID           string         `json:"criterion_id"`
+	ResourceName          string         `json:"resource_name"`
+	Text                  string         `json:"text"`
+	MatchType             string         `json:"match_type"`
+	Status                string         `json:"status"`
+	CPCBidMicros          *int64         `json:"cpc_bid_micros,omitempty"`
+	CPCBid                string         `json:"cpc_bid,omitempty"`
+	EffectiveCPCBidMicros *int64         `json:"effective_cpc_bid_micros,omitempty"`
+	EffectiveCPCBid       string         `json:"effective_cpc_bid,omitempty"`
+	CampaignID            string         `json:"campaign_id"`
+	CampaignName          string         `json:"campaign_name"`
+	CampaignResourceName  string         `json:"campaign_resource_name"`
+	AdGroupID             string         `json:"ad_group_id"`
+	AdGroupName           string         `json:"ad_group_name"`
+	AdGroupResourceName   string         `json:"ad_group_resource_name"`
+	QualityInfo           QualityInfo    `json:"quality_info"`
+	Metrics               KeywordMetrics `json:"metrics"`
+}
+
+// QualityInfo represents the quality score and its components for a keyword.
+type QualityInfo struct {
+	QualityScore          int32  `json:"quality_score,omitempty"`
+	CreativeQualityScore  string `json:"creative_quality_score,omitempty"`
+	PostClickQualityScore string `json:"post_click_quality_score,omitempty"`
+	SearchPredictedCTR    string `json:"search_predicted_ctr,omitempty"`
+}
+
+// KeywordMetrics represents metrics for a keyword. Money amounts are returned in
+// micros, as decimal amounts in the account currency, or both, per money_format.
+type KeywordMetrics struct {
+	Clicks                             int64   `json:"clicks"`
+	Impressions                        int64   `json:"impressions"`
+	CTR                                float64 `json:"ctr"`
+	AverageCPC                         *int64  `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount                   string  `json:"average_cpc,omitempty"`
+	CostMicros                         *int64  `json:"cost_micros,omitempty"`
+	Cost                               string  `json:"cost,omitempty"`
+	Conversions                        float64 `json:"conversions"`
+	ConversionsValue                   float64 `json:"conversions_value"`
+	CostPerConversion                  *int64  `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount            string  `json:"cost_per_conversion,omitempty"`
+	ConversionRate                     float64 `json:"conversion_rate"`
+	AllConversions                     float64 `json:"all_conversions"`
+	AllConversionsValue                float64 `json:"all_conversions_value"`
+	AllConversionsFromInteractionsRate float64 `json:"all_conversions_from_interactions_rate"`
+	CostPerAllConversions              *int64  `json:"cost_per_all_conversions_micros,omitempty"`
+	CostPerAllConversionsAmount        string  `json:"cost_per_all_conversions,omitempty"`
+	Interactions                       int64   `json:"interactions"`
+	EngagementRate                     float64 `json:"engagement_rate"`
+	SearchImpressionShare              float64 `json:"search_impression_share"`
+	SearchRankLostImpressionShare      float64 `json:"search_rank_lost_impression_share"`
+	ROAS                               float64 `json:"roas"`
+	CPAMicros                          *int64  `json:"cpa_micros,omitempty"`
+	CPA                                string  `json:"cpa,omitempty"`
+	CPMMicros                          *int64  `json:"cpm_micros,omitempty"`
+	CPM                                string  `json:"cpm,omitempty"`
+	CostPerInteractionMicros           *int64  `json:"cost_per_interaction_micros,omitempty"`
+	CostPerInteraction                 string  `json:"cost_per_interaction,omitempty"`
+	ValuePerClick                      float64 `json:"value_per_click"`
+}
@@ -0,0 +1,184 @@
+package searchkeywords
+
+import (
+	"context"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/currency"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+	"google-ads-mcp/internal/infrastructure/api/searchkeywords"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var validate = validator.New()
+
+type Tool struct {
+	service        *searchkeywords.Service
+	currencyLookup currency.Lookup
+	budget         responsebudget.Budget
+}
+
+func NewSearchKeywordsTool(service *searchkeywords.Service, currencyLookup currency.Lookup, budget responsebudget.Budget) *Tool {
+	return &Tool{
+		service:        service,
+		currencyLookup: currencyLookup,
+		budget:         budget,
+	}
+}
+
+func (t *Tool) SearchKeywords(ctx context.Context, req *mcp.CallToolRequest, input ToolInput) (*mcp.CallToolResult, ToolOutput, error) {
+	if req.Params.Arguments == nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchkeywords: arguments payload is required")
+	}
+
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchkeywords: validation error: %w", err)
+	}
+
+	moneyFormat, err := currency.ParseMoneyFormat(input.MoneyFormat)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchkeywords: %w", err)
+	}
+
+	fields, err := searchkeywords.Fields.Select(input.Fields)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchkeywords: %w", err)
+	}
+
+	filters := mapInputToFilters(input, fields)
+
+	result, err := t.service.SearchKeywords(ctx, filters)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, err
+	}
+
+	output := ToolOutput{
+		Keywords:      mapKeywords(result.Keywords),
+		NextPageToken: result.NextPageToken,
+		TotalCount:    result.TotalResultsCount,
+		Cache:         string(result.Cache),
+		fields:        fields,
+	}
+
+	if moneyFormat.IncludesDecimal() {
+		code, err := t.currencyLookup.CurrencyCode(ctx, input.CustomerID, input.LoginCustomerID)
+		if err != nil {
+			return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchkeywords: %w", err)
+		}
+		currency.Apply(output.Keywords, moneyFormat, code)
+	}
+
+	data, err := responsebudget.Marshal(t.budget, &output, &output.Keywords, &output.Truncation, func() *responsebudget.Totals { return keywordTotals(result.Keywords) })
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchkeywords: marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, output, nil
+}
+
+func mapInputToFilters(input ToolInput, fields projection.Selection) searchkeywords.Filters {
+	return searchkeywords.Filters{
+		CustomerID:      input.CustomerID,
+		LoginCustomerID: input.LoginCustomerID,
+		CampaignIDs:     input.CampaignIDs,
+		CampaignNames:   input.CampaignNames,
+		AdGroupIDs:      input.AdGroupIDs,
+		AdGroupNames:    input.AdGroupNames,
+		Statuses:        input.Statuses,
+		MatchTypes:      input.MatchTypes,
+		MinCostMicros:   input.MinCostMicros,
+		MinImpressions:  input.MinImpressions,
+		MinClicks:       input.MinClicks,
+		MinConversions:  input.MinConversions,
+		MaxCPAMicros:    input.MaxCPAMicros,
+		OrderBy:         input.OrderBy,
+		Limit:           input.Limit,
+		DateRangeStart:  input.DateRangeStart,
+		DateRangeEnd:    input.DateRangeEnd,
+		PageToken:       input.PageToken,
+		PageSize:        input.PageSize,
+		FetchAll:        input.FetchAll,
+		BypassCache:     input.BypassCache,
+		Fields:          fields,
+	}
+}
+
+func mapKeywords(keywords []searchkeywords.Keyword) []KeywordOutput {
+	normalized := make([]KeywordOutput, 0, len(keywords))
+	for _, kw := range keywords {
+		normalized = append(normalized, KeywordOutput{
+			CriterionID:           kw.CriterionID,
+			ResourceName:          kw.ResourceName,
+			Text:                  kw.Text,
+			MatchType:             kw.MatchType,
+			Status:                kw.Status,
+			CPCBidMicros:          currency.Micros(kw.CPCBidMicros),
+			EffectiveCPCBidMicros: currency.Micros(kw.EffectiveCPCBidMicros),
+			CampaignID:            kw.CampaignID,
+			CampaignName:          kw.CampaignName,
+			CampaignResourceName:  kw.CampaignResourceName,
+			AdGroupID:             kw.AdGroupID,
+			AdGroupName:           kw.AdGroupName,
+			AdGroupResourceName:   kw.AdGroupResourceName,
+			QualityInfo: QualityInfo{
+				QualityScore:          kw.QualityInfo.QualityScore,
+				CreativeQualityScore:  kw.QualityInfo.CreativeQualityScore,
+				PostClickQualityScore: kw.QualityInfo.PostClickQualityScore,
+				SearchPredictedCTR:    kw.QualityInfo.SearchPredictedCTR,
+			},
+			Metrics: KeywordMetrics{
+				Clicks:                             kw.Metrics.Clicks,
+				Impressions:                        kw.Metrics.Impressions,
+				CTR:                                kw.Metrics.CTR,
+				AverageCPC:                         currency.Micros(kw.Metrics.AverageCPC),
+				CostMicros:                         currency.Micros(kw.Metrics.CostMicros),
+				Conversions:                        kw.Metrics.Conversions,
+				ConversionsValue:                   kw.Metrics.ConversionsValue,
+				CostPerConversion:                  currency.RoundMicros(kw.Metrics.CostPerConversion),
+				ConversionRate:                     kw.Metrics.ConversionRate,
+				AllConversions:                     kw.Metrics.AllConversions,
+				AllConversionsValue:                kw.Metrics.AllConversionsValue,
+				AllConversionsFromInteractionsRate: kw.Metrics.AllConversionsFromInteractionsRate,
+				CostPerAllConversions:              currency.RoundMicros(kw.Metrics.CostPerAllConversions),
+				Interactions:                       kw.Metrics.Interactions,
+				EngagementRate:                     kw.Metrics.EngagementRate,
+				SearchImpressionShare:              kw.Metrics.SearchImpressionShare,
+				SearchRankLostImpressionShare:      kw.Metrics.SearchRankLostImpressionShare,
+				ROAS:                               kw.Metrics.KPIs.ROAS,
+				CPAMicros:                          currency.Micros(kw.Metrics.KPIs.CPAMicros),
+				CPMMicros:                          currency.Micros(kw.Metrics.KPIs.CPMMicros),
+				CostPerInteractionMicros:           currency.Micros(kw.Metrics.KPIs.CostPerInteractionMicros),
+				ValuePerClick:                      kw.Metrics.KPIs.ValuePerClick,
+			},
+		})
+	}
+
+	return normalized
+}
+
+// keywordTotals rolls up the metrics of every keyword for the truncation summary.
+func keywordTotals(keywords []searchkeywords.Keyword) *responsebudget.Totals {
+	inputs := make([]kpi.Inputs, 0, len(keywords))
+	for _, keyword := range keywords {
+		inputs = append(inputs, keywordInputs(keyword.Metrics))
+	}
+	return responsebudget.Sum(len(keywords), inputs)
+}
+
+func keywordInputs(metrics searchkeywords.KeywordMetrics) kpi.Inputs {
+	return kpi.Inputs{
+		Clicks:           metrics.Clicks,
+		Impressions:      metrics.Impressions,
+		Interactions:     metrics.Interactions,
+		CostMicros:       metrics.CostMicros,
+		Conversions:      metrics.Conversions,
+		ConversionsValue: metrics.ConversionsValue,
+	}
+}
@@ -0,0 +1,68 @@
+package searchsearchterms
+
+import (
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+)
+
+// ToolOutput captures the structured response returned to the MCP client.
+type ToolOutput struct {
+	SearchTerms   []SearchTermOutput `json:"search_terms"`
+	NextPageToken string             `json:"next_page_token,omitempty"`
+	TotalCount    int64              `json:"total_count"`
+	Cache         string             `json:"cache,omitempty"`
+	responsebudget.Truncation
+
+	fields projection.Selection
+}
+
+// MarshalJSON serializes only the search term fields selected by the fields input.
+func (o ToolOutput) MarshalJSON() ([]byte, error) {
+	type toolOutput ToolOutput
+	return o.fields.Marshal(toolOutput(o), "search_terms")
+}
+
+// SearchTermOutput mirrors the normalized search term representation returned to clients.
+type SearchTermOutput struct {
+	SearchTerm           string            `json:"search_term"`
+	ResourceName         string            `json:"resource_name"`
+	Status               string            `json:"status"`
+	KeywordText          string            `json:"keyword_text,omitempty"`
+	KeywordMatchType     string            `json:"keyword_match_type,omitempty"`
+	KeywordResourceName  string            `json:"keyword_resource_name,omitempty"`
+	CampaignID           string            `json:"campaign_id"`
+	CampaignName         string            `json:"campaign_name"`
+	CampaignResourceName string            `json:"campaign_resource_name"`
+	AdGroupID            string            `json:"ad_group_id"`
+	AdGroupName          string            `json:"ad_group_name"`
+	AdGroupResourceName  string            `json:"ad_group_resource_name"`
+	Metrics              SearchTermMetrics `json:"metrics"`
+}
+
+// SearchTermMetrics represents metrics for a search term. Money amounts are returned in
+// micros, as decimal amounts in the account currency, or both, per money_format.
+type SearchTermMetrics struct {
+	Clicks                   int64   `json:"clicks"`
+	Impressions              int64   `json:"impressions"`
+	CTR                      float64 `json:"ctr"`
+	AverageCPC               *int64  `json:"average_cpc_micros,omitempty"`
+	AverageCPCAmount         string  `json:"average_cpc,omitempty"`
+	CostMicros               *int64  `json:"cost_micros,omitempty"`
+	Cost                     string  `json:"cost,omitempty"`
+	Conversions              float64 `json:"conversions"`
+	ConversionsValue         float64 `json:"conversions_value"`
+	CostPerConversion        *int64  `json:"cost_per_conversion_micros,omitempty"`
+	CostPerConversionAmount  string  `json:"cost_per_conversion,omitempty"`
+	ConversionRate           float64 `json:"conversion_rate"`
+	AllConversions           float64 `json:"all_conversions"`
+	AllConversionsValue      float64 `json:"all_conversions_value"`
+	Interactions             int64   `json:"interactions"`
+	ROAS                     float64 `json:"roas"`
+	CPAMicros                *int64  `json:"cpa_micros,omitempty"`
+	CPA                      string  `json:"cpa,omitempty"`
+	CPMMicros                *int64  `json:"cpm_micros,omitempty"`
+	CPM                      string  `json:"cpm,omitempty"`
+	CostPerInteractionMicros *int64  `json:"cost_per_interaction_micros,omitempty"`
+	CostPerInteraction       string  `json:"cost_per_interaction,omitempty"`
+	ValuePerClick            float64 `json:"value_per_click"`
+}
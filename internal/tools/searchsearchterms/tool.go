@@ -0,0 +1,172 @@
+package searchsearchterms
+
+import (
+	"context"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/currency"
+	"google-ads-mcp/internal/infrastructure/api/kpi"
+	"google-ads-mcp/internal/infrastructure/api/projection"
+	"google-ads-mcp/internal/infrastructure/api/responsebudget"
+	"google-ads-mcp/internal/infrastructure/api/searchsearchterms"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var validate = validator.New()
+
+type Tool struct {
+	service        *searchsearchterms.Service
+	currencyLookup currency.Lookup
+	budget         responsebudget.Budget
+}
+
+func NewSearchSearchTermsTool(service *searchsearchterms.Service, currencyLookup currency.Lookup, budget responsebudget.Budget) *Tool {
+	return &Tool{
+		service:        service,
+		currencyLookup: currencyLookup,
+		budget:         budget,
+	}
+}
+
+func (t *Tool) SearchSearchTerms(ctx context.Context, req *mcp.CallToolRequest, input ToolInput) (*mcp.CallToolResult, ToolOutput, error) {
+	if req.Params.Arguments == nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchsearchterms: arguments payload is required")
+	}
+
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchsearchterms: validation error: %w", err)
+	}
+
+	moneyFormat, err := currency.ParseMoneyFormat(input.MoneyFormat)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchsearchterms: %w", err)
+	}
+
+	fields, err := searchsearchterms.Fields.Select(input.Fields)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchsearchterms: %w", err)
+	}
+
+	filters := mapInputToFilters(input, fields)
+
+	result, err := t.service.SearchSearchTerms(ctx, filters)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, err
+	}
+
+	output := ToolOutput{
+		SearchTerms:   mapSearchTerms(result.SearchTerms),
+		NextPageToken: result.NextPageToken,
+		TotalCount:    result.TotalResultsCount,
+		Cache:         string(result.Cache),
+		fields:        fields,
+	}
+
+	if moneyFormat.IncludesDecimal() {
+		code, err := t.currencyLookup.CurrencyCode(ctx, input.CustomerID, input.LoginCustomerID)
+		if err != nil {
+			return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchsearchterms: %w", err)
+		}
+		currency.Apply(output.SearchTerms, moneyFormat, code)
+	}
+
+	data, err := responsebudget.Marshal(t.budget, &output, &output.SearchTerms, &output.Truncation, func() *responsebudget.Totals { return searchTermTotals(result.SearchTerms) })
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("searchsearchterms: marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, output, nil
+}
+
+func mapInputToFilters(input ToolInput, fields projection.Selection) searchsearchterms.Filters {
+	return searchsearchterms.Filters{
+		CustomerID:         input.CustomerID,
+		LoginCustomerID:    input.LoginCustomerID,
+		CampaignIDs:        input.CampaignIDs,
+		CampaignNames:      input.CampaignNames,
+		AdGroupIDs:         input.AdGroupIDs,
+		AdGroupNames:       input.AdGroupNames,
+		Statuses:           input.Statuses,
+		MinCostMicros:      input.MinCostMicros,
+		MinClicks:          input.MinClicks,
+		ZeroConversionOnly: input.ZeroConversionOnly,
+		MinImpressions:     input.MinImpressions,
+		MinConversions:     input.MinConversions,
+		MaxCPAMicros:       input.MaxCPAMicros,
+		OrderBy:            input.OrderBy,
+		Limit:              input.Limit,
+		DateRangeStart:     input.DateRangeStart,
+		DateRangeEnd:       input.DateRangeEnd,
+		PageToken:          input.PageToken,
+		PageSize:           input.PageSize,
+		FetchAll:           input.FetchAll,
+		BypassCache:        input.BypassCache,
+		Fields:             fields,
+	}
+}
+
+func mapSearchTerms(searchTerms []searchsearchterms.SearchTerm) []SearchTermOutput {
+	normalized := make([]SearchTermOutput, 0, len(searchTerms))
+	for _, st := range searchTerms {
+		normalized = append(normalized, SearchTermOutput{
+			SearchTerm:           st.SearchTerm,
+			ResourceName:         st.ResourceName,
+			Status:               st.Status,
+			KeywordText:          st.KeywordText,
+			KeywordMatchType:     st.KeywordMatchType,
+			KeywordResourceName:  st.KeywordResourceName,
+			CampaignID:           st.CampaignID,
+			CampaignName:         st.CampaignName,
+			CampaignResourceName: st.CampaignResourceName,
+			AdGroupID:            st.AdGroupID,
+			AdGroupName:          st.AdGroupName,
+			AdGroupResourceName:  st.AdGroupResourceName,
+			Metrics: SearchTermMetrics{
+				Clicks:                   st.Metrics.Clicks,
+				Impressions:              st.Metrics.Impressions,
+				CTR:                      st.Metrics.CTR,
+				AverageCPC:               currency.Micros(st.Metrics.AverageCPC),
+				CostMicros:               currency.Micros(st.Metrics.CostMicros),
+				Conversions:              st.Metrics.Conversions,
+				ConversionsValue:         st.Metrics.ConversionsValue,
+				CostPerConversion:        currency.RoundMicros(st.Metrics.CostPerConversion),
+				ConversionRate:           st.Metrics.ConversionRate,
+				AllConversions:           st.Metrics.AllConversions,
+				AllConversionsValue:      st.Metrics.AllConversionsValue,
+				Interactions:             st.Metrics.Interactions,
+				ROAS:                     st.Metrics.KPIs.ROAS,
+				CPAMicros:                currency.Micros(st.Metrics.KPIs.CPAMicros),
+				CPMMicros:                currency.Micros(st.Metrics.KPIs.CPMMicros),
+				CostPerInteractionMicros: currency.Micros(st.Metrics.KPIs.CostPerInteractionMicros),
+				ValuePerClick:            st.Metrics.KPIs.ValuePerClick,
+			},
+		})
+	}
+
+	return normalized
+}
+
+// searchTermTotals rolls up the metrics of every search term for the truncation summary.
+func searchTermTotals(searchTerms []searchsearchterms.SearchTerm) *responsebudget.Totals {
+	inputs := make([]kpi.Inputs, 0, len(searchTerms))
+	for _, searchTerm := range searchTerms {
+		inputs = append(inputs, searchTermInputs(searchTerm.Metrics))
+	}
+	return responsebudget.Sum(len(searchTerms), inputs)
+}
+
+func searchTermInputs(metrics searchsearchterms.SearchTermMetrics) kpi.Inputs {
+	return kpi.Inputs{
+		Clicks:           metrics.Clicks,
+		Impressions:      metrics.Impressions,
+		Interactions:     metrics.Interactions,
+		CostMicros:       metrics.CostMicros,
+		Conversions:      metrics.Conversions,
+		ConversionsValue: metrics.ConversionsValue,
+	}
+}
@@ -0,0 +1,10 @@
+package setcampaignstatus
+
+// ToolInput defines the parameters accepted by the MCP tool.
+type ToolInput struct {
+	CustomerID      string   `json:"customer_id" validate:"required"`
+	LoginCustomerID string   `json:"login_customer_id,omitempty"`
+	CampaignIDs     []string `json:"campaign_ids" validate:"required,min=1"`
+	Status          string   `json:"status" validate:"required"`
+	ValidateOnly    bool     `json:"validate_only,omitempty"`
+}
@@ -0,0 +1,27 @@
+package setcampaignstatus
+
+// ToolOutput captures the structured response returned to the MCP client.
+type ToolOutput struct {
+	Status         string                  `json:"status"`
+	ValidateOnly   bool                    `json:"validate_only"`
+	Results        []OperationResultOutput `json:"results"`
+	RequestErrors  []OperationErrorOutput  `json:"request_errors,omitempty"`
+	SucceededCount int                     `json:"succeeded_count"`
+	FailedCount    int                     `json:"failed_count"`
+	RequestID      string                  `json:"request_id,omitempty"`
+}
+
+// OperationResultOutput reports the outcome of the update of a single campaign.
+type OperationResultOutput struct {
+	Index        int                    `json:"index"`
+	CampaignID   string                 `json:"campaign_id"`
+	ResourceName string                 `json:"resource_name,omitempty"`
+	Success      bool                   `json:"success"`
+	Errors       []OperationErrorOutput `json:"errors,omitempty"`
+}
+
+// OperationErrorOutput is an API error attached to one operation.
+type OperationErrorOutput struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
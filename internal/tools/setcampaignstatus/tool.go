@@ -0,0 +1,101 @@
+package setcampaignstatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/setcampaignstatus"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var validate = validator.New()
+
+type Tool struct {
+	service *setcampaignstatus.Service
+}
+
+func NewSetCampaignStatusTool(service *setcampaignstatus.Service) *Tool {
+	return &Tool{
+		service: service,
+	}
+}
+
+func (t *Tool) SetCampaignStatus(ctx context.Context, req *mcp.CallToolRequest, input ToolInput) (*mcp.CallToolResult, ToolOutput, error) {
+	if req.Params.Arguments == nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("setcampaignstatus: arguments payload is required")
+	}
+
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("setcampaignstatus: validation error: %w", err)
+	}
+
+	result, err := t.service.SetCampaignStatus(ctx, mapInputToRequest(input))
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, err
+	}
+
+	output := ToolOutput{
+		Status:        result.Status,
+		ValidateOnly:  result.ValidateOnly,
+		Results:       mapOperationResults(result.Operations),
+		RequestErrors: mapOperationErrors(result.RequestErrors),
+		RequestID:     result.RequestID,
+	}
+	for _, operation := range output.Results {
+		if operation.Success {
+			output.SucceededCount++
+		} else {
+			output.FailedCount++
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("setcampaignstatus: marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, output, nil
+}
+
+func mapInputToRequest(input ToolInput) setcampaignstatus.Request {
+	return setcampaignstatus.Request{
+		CustomerID:      input.CustomerID,
+		LoginCustomerID: input.LoginCustomerID,
+		CampaignIDs:     input.CampaignIDs,
+		Status:          input.Status,
+		ValidateOnly:    input.ValidateOnly,
+	}
+}
+
+func mapOperationResults(operations []setcampaignstatus.OperationResult) []OperationResultOutput {
+	normalized := make([]OperationResultOutput, 0, len(operations))
+	for _, operation := range operations {
+		normalized = append(normalized, OperationResultOutput{
+			Index:        operation.Index,
+			CampaignID:   operation.CampaignID,
+			ResourceName: operation.ResourceName,
+			Success:      operation.Success,
+			Errors:       mapOperationErrors(operation.Errors),
+		})
+	}
+
+	return normalized
+}
+
+func mapOperationErrors(errs []setcampaignstatus.OperationError) []OperationErrorOutput {
+	var normalized []OperationErrorOutput
+	for _, opErr := range errs {
+		normalized = append(normalized, OperationErrorOutput{
+			Code:    opErr.Code,
+			Message: opErr.Message,
+		})
+	}
+
+	return normalized
+}
@@ -0,0 +1,11 @@
+package updatecampaignbudget
+
+// ToolInput defines the parameters accepted by the MCP tool.
+type ToolInput struct {
+	CustomerID      string `json:"customer_id" validate:"required"`
+	LoginCustomerID string `json:"login_customer_id,omitempty"`
+	CampaignID      string `json:"campaign_id" validate:"required"`
+	AmountMicros    int64  `json:"amount_micros" validate:"required,gt=0"`
+	ValidateOnly    bool   `json:"validate_only,omitempty"`
+	MoneyFormat     string `json:"money_format,omitempty" validate:"omitempty,oneof=micros decimal both"`
+}
@@ -0,0 +1,31 @@
+package updatecampaignbudget
+
+// ToolOutput captures the structured response returned to the MCP client. Budget
+// amounts are returned in micros, as decimal amounts in the account currency, or
+// both, per money_format.
+type ToolOutput struct {
+	CampaignID         string                 `json:"campaign_id"`
+	CampaignName       string                 `json:"campaign_name"`
+	BudgetID           string                 `json:"budget_id"`
+	BudgetName         string                 `json:"budget_name"`
+	BudgetResourceName string                 `json:"budget_resource_name"`
+	OldAmountMicros    *int64                 `json:"old_amount_micros,omitempty"`
+	OldAmount          string                 `json:"old_amount,omitempty"`
+	NewAmountMicros    *int64                 `json:"new_amount_micros,omitempty"`
+	NewAmount          string                 `json:"new_amount,omitempty"`
+	ChangeMicros       *int64                 `json:"change_micros,omitempty"`
+	Change             string                 `json:"change,omitempty"`
+	ChangePercent      float64                `json:"change_percent"`
+	ValidateOnly       bool                   `json:"validate_only"`
+	Shared             bool                   `json:"shared"`
+	ReferenceCount     int64                  `json:"reference_count"`
+	SharedCampaigns    []SharedCampaignOutput `json:"shared_campaigns,omitempty"`
+	Warnings           []string               `json:"warnings,omitempty"`
+	RequestID          string                 `json:"request_id,omitempty"`
+}
+
+// SharedCampaignOutput is another campaign affected by a change to a shared budget.
+type SharedCampaignOutput struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
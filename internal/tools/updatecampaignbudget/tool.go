@@ -0,0 +1,109 @@
+package updatecampaignbudget
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google-ads-mcp/internal/infrastructure/api/currency"
+	"google-ads-mcp/internal/infrastructure/api/updatecampaignbudget"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+var validate = validator.New()
+
+type Tool struct {
+	service        *updatecampaignbudget.Service
+	currencyLookup currency.Lookup
+}
+
+func NewUpdateCampaignBudgetTool(service *updatecampaignbudget.Service, currencyLookup currency.Lookup) *Tool {
+	return &Tool{
+		service:        service,
+		currencyLookup: currencyLookup,
+	}
+}
+
+func (t *Tool) UpdateCampaignBudget(ctx context.Context, req *mcp.CallToolRequest, input ToolInput) (*mcp.CallToolResult, ToolOutput, error) {
+	if req.Params.Arguments == nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("updatecampaignbudget: arguments payload is required")
+	}
+
+	// Validate input
+	if err := validate.Struct(input); err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("updatecampaignbudget: validation error: %w", err)
+	}
+
+	moneyFormat, err := currency.ParseMoneyFormat(input.MoneyFormat)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("updatecampaignbudget: %w", err)
+	}
+
+	result, err := t.service.UpdateCampaignBudget(ctx, mapInputToRequest(input))
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, err
+	}
+
+	output := ToolOutput{
+		CampaignID:         result.CampaignID,
+		CampaignName:       result.CampaignName,
+		BudgetID:           result.BudgetID,
+		BudgetName:         result.BudgetName,
+		BudgetResourceName: result.BudgetResourceName,
+		OldAmountMicros:    currency.Micros(result.OldAmountMicros),
+		NewAmountMicros:    currency.Micros(result.NewAmountMicros),
+		ChangeMicros:       currency.Micros(result.ChangeMicros),
+		ChangePercent:      result.ChangePercent,
+		ValidateOnly:       result.ValidateOnly,
+		Shared:             result.Shared,
+		ReferenceCount:     result.ReferenceCount,
+		SharedCampaigns:    mapSharedCampaigns(result.SharedCampaigns),
+		Warnings:           result.Warnings,
+		RequestID:          result.RequestID,
+	}
+
+	if moneyFormat.IncludesDecimal() {
+		code, err := t.currencyLookup.CurrencyCode(ctx, input.CustomerID, input.LoginCustomerID)
+		if err != nil {
+			return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("updatecampaignbudget: %w", err)
+		}
+		currency.Apply(&output, moneyFormat, code)
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return &mcp.CallToolResult{}, ToolOutput{}, fmt.Errorf("updatecampaignbudget: marshal response: %v", err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: string(data)}},
+	}, output, nil
+}
+
+func mapInputToRequest(input ToolInput) updatecampaignbudget.Request {
+	return updatecampaignbudget.Request{
+		CustomerID:      input.CustomerID,
+		LoginCustomerID: input.LoginCustomerID,
+		CampaignID:      input.CampaignID,
+		AmountMicros:    input.AmountMicros,
+		ValidateOnly:    input.ValidateOnly,
+	}
+}
+
+func mapSharedCampaigns(campaigns []updatecampaignbudget.SharedCampaign) []SharedCampaignOutput {
+	if len(campaigns) == 0 {
+		return nil
+	}
+
+	normalized := make([]SharedCampaignOutput, 0, len(campaigns))
+	for _, campaign := range campaigns {
+		normalized = append(normalized, SharedCampaignOutput{
+			ID:   campaign.ID,
+			Name: campaign.Name,
+		})
+	}
+
+	return normalized
+}